@@ -0,0 +1,98 @@
+// Package blob contains implementations for storing uploaded files.
+package blob
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+var errInvalidKey = errors.New("invalid blob key")
+
+// FileSystem stores blobs as files under a directory which is served from a public url.
+type FileSystem struct {
+	dir       string
+	publicURL string
+}
+
+// NewFileSystem is a construction function for FileSystem.
+func NewFileSystem(dir, publicURL string) *FileSystem {
+	return &FileSystem{
+		dir:       dir,
+		publicURL: strings.TrimSuffix(publicURL, "/"),
+	}
+}
+
+// Put writes the content of r to the file of the given key, replacing any previous content.
+// The file becomes visible only after all of it is written.
+func (fs *FileSystem) Put(ctx context.Context, key string, r io.Reader) (err error) {
+	path, err := fs.path(key)
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("creating blob directory: %w", err)
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".upload-*")
+	if err != nil {
+		return fmt.Errorf("creating blob file: %w", err)
+	}
+
+	defer func() {
+		if err != nil {
+			_ = tmp.Close()
+			_ = os.Remove(tmp.Name())
+		}
+	}()
+
+	if _, err = io.Copy(tmp, r); err != nil {
+		return fmt.Errorf("writing blob file: %w", err)
+	}
+
+	if err = tmp.Close(); err != nil {
+		return fmt.Errorf("writing blob file: %w", err)
+	}
+
+	if err = os.Chmod(tmp.Name(), 0o644); err != nil {
+		return fmt.Errorf("writing blob file: %w", err)
+	}
+
+	if err = os.Rename(tmp.Name(), path); err != nil {
+		return fmt.Errorf("writing blob file: %w", err)
+	}
+
+	return nil
+}
+
+// DeleteAll removes the blob of the given key along with every blob nested under it.
+func (fs *FileSystem) DeleteAll(ctx context.Context, key string) error {
+	path, err := fs.path(key)
+	if err != nil {
+		return err
+	}
+
+	if err := os.RemoveAll(path); err != nil {
+		return fmt.Errorf("deleting blob: %w", err)
+	}
+
+	return nil
+}
+
+// URL returns the public url of the blob of the given key.
+func (fs *FileSystem) URL(key string) string {
+	return fs.publicURL + "/" + key
+}
+
+func (fs *FileSystem) path(key string) (string, error) {
+	if key == "" || !filepath.IsLocal(filepath.FromSlash(key)) {
+		return "", fmt.Errorf("%w: %q", errInvalidKey, key)
+	}
+
+	return filepath.Join(fs.dir, filepath.FromSlash(key)), nil
+}
@@ -0,0 +1,41 @@
+package blob_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/dragonator/rental-service/module/rental/internal/blob"
+)
+
+func TestFileSystem(t *testing.T) {
+	dir := t.TempDir()
+	fs := blob.NewFileSystem(dir, "http://localhost:9090/uploads/")
+
+	if err := fs.Put(context.Background(), "rentals/1/abc/original.jpg", strings.NewReader("content")); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	content, err := os.ReadFile(filepath.Join(dir, "rentals", "1", "abc", "original.jpg"))
+	if err != nil || string(content) != "content" {
+		t.Fatalf("Unexpected blob content: %q (%v)", content, err)
+	}
+
+	if url := fs.URL("rentals/1/abc/original.jpg"); url != "http://localhost:9090/uploads/rentals/1/abc/original.jpg" {
+		t.Fatalf("Unexpected url: %s", url)
+	}
+
+	if err := fs.DeleteAll(context.Background(), "rentals/1/abc"); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if _, err := os.Stat(filepath.Join(dir, "rentals", "1", "abc")); !os.IsNotExist(err) {
+		t.Fatalf("Expected the blobs to be deleted, got: %v", err)
+	}
+
+	if err := fs.Put(context.Background(), "../outside.jpg", strings.NewReader("content")); err == nil {
+		t.Fatalf("Expected an error for a key outside of the directory")
+	}
+}
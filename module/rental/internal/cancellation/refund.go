@@ -0,0 +1,56 @@
+// Package cancellation computes the refunds of cancelled bookings.
+package cancellation
+
+import (
+	"time"
+
+	"github.com/dragonator/rental-service/module/rental/internal/model"
+)
+
+const day = 24 * time.Hour
+
+// Refund is the part of the amount paid for a booking which is returned when the booking is cancelled.
+type Refund struct {
+	Policy  model.CancellationPolicy
+	Percent int
+	Amount  int64
+}
+
+// tier refunds percent of the paid amount of bookings cancelled at least notice before check-in.
+type tier struct {
+	notice  time.Duration
+	percent int
+}
+
+// tiers lists the refund tiers of every cancellation policy, from the longest notice.
+// Bookings cancelled with a shorter notice than the last tier are not refunded.
+var tiers = map[model.CancellationPolicy][]tier{
+	model.CancellationPolicyFlexible: {
+		{notice: day, percent: 100},
+	},
+	model.CancellationPolicyModerate: {
+		{notice: 5 * day, percent: 100},
+		{notice: 0, percent: 50},
+	},
+	model.CancellationPolicyStrict: {
+		{notice: 7 * day, percent: 50},
+	},
+}
+
+// ComputeRefund returns the refund of the paid amount of a booking under the policy when the booking is
+// cancelled at the given time. Check-in is at the start of the first night of the booking.
+func ComputeRefund(policy model.CancellationPolicy, booking *model.Booking, paid int64, cancelledAt time.Time) *Refund {
+	refund := &Refund{Policy: policy}
+	notice := booking.StartDate.Sub(cancelledAt)
+
+	for _, t := range tiers[policy] {
+		if notice >= t.notice {
+			refund.Percent = t.percent
+			refund.Amount = paid * int64(t.percent) / 100
+
+			break
+		}
+	}
+
+	return refund
+}
@@ -0,0 +1,78 @@
+package cancellation_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/dragonator/rental-service/module/rental/internal/cancellation"
+	"github.com/dragonator/rental-service/module/rental/internal/model"
+)
+
+func TestComputeRefund(t *testing.T) {
+	booking := &model.Booking{
+		StartDate: time.Date(2023, 7, 10, 0, 0, 0, 0, time.UTC),
+		EndDate:   time.Date(2023, 7, 14, 0, 0, 0, 0, time.UTC),
+	}
+
+	testCases := []struct {
+		name           string
+		policy         model.CancellationPolicy
+		cancelledAt    time.Time
+		expectedResult *cancellation.Refund
+	}{
+		{
+			name:           "Flexible a day before check-in",
+			policy:         model.CancellationPolicyFlexible,
+			cancelledAt:    time.Date(2023, 7, 9, 0, 0, 0, 0, time.UTC),
+			expectedResult: &cancellation.Refund{Policy: model.CancellationPolicyFlexible, Percent: 100, Amount: 50001},
+		},
+		{
+			name:           "Flexible on the day of check-in",
+			policy:         model.CancellationPolicyFlexible,
+			cancelledAt:    time.Date(2023, 7, 9, 12, 0, 0, 0, time.UTC),
+			expectedResult: &cancellation.Refund{Policy: model.CancellationPolicyFlexible},
+		},
+		{
+			name:           "Moderate five days before check-in",
+			policy:         model.CancellationPolicyModerate,
+			cancelledAt:    time.Date(2023, 7, 5, 0, 0, 0, 0, time.UTC),
+			expectedResult: &cancellation.Refund{Policy: model.CancellationPolicyModerate, Percent: 100, Amount: 50001},
+		},
+		{
+			name:           "Moderate before check-in",
+			policy:         model.CancellationPolicyModerate,
+			cancelledAt:    time.Date(2023, 7, 8, 0, 0, 0, 0, time.UTC),
+			expectedResult: &cancellation.Refund{Policy: model.CancellationPolicyModerate, Percent: 50, Amount: 25000},
+		},
+		{
+			name:           "Moderate after check-in",
+			policy:         model.CancellationPolicyModerate,
+			cancelledAt:    time.Date(2023, 7, 11, 0, 0, 0, 0, time.UTC),
+			expectedResult: &cancellation.Refund{Policy: model.CancellationPolicyModerate},
+		},
+		{
+			name:           "Strict a week before check-in",
+			policy:         model.CancellationPolicyStrict,
+			cancelledAt:    time.Date(2023, 7, 3, 0, 0, 0, 0, time.UTC),
+			expectedResult: &cancellation.Refund{Policy: model.CancellationPolicyStrict, Percent: 50, Amount: 25000},
+		},
+		{
+			name:           "Strict less than a week before check-in",
+			policy:         model.CancellationPolicyStrict,
+			cancelledAt:    time.Date(2023, 7, 4, 0, 0, 0, 0, time.UTC),
+			expectedResult: &cancellation.Refund{Policy: model.CancellationPolicyStrict},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			refund := cancellation.ComputeRefund(tc.policy, booking, 50001, tc.cancelledAt)
+
+			if !cmp.Equal(refund, tc.expectedResult) {
+				t.Fatalf("Unexpected refund:\n%s", cmp.Diff(tc.expectedResult, refund))
+			}
+		})
+	}
+}
@@ -0,0 +1,20 @@
+package contract
+
+// Amenity is a contract for an entry of the amenities catalog.
+type Amenity struct {
+	Slug string `json:"slug"`
+	Name string `json:"name"`
+}
+
+// ListAmenitiesResponse is a server response listing the amenities catalog.
+type ListAmenitiesResponse []*Amenity
+
+// SetRentalAmenitiesRequest is a client request for replacing the amenities of a rental.
+type SetRentalAmenitiesRequest struct {
+	Amenities []string `json:"amenities"`
+}
+
+// SetRentalAmenitiesResponse is a server response to replacing the amenities of a rental.
+type SetRentalAmenitiesResponse struct {
+	Amenities []string `json:"amenities"`
+}
@@ -0,0 +1,73 @@
+package contract
+
+import "time"
+
+// Booking is a contract for the booking object. Dates are formatted as YYYY-MM-DD and
+// the end date is the day of return, which is not charged as a night.
+type Booking struct {
+	ID                 int32      `json:"id"`
+	RentalID           int32      `json:"rental_id"`
+	UserID             int32      `json:"user_id"`
+	StartDate          string     `json:"start_date"`
+	EndDate            string     `json:"end_date"`
+	Nights             int        `json:"nights"`
+	Status             string     `json:"status"`
+	CancellationPolicy string     `json:"cancellation_policy"`
+	Created            time.Time  `json:"created"`
+	CancelledAt        *time.Time `json:"cancelled_at,omitempty"`
+}
+
+// CreateBookingRequest is a client request for booking a rental.
+type CreateBookingRequest struct {
+	UserID    int32  `json:"user_id"`
+	StartDate string `json:"start_date"`
+	EndDate   string `json:"end_date"`
+}
+
+// CreateBookingResponse is a server response to booking a rental.
+type CreateBookingResponse struct {
+	Booking
+}
+
+// GetBookingByIDResponse is a server response getting a single booking by id.
+type GetBookingByIDResponse struct {
+	Booking
+}
+
+// Refund is a contract for the refund of a cancelled booking. Amount is in cents.
+type Refund struct {
+	Policy  string `json:"policy"`
+	Percent int    `json:"percent"`
+	Amount  int64  `json:"amount"`
+}
+
+// CancelBookingResponse is a server response to cancelling a booking.
+type CancelBookingResponse struct {
+	Booking
+	Refund Refund `json:"refund"`
+}
+
+// Payment is a contract for the payment intent of a booking. Amounts are in cents.
+type Payment struct {
+	ID             int32                `json:"id"`
+	BookingID      int32                `json:"booking_id"`
+	Amount         int64                `json:"amount"`
+	RefundedAmount int64                `json:"refunded_amount"`
+	Status         string               `json:"status"`
+	Transitions    []*PaymentTransition `json:"transitions"`
+	Created        time.Time            `json:"created"`
+}
+
+// PaymentTransition is a contract for a change of the status of a payment intent.
+// From is empty for the creation of the intent.
+type PaymentTransition struct {
+	From    string    `json:"from"`
+	To      string    `json:"to"`
+	Amount  int64     `json:"amount"`
+	Created time.Time `json:"created"`
+}
+
+// GetBookingPaymentResponse is a server response getting the payment of a booking.
+type GetBookingPaymentResponse struct {
+	Payment
+}
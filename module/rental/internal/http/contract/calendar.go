@@ -0,0 +1,39 @@
+package contract
+
+// CalendarDay is a contract for the status of a rental on a single day.
+type CalendarDay struct {
+	Date   string `json:"date"`
+	Status string `json:"status"`
+}
+
+// Calendar is a contract for the availability calendar of a rental.
+type Calendar struct {
+	RentalID  int32         `json:"rental_id"`
+	MinNights int           `json:"min_nights"`
+	MaxNights *int          `json:"max_nights"`
+	Days      []CalendarDay `json:"days"`
+}
+
+// CalendarQuery is used to decode the query parameters selecting the months of a calendar.
+type CalendarQuery struct {
+	From *string `schema:"from"`
+	To   *string `schema:"to"`
+}
+
+// UpdateCalendarRequest is a client request for replacing the stay rules and the blocked days of a rental
+// within the requested months.
+type UpdateCalendarRequest struct {
+	MinNights   int      `json:"min_nights"`
+	MaxNights   *int     `json:"max_nights"`
+	BlockedDays []string `json:"blocked_days"`
+}
+
+// GetCalendarResponse is a server response getting the calendar of a rental.
+type GetCalendarResponse struct {
+	Calendar
+}
+
+// UpdateCalendarResponse is a server response to updating the calendar of a rental.
+type UpdateCalendarResponse struct {
+	Calendar
+}
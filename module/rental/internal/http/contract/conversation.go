@@ -0,0 +1,71 @@
+package contract
+
+import "time"
+
+// Conversation is a contract for the conversation object. UnreadCount is the number of
+// messages unread by the user the conversations are listed for.
+type Conversation struct {
+	ID            int32     `json:"id"`
+	RentalID      int32     `json:"rental_id"`
+	RenterID      int32     `json:"renter_id"`
+	OwnerID       int32     `json:"owner_id"`
+	UnreadCount   int32     `json:"unread_count"`
+	LastMessageAt time.Time `json:"last_message_at"`
+	Created       time.Time `json:"created"`
+}
+
+// Message is a contract for the message object.
+type Message struct {
+	ID             int32     `json:"id"`
+	ConversationID int32     `json:"conversation_id"`
+	SenderID       int32     `json:"sender_id"`
+	Body           string    `json:"body"`
+	Created        time.Time `json:"created"`
+}
+
+// PostMessageRequest is a client request for starting a conversation or posting a message to one.
+type PostMessageRequest struct {
+	SenderID int32  `json:"sender_id"`
+	Body     string `json:"body"`
+}
+
+// MarkReadRequest is a client request for marking the messages of a conversation as read.
+type MarkReadRequest struct {
+	UserID int32 `json:"user_id"`
+}
+
+// ListMessagesQuery is used to decode the query parameters of ListMessages.
+type ListMessagesQuery struct {
+	Limit  *int    `schema:"limit"`
+	Cursor *string `schema:"cursor"`
+}
+
+// StartConversationResponse is a server response to starting a conversation.
+type StartConversationResponse struct {
+	Conversation
+}
+
+// GetConversationByIDResponse is a server response getting a single conversation by id.
+type GetConversationByIDResponse struct {
+	Conversation
+}
+
+// ListConversationsResponse is a server response listing the conversations of a user.
+type ListConversationsResponse []*Conversation
+
+// PostMessageResponse is a server response to posting a message.
+type PostMessageResponse struct {
+	Message
+}
+
+// ListMessagesResponse is a server response listing a page of the messages of a conversation, latest first.
+// NextCursor is passed as cursor to get the following page and is null on the last page.
+type ListMessagesResponse struct {
+	Messages   []*Message `json:"messages"`
+	NextCursor *string    `json:"next_cursor"`
+}
+
+// UnreadCountResponse is a server response counting the messages unread by a user.
+type UnreadCountResponse struct {
+	UnreadCount int `json:"unread_count"`
+}
@@ -0,0 +1,35 @@
+package contract
+
+// PricingRule is a contract for the pricing rule object. Dates are formatted as YYYY-MM-DD and
+// the end date of a season is the first day it no longer applies to.
+type PricingRule struct {
+	ID          int32    `json:"id"`
+	RentalID    int32    `json:"rental_id"`
+	Type        string   `json:"type"`
+	StartDate   *string  `json:"start_date,omitempty"`
+	EndDate     *string  `json:"end_date,omitempty"`
+	PricePerDay *int64   `json:"price_per_day,omitempty"`
+	Percent     *float64 `json:"percent,omitempty"`
+}
+
+// PricingRuleRequest is a client request for creating or replacing a pricing rule of a rental.
+type PricingRuleRequest struct {
+	Type        string   `json:"type"`
+	StartDate   *string  `json:"start_date"`
+	EndDate     *string  `json:"end_date"`
+	PricePerDay *int64   `json:"price_per_day"`
+	Percent     *float64 `json:"percent"`
+}
+
+// CreatePricingRuleResponse is a server response to creating a pricing rule.
+type CreatePricingRuleResponse struct {
+	PricingRule
+}
+
+// UpdatePricingRuleResponse is a server response to replacing a pricing rule.
+type UpdatePricingRuleResponse struct {
+	PricingRule
+}
+
+// ListPricingRulesResponse is a server response listing the pricing rules of a rental.
+type ListPricingRulesResponse []*PricingRule
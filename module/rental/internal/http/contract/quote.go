@@ -0,0 +1,33 @@
+package contract
+
+// LineItem is a contract for a single amount of a quote. Amounts are in cents and discounts are negative.
+type LineItem struct {
+	Type        string `json:"type"`
+	Description string `json:"description"`
+	Amount      int64  `json:"amount"`
+}
+
+// Quote is a contract for the price of a stay at a rental.
+type Quote struct {
+	RentalID  int32      `json:"rental_id"`
+	StartDate string     `json:"start_date"`
+	EndDate   string     `json:"end_date"`
+	Nights    int        `json:"nights"`
+	LineItems []LineItem `json:"line_items"`
+	Subtotal  int64      `json:"subtotal"`
+	Discounts int64      `json:"discounts"`
+	Fees      int64      `json:"fees"`
+	Taxes     int64      `json:"taxes"`
+	Total     int64      `json:"total"`
+}
+
+// QuoteRentalQuery is used to decode the query parameters of QuoteRental.
+type QuoteRentalQuery struct {
+	Start *string `schema:"start"`
+	End   *string `schema:"end"`
+}
+
+// QuoteRentalResponse is a server response to pricing a stay at a rental.
+type QuoteRentalResponse struct {
+	Quote
+}
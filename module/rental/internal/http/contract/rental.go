@@ -1,12 +1,5 @@
 package contract
 
-// User is a contract for the user object.
-type User struct {
-	ID        int32  `json:"id"`
-	FirstName string `json:"first_name"`
-	LastName  string `json:"last_name"`
-}
-
 // Price is a contract for the price object.
 type Price struct {
 	Day int64 `json:"day"`
@@ -24,30 +17,82 @@ type Location struct {
 
 // Rental is a contract for the rental object.
 type Rental struct {
-	ID              int32   `json:"id"`
-	Name            string  `json:"name"`
-	Description     string  `json:"description"`
-	Type            string  `json:"type"`
-	Make            string  `json:"make"`
-	Model           string  `json:"model"`
-	Year            int32   `json:"year"`
-	Length          float32 `json:"length"`
-	Sleeps          int32   `json:"sleeps"`
-	PrimaryImageURL string  `json:"primary_image_url"`
-	Price           Price
-	Location        Location
-	User            User
+	ID                 int32    `json:"id"`
+	Name               string   `json:"name"`
+	Description        string   `json:"description"`
+	Type               string   `json:"type"`
+	Make               string   `json:"make"`
+	Model              string   `json:"model"`
+	Year               int32    `json:"year"`
+	Length             float32  `json:"length"`
+	Sleeps             int32    `json:"sleeps"`
+	PrimaryImageURL    string   `json:"primary_image_url"`
+	CancellationPolicy string   `json:"cancellation_policy"`
+	RatingAverage      *float64 `json:"rating_average"`
+	RatingCount        int32    `json:"rating_count"`
+	Images             []Image  `json:"images"`
+	Amenities          []string `json:"amenities"`
+	Distance           *float64 `json:"distance,omitempty"`
+	Price              Price
+	Location           Location
+	User               User
 }
 
 // ListRentalsQuery is used to decode the query parameters of ListRentals.
 type ListRentalsQuery struct {
-	Ids      []int32   `schema:"ids"`
-	PriceMin *int64    `schema:"price_min"`
-	PriceMax *int64    `schema:"price_max"`
-	Near     []float32 `schema:"near"`
-	Limit    *int      `schema:"limit"`
-	Offset   *int      `schema:"offset"`
-	Sort     *string   `schema:"sort"`
+	Ids            []int32   `schema:"ids"`
+	UserIDs        []int32   `schema:"user_id"`
+	WishlistID     *int32    `schema:"wishlist_id"`
+	Amenities      []string  `schema:"amenities"`
+	Q              *string   `schema:"q"`
+	Types          []string  `schema:"type"`
+	Make           *string   `schema:"make"`
+	Model          *string   `schema:"model"`
+	YearMin        *int32    `schema:"year_min"`
+	YearMax        *int32    `schema:"year_max"`
+	LengthMin      *float32  `schema:"length_min"`
+	LengthMax      *float32  `schema:"length_max"`
+	SleepsMin      *int32    `schema:"sleeps_min"`
+	PriceMin       *int64    `schema:"price_min"`
+	PriceMax       *int64    `schema:"price_max"`
+	RatingMin      *float64  `schema:"rating_min"`
+	Near           []float32 `schema:"near"`
+	Radius         *float64  `schema:"radius"`
+	AvailableFrom  *string   `schema:"available_from"`
+	AvailableTo    *string   `schema:"available_to"`
+	EffectivePrice *bool     `schema:"effective_price"`
+	Limit          *int      `schema:"limit"`
+	Offset         *int      `schema:"offset"`
+	Cursor         *string   `schema:"cursor"`
+	Envelope       *bool     `schema:"envelope"`
+	Sort           *string   `schema:"sort"`
+
+	IncludeDeleted *bool `schema:"include_deleted"`
+}
+
+// CreateRentalRequest is a client request for creating a rental.
+type CreateRentalRequest struct {
+	Rental
+}
+
+// CreateRentalResponse is a server response to creating a rental.
+type CreateRentalResponse struct {
+	Rental
+}
+
+// UpdateRentalRequest is a client request for replacing a rental.
+type UpdateRentalRequest struct {
+	Rental
+}
+
+// UpdateRentalResponse is a server response to updating a rental.
+type UpdateRentalResponse struct {
+	Rental
+}
+
+// RestoreRentalResponse is a server response to restoring an archived rental.
+type RestoreRentalResponse struct {
+	Rental
 }
 
 // GetRentalByIDResponse is a server response getting a single rental by id.
@@ -57,3 +102,19 @@ type GetRentalByIDResponse struct {
 
 // ListRentalsResponse is a server response listing rentals by filters.
 type ListRentalsResponse []*Rental
+
+// PageMeta is a contract for the pagination metadata of a listing. Total counts the results
+// of every page and Limit is null when the results are not limited. NextCursor is null when
+// the following page cannot be listed by cursor.
+type PageMeta struct {
+	Total      int     `json:"total"`
+	Limit      *int    `json:"limit"`
+	Offset     int     `json:"offset"`
+	NextCursor *string `json:"next_cursor"`
+}
+
+// ListRentalsEnvelope is a server response listing rentals by filters along with pagination metadata.
+type ListRentalsEnvelope struct {
+	Data ListRentalsResponse `json:"data"`
+	Meta PageMeta            `json:"meta"`
+}
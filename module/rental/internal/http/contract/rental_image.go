@@ -0,0 +1,34 @@
+package contract
+
+// Image is a contract for the rental image object. Thumbnails are present only for uploaded images.
+type Image struct {
+	ID         int32             `json:"id"`
+	URL        string            `json:"url"`
+	Caption    string            `json:"caption"`
+	Position   int               `json:"position"`
+	Thumbnails map[string]string `json:"thumbnails,omitempty"`
+}
+
+// AddImageRequest is a client request for appending an image to the images of a rental.
+type AddImageRequest struct {
+	URL     string `json:"url"`
+	Caption string `json:"caption"`
+}
+
+// ReorderImagesRequest is a client request for changing the order of the images of a rental.
+type ReorderImagesRequest struct {
+	ImageIDs []int32 `json:"image_ids"`
+}
+
+// AddImageResponse is a server response to appending an image to a rental.
+type AddImageResponse struct {
+	Image
+}
+
+// GetImageResponse is a server response getting a single image of a rental.
+type GetImageResponse struct {
+	Image
+}
+
+// ListImagesResponse is a server response listing the images of a rental in their order.
+type ListImagesResponse []*Image
@@ -0,0 +1,40 @@
+package contract
+
+import "time"
+
+// Review is a contract for the review object.
+type Review struct {
+	ID        int32     `json:"id"`
+	RentalID  int32     `json:"rental_id"`
+	BookingID int32     `json:"booking_id"`
+	UserID    int32     `json:"user_id"`
+	Rating    int       `json:"rating"`
+	Comment   string    `json:"comment"`
+	Created   time.Time `json:"created"`
+}
+
+// CreateReviewRequest is a client request for reviewing a rental after a completed booking.
+type CreateReviewRequest struct {
+	BookingID int32  `json:"booking_id"`
+	Rating    int    `json:"rating"`
+	Comment   string `json:"comment"`
+}
+
+// ListReviewsQuery is used to decode the query parameters of ListReviews.
+type ListReviewsQuery struct {
+	Limit  *int `schema:"limit"`
+	Offset *int `schema:"offset"`
+}
+
+// CreateReviewResponse is a server response to reviewing a rental.
+type CreateReviewResponse struct {
+	Review
+}
+
+// GetReviewByIDResponse is a server response getting a single review by id.
+type GetReviewByIDResponse struct {
+	Review
+}
+
+// ListReviewsResponse is a server response listing the reviews of a rental.
+type ListReviewsResponse []*Review
@@ -0,0 +1,38 @@
+package contract
+
+// User is a contract for the user object.
+type User struct {
+	ID        int32  `json:"id"`
+	FirstName string `json:"first_name"`
+	LastName  string `json:"last_name"`
+}
+
+// ListUsersQuery is used to decode the query parameters of ListUsers.
+type ListUsersQuery struct {
+	Name   *string `schema:"name"`
+	Limit  *int    `schema:"limit"`
+	Offset *int    `schema:"offset"`
+}
+
+// CreateUserRequest is a client request for creating a user.
+type CreateUserRequest struct {
+	User
+}
+
+// CreateUserResponse is a server response to creating a user.
+type CreateUserResponse struct {
+	User
+}
+
+// UpdateUserResponse is a server response to updating a user.
+type UpdateUserResponse struct {
+	User
+}
+
+// GetUserByIDResponse is a server response getting a single user by id.
+type GetUserByIDResponse struct {
+	User
+}
+
+// ListUsersResponse is a server response listing users by filters.
+type ListUsersResponse []*User
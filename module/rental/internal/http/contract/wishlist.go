@@ -0,0 +1,30 @@
+package contract
+
+import "time"
+
+// Wishlist is a contract for the wishlist object.
+type Wishlist struct {
+	ID          int32     `json:"id"`
+	UserID      int32     `json:"user_id"`
+	Name        string    `json:"name"`
+	RentalCount int32     `json:"rental_count"`
+	Created     time.Time `json:"created"`
+}
+
+// CreateWishlistRequest is a client request for creating a wishlist.
+type CreateWishlistRequest struct {
+	Name string `json:"name"`
+}
+
+// CreateWishlistResponse is a server response to creating a wishlist.
+type CreateWishlistResponse struct {
+	Wishlist
+}
+
+// GetWishlistByIDResponse is a server response getting a single wishlist by id.
+type GetWishlistByIDResponse struct {
+	Wishlist
+}
+
+// ListWishlistsResponse is a server response listing the wishlists of a user.
+type ListWishlistsResponse []*Wishlist
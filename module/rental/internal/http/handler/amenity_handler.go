@@ -0,0 +1,97 @@
+package handler
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi"
+
+	"github.com/dragonator/rental-service/module/rental/internal/http/contract"
+	"github.com/dragonator/rental-service/module/rental/internal/http/service/svc"
+	"github.com/dragonator/rental-service/module/rental/internal/model"
+)
+
+// AmenityManagingOp is a contract to an amenity managing operation.
+//
+//go:generate moq -rm -pkg handler_test -out amenity_managing_op_mock_test.go . AmenityManagingOp
+type AmenityManagingOp interface {
+	ListAmenities(ctx context.Context) (model.Amenities, error)
+	SetRentalAmenities(ctx context.Context, rentalID int, slugs []string) (*model.Rental, error)
+}
+
+// AmenityHandler holds implementation of handlers for amenities.
+type AmenityHandler struct {
+	amenityManagingOp AmenityManagingOp
+}
+
+// NewAmenityHandler is a construction function for AmenityHandler.
+func NewAmenityHandler(amenityManagingOp AmenityManagingOp) *AmenityHandler {
+	return &AmenityHandler{
+		amenityManagingOp: amenityManagingOp,
+	}
+}
+
+// ListAmenities returns a handle that is listing the amenities catalog.
+func (ah *AmenityHandler) ListAmenities(method, path string) func(w http.ResponseWriter, r *http.Request) {
+	return func(w http.ResponseWriter, r *http.Request) {
+		amenities, err := ah.amenityManagingOp.ListAmenities(r.Context())
+		if err != nil {
+			errorResponse(w, err)
+			return
+		}
+
+		resp := make(contract.ListAmenitiesResponse, 0, len(amenities))
+		for _, amenity := range amenities {
+			resp = append(resp, &contract.Amenity{
+				Slug: amenity.Slug,
+				Name: amenity.Name,
+			})
+		}
+
+		successResponse(w, resp)
+
+		return
+	}
+}
+
+// SetRentalAmenities returns a handle that is replacing the amenities of a rental.
+func (ah *AmenityHandler) SetRentalAmenities(method, path string) func(w http.ResponseWriter, r *http.Request) {
+	return func(w http.ResponseWriter, r *http.Request) {
+		rentalID, err := strconv.Atoi(chi.URLParam(r, "id"))
+		if err != nil {
+			errorResponse(w, fmt.Errorf("%w: id", svc.ErrInvalidQueryParameters))
+			return
+		}
+
+		var req contract.SetRentalAmenitiesRequest
+		if err := decodeJSONBody(r, &req); err != nil {
+			errorResponse(w, err)
+			return
+		}
+
+		if req.Amenities == nil {
+			errorResponse(w, fmt.Errorf("%w: amenities is required", svc.ErrInvalidRequestBody))
+			return
+		}
+
+		rental, err := ah.amenityManagingOp.SetRentalAmenities(r.Context(), rentalID, req.Amenities)
+		if err != nil {
+			errorResponse(w, err)
+			return
+		}
+
+		successResponse(w, &contract.SetRentalAmenitiesResponse{
+			Amenities: toAmenitiesContract(rental.Amenities),
+		})
+
+		return
+	}
+}
+
+func toAmenitiesContract(slugs []string) []string {
+	resp := make([]string, 0, len(slugs))
+
+	return append(resp, slugs...)
+}
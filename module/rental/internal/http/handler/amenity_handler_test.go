@@ -0,0 +1,123 @@
+package handler_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-chi/chi"
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/dragonator/rental-service/module/rental/internal/http/contract"
+	"github.com/dragonator/rental-service/module/rental/internal/http/handler"
+	"github.com/dragonator/rental-service/module/rental/internal/http/service/svc"
+	"github.com/dragonator/rental-service/module/rental/internal/model"
+)
+
+func TestAmenityHandler_SetRentalAmenities(t *testing.T) {
+	testCases := []struct {
+		name              string
+		path              string
+		body              string
+		setErr            error
+		expectedCalls     int
+		expectedCode      int
+		expectedAmenities []string
+	}{
+		{
+			name:              "Valid amenities",
+			path:              "/rentals/1/amenities",
+			body:              `{"amenities":["shower","solar"]}`,
+			expectedCalls:     1,
+			expectedCode:      http.StatusOK,
+			expectedAmenities: []string{"shower", "solar"},
+		},
+		{
+			name:              "Clear amenities",
+			path:              "/rentals/1/amenities",
+			body:              `{"amenities":[]}`,
+			expectedCalls:     1,
+			expectedCode:      http.StatusOK,
+			expectedAmenities: []string{},
+		},
+		{
+			name:          "Missing amenities",
+			path:          "/rentals/1/amenities",
+			body:          `{}`,
+			expectedCalls: 0,
+			expectedCode:  http.StatusBadRequest,
+		},
+		{
+			name:          "Invalid rental id",
+			path:          "/rentals/abc/amenities",
+			body:          `{"amenities":["shower"]}`,
+			expectedCalls: 0,
+			expectedCode:  http.StatusBadRequest,
+		},
+		{
+			name:          "Unknown amenity",
+			path:          "/rentals/1/amenities",
+			body:          `{"amenities":["sauna"]}`,
+			setErr:        svc.ErrInvalidRequestBody,
+			expectedCalls: 1,
+			expectedCode:  http.StatusBadRequest,
+		},
+		{
+			name:          "Missing rental",
+			path:          "/rentals/1/amenities",
+			body:          `{"amenities":["shower"]}`,
+			setErr:        svc.ErrNotFound,
+			expectedCalls: 1,
+			expectedCode:  http.StatusNotFound,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockAmenityManagingOp := &AmenityManagingOpMock{
+				SetRentalAmenitiesFunc: func(ctx context.Context, rentalID int, slugs []string) (*model.Rental, error) {
+					if tc.setErr != nil {
+						return nil, tc.setErr
+					}
+
+					return &model.Rental{ID: int32(rentalID), Amenities: slugs}, nil
+				},
+			}
+
+			amenityHandler := handler.NewAmenityHandler(mockAmenityManagingOp)
+
+			router := chi.NewRouter()
+			router.Put("/rentals/{id}/amenities", amenityHandler.SetRentalAmenities("PUT", "/rentals/{id}/amenities"))
+
+			request := httptest.NewRequest("PUT", tc.path, strings.NewReader(tc.body))
+			responseRecorder := httptest.NewRecorder()
+
+			router.ServeHTTP(responseRecorder, request)
+
+			calls := mockAmenityManagingOp.SetRentalAmenitiesCalls()
+			if len(calls) != tc.expectedCalls {
+				t.Fatalf("Unexpected number of calls to SetRentalAmenities:\nexpected: %d\ngot      %d", tc.expectedCalls, len(calls))
+			}
+
+			if responseRecorder.Code != tc.expectedCode {
+				t.Fatalf("Unexpected status code:\nexpected %d\ngot:      %d", tc.expectedCode, responseRecorder.Code)
+			}
+
+			if tc.expectedCode == http.StatusOK {
+				var responseBody contract.SetRentalAmenitiesResponse
+
+				err := json.NewDecoder(responseRecorder.Body).Decode(&responseBody)
+				if err != nil {
+					t.Fatalf("Failed to decode response body: %v", err)
+				}
+
+				if !cmp.Equal(responseBody.Amenities, tc.expectedAmenities) {
+					t.Fatalf("Unexpected amenities:\nexpected: %v\ngot:      %v", tc.expectedAmenities, responseBody.Amenities)
+				}
+			}
+		})
+	}
+}
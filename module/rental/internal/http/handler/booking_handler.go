@@ -0,0 +1,205 @@
+package handler
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/chi"
+
+	"github.com/dragonator/rental-service/module/rental/internal/cancellation"
+	"github.com/dragonator/rental-service/module/rental/internal/http/contract"
+	"github.com/dragonator/rental-service/module/rental/internal/http/service/svc"
+	"github.com/dragonator/rental-service/module/rental/internal/model"
+)
+
+// BookingFetchingOp is a contract to a booking fetching operation.
+//
+//go:generate moq -rm -pkg handler_test -out booking_fetching_op_mock_test.go . BookingFetchingOp
+type BookingFetchingOp interface {
+	GetBookingByID(ctx context.Context, bookingID int) (*model.Booking, error)
+	GetBookingPayment(ctx context.Context, bookingID int) (*model.PaymentIntent, error)
+}
+
+// BookingManagingOp is a contract to a booking managing operation.
+//
+//go:generate moq -rm -pkg handler_test -out booking_managing_op_mock_test.go . BookingManagingOp
+type BookingManagingOp interface {
+	CreateBooking(ctx context.Context, booking *model.Booking) (*model.Booking, error)
+	CancelBooking(ctx context.Context, bookingID int) (*model.Booking, *cancellation.Refund, error)
+}
+
+// BookingHandler holds implementation of handlers for bookings.
+type BookingHandler struct {
+	bookingFetchingOp BookingFetchingOp
+	bookingManagingOp BookingManagingOp
+}
+
+// NewBookingHandler is a construction function for BookingHandler.
+func NewBookingHandler(bookingFetchingOp BookingFetchingOp, bookingManagingOp BookingManagingOp) *BookingHandler {
+	return &BookingHandler{
+		bookingFetchingOp: bookingFetchingOp,
+		bookingManagingOp: bookingManagingOp,
+	}
+}
+
+// CreateBooking returns a handle that is booking a rental.
+func (bh *BookingHandler) CreateBooking(method, path string) func(w http.ResponseWriter, r *http.Request) {
+	return func(w http.ResponseWriter, r *http.Request) {
+		rentalID, err := strconv.Atoi(chi.URLParam(r, "id"))
+		if err != nil {
+			errorResponse(w, fmt.Errorf("%w: id", svc.ErrInvalidQueryParameters))
+			return
+		}
+
+		var req contract.CreateBookingRequest
+		if err := decodeJSONBody(r, &req); err != nil {
+			errorResponse(w, err)
+			return
+		}
+
+		booking, err := toBookingModel(rentalID, &req)
+		if err != nil {
+			errorResponse(w, err)
+			return
+		}
+
+		created, err := bh.bookingManagingOp.CreateBooking(r.Context(), booking)
+		if err != nil {
+			errorResponse(w, err)
+			return
+		}
+
+		createdResponse(w, fmt.Sprintf("/bookings/%d", created.ID), toBookingContract(created))
+
+		return
+	}
+}
+
+// GetBookingByID returns a handle that is fetching a booking by id.
+func (bh *BookingHandler) GetBookingByID(method, path string) func(w http.ResponseWriter, r *http.Request) {
+	return func(w http.ResponseWriter, r *http.Request) {
+		bookingID, err := strconv.Atoi(chi.URLParam(r, "id"))
+		if err != nil {
+			errorResponse(w, fmt.Errorf("%w: id", svc.ErrInvalidQueryParameters))
+			return
+		}
+
+		booking, err := bh.bookingFetchingOp.GetBookingByID(r.Context(), bookingID)
+		if err != nil {
+			errorResponse(w, err)
+			return
+		}
+
+		successResponse(w, toBookingContract(booking))
+
+		return
+	}
+}
+
+// GetBookingPayment returns a handle that is fetching the payment of a booking.
+func (bh *BookingHandler) GetBookingPayment(method, path string) func(w http.ResponseWriter, r *http.Request) {
+	return func(w http.ResponseWriter, r *http.Request) {
+		bookingID, err := strconv.Atoi(chi.URLParam(r, "id"))
+		if err != nil {
+			errorResponse(w, fmt.Errorf("%w: id", svc.ErrInvalidQueryParameters))
+			return
+		}
+
+		intent, err := bh.bookingFetchingOp.GetBookingPayment(r.Context(), bookingID)
+		if err != nil {
+			errorResponse(w, err)
+			return
+		}
+
+		successResponse(w, toPaymentContract(intent))
+
+		return
+	}
+}
+
+// CancelBooking returns a handle that is cancelling a booking.
+func (bh *BookingHandler) CancelBooking(method, path string) func(w http.ResponseWriter, r *http.Request) {
+	return func(w http.ResponseWriter, r *http.Request) {
+		bookingID, err := strconv.Atoi(chi.URLParam(r, "id"))
+		if err != nil {
+			errorResponse(w, fmt.Errorf("%w: id", svc.ErrInvalidQueryParameters))
+			return
+		}
+
+		booking, refund, err := bh.bookingManagingOp.CancelBooking(r.Context(), bookingID)
+		if err != nil {
+			errorResponse(w, err)
+			return
+		}
+
+		successResponse(w, &contract.CancelBookingResponse{
+			Booking: *toBookingContract(booking),
+			Refund: contract.Refund{
+				Policy:  string(refund.Policy),
+				Percent: refund.Percent,
+				Amount:  refund.Amount,
+			},
+		})
+
+		return
+	}
+}
+
+func toBookingContract(booking *model.Booking) *contract.Booking {
+	return &contract.Booking{
+		ID:                 booking.ID,
+		RentalID:           booking.RentalID,
+		UserID:             booking.UserID,
+		StartDate:          booking.StartDate.Format(time.DateOnly),
+		EndDate:            booking.EndDate.Format(time.DateOnly),
+		Nights:             booking.Nights(),
+		Status:             string(booking.Status),
+		CancellationPolicy: string(booking.CancellationPolicy),
+		Created:            booking.Created,
+		CancelledAt:        booking.CancelledAt,
+	}
+}
+
+func toPaymentContract(intent *model.PaymentIntent) *contract.Payment {
+	transitions := make([]*contract.PaymentTransition, 0, len(intent.Transitions))
+	for _, transition := range intent.Transitions {
+		transitions = append(transitions, &contract.PaymentTransition{
+			From:    string(transition.From),
+			To:      string(transition.To),
+			Amount:  transition.Amount,
+			Created: transition.Created,
+		})
+	}
+
+	return &contract.Payment{
+		ID:             intent.ID,
+		BookingID:      intent.BookingID,
+		Amount:         intent.Amount,
+		RefundedAmount: intent.RefundedAmount,
+		Status:         string(intent.Status),
+		Transitions:    transitions,
+		Created:        intent.Created,
+	}
+}
+
+func toBookingModel(rentalID int, req *contract.CreateBookingRequest) (*model.Booking, error) {
+	startDate, err := time.Parse(time.DateOnly, req.StartDate)
+	if err != nil {
+		return nil, fmt.Errorf("%w: start_date: expected format YYYY-MM-DD", svc.ErrInvalidRequestBody)
+	}
+
+	endDate, err := time.Parse(time.DateOnly, req.EndDate)
+	if err != nil {
+		return nil, fmt.Errorf("%w: end_date: expected format YYYY-MM-DD", svc.ErrInvalidRequestBody)
+	}
+
+	return &model.Booking{
+		RentalID:  int32(rentalID),
+		UserID:    req.UserID,
+		StartDate: startDate,
+		EndDate:   endDate,
+	}, nil
+}
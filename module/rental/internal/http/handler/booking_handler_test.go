@@ -0,0 +1,225 @@
+package handler_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi"
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/dragonator/rental-service/module/rental/internal/cancellation"
+	"github.com/dragonator/rental-service/module/rental/internal/http/contract"
+	"github.com/dragonator/rental-service/module/rental/internal/http/handler"
+	"github.com/dragonator/rental-service/module/rental/internal/http/service/svc"
+	"github.com/dragonator/rental-service/module/rental/internal/model"
+)
+
+var _booking = &model.Booking{
+	ID:        1,
+	RentalID:  2,
+	UserID:    3,
+	StartDate: time.Date(2023, 7, 1, 0, 0, 0, 0, time.UTC),
+	EndDate:   time.Date(2023, 7, 5, 0, 0, 0, 0, time.UTC),
+	Status:    model.BookingStatusConfirmed,
+	Created:   time.Date(2023, 6, 1, 10, 0, 0, 0, time.UTC),
+	Updated:   time.Date(2023, 6, 1, 10, 0, 0, 0, time.UTC),
+}
+
+func TestBookingHandler_CreateBooking(t *testing.T) {
+	testCases := []struct {
+		name            string
+		body            string
+		createErr       error
+		expectedBooking *model.Booking
+		expectedCalls   int
+		expectedCode    int
+	}{
+		{
+			name: "Valid booking",
+			body: `{"user_id":3,"start_date":"2023-07-01","end_date":"2023-07-05"}`,
+			expectedBooking: &model.Booking{
+				RentalID:  2,
+				UserID:    3,
+				StartDate: _booking.StartDate,
+				EndDate:   _booking.EndDate,
+			},
+			expectedCalls: 1,
+			expectedCode:  http.StatusCreated,
+		},
+		{
+			name:          "Invalid start date",
+			body:          `{"user_id":3,"start_date":"07/01/2023","end_date":"2023-07-05"}`,
+			expectedCalls: 0,
+			expectedCode:  http.StatusBadRequest,
+		},
+		{
+			name:          "Overlapping booking",
+			body:          `{"user_id":3,"start_date":"2023-07-01","end_date":"2023-07-05"}`,
+			createErr:     svc.ErrConflict,
+			expectedCalls: 1,
+			expectedCode:  http.StatusConflict,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockBookingManagingOp := &BookingManagingOpMock{
+				CreateBookingFunc: func(ctx context.Context, booking *model.Booking) (*model.Booking, error) {
+					if tc.createErr != nil {
+						return nil, tc.createErr
+					}
+
+					return _booking, nil
+				},
+			}
+
+			bookingHandler := handler.NewBookingHandler(&BookingFetchingOpMock{}, mockBookingManagingOp)
+
+			router := chi.NewRouter()
+			router.Post("/rentals/{id}/bookings", bookingHandler.CreateBooking("POST", "/rentals/{id}/bookings"))
+
+			request := httptest.NewRequest("POST", "/rentals/2/bookings", strings.NewReader(tc.body))
+			responseRecorder := httptest.NewRecorder()
+
+			router.ServeHTTP(responseRecorder, request)
+
+			calls := mockBookingManagingOp.CreateBookingCalls()
+			if tc.expectedCalls != len(calls) {
+				t.Fatalf("Unexpected number of calls to CreateBooking:\nexpected: %d\ngot      %d", tc.expectedCalls, len(calls))
+			}
+
+			if tc.expectedBooking != nil && !cmp.Equal(calls[0].Booking, tc.expectedBooking) {
+				t.Fatalf("Unexpected booking:\nexpected: %v\ngot:      %v", tc.expectedBooking, calls[0].Booking)
+			}
+
+			if responseRecorder.Code != tc.expectedCode {
+				t.Fatalf("Unexpected status code:\nexpected %d\ngot:      %d", tc.expectedCode, responseRecorder.Code)
+			}
+
+			if tc.expectedCode == http.StatusCreated {
+				if location := responseRecorder.Header().Get("Location"); location != "/bookings/1" {
+					t.Fatalf("Unexpected location:\nexpected: /bookings/1\ngot:      %s", location)
+				}
+			}
+		})
+	}
+}
+
+func TestBookingHandler_GetBookingByID(t *testing.T) {
+	mockBookingFetchingOp := &BookingFetchingOpMock{
+		GetBookingByIDFunc: func(ctx context.Context, bookingID int) (*model.Booking, error) {
+			return _booking, nil
+		},
+	}
+
+	bookingHandler := handler.NewBookingHandler(mockBookingFetchingOp, &BookingManagingOpMock{})
+
+	router := chi.NewRouter()
+	router.Get("/bookings/{id}", bookingHandler.GetBookingByID("GET", "/bookings/{id}"))
+
+	request := httptest.NewRequest("GET", "/bookings/1", nil)
+	responseRecorder := httptest.NewRecorder()
+
+	router.ServeHTTP(responseRecorder, request)
+
+	if responseRecorder.Code != http.StatusOK {
+		t.Fatalf("Unexpected status code:\nexpected %d\ngot:      %d", http.StatusOK, responseRecorder.Code)
+	}
+
+	var responseBody contract.GetBookingByIDResponse
+
+	if err := json.NewDecoder(responseRecorder.Body).Decode(&responseBody); err != nil {
+		t.Fatalf("Failed to decode response body: %v", err)
+	}
+
+	expected := contract.GetBookingByIDResponse{Booking: contract.Booking{
+		ID:        1,
+		RentalID:  2,
+		UserID:    3,
+		StartDate: "2023-07-01",
+		EndDate:   "2023-07-05",
+		Nights:    4,
+		Status:    "confirmed",
+		Created:   _booking.Created,
+	}}
+
+	if !cmp.Equal(responseBody, expected) {
+		t.Fatalf("Unexpected booking:\nexpected: %v\ngot:      %v", expected, responseBody)
+	}
+}
+
+func TestBookingHandler_CancelBooking(t *testing.T) {
+	refund := &cancellation.Refund{Policy: model.CancellationPolicyModerate, Percent: 50, Amount: 25000}
+
+	testCases := []struct {
+		name         string
+		cancelErr    error
+		expectedCode int
+	}{
+		{
+			name:         "Confirmed booking",
+			expectedCode: http.StatusOK,
+		},
+		{
+			name:         "Cancelled booking",
+			cancelErr:    svc.ErrConflict,
+			expectedCode: http.StatusConflict,
+		},
+		{
+			name:         "Missing booking",
+			cancelErr:    svc.ErrNotFound,
+			expectedCode: http.StatusNotFound,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockBookingManagingOp := &BookingManagingOpMock{
+				CancelBookingFunc: func(ctx context.Context, bookingID int) (*model.Booking, *cancellation.Refund, error) {
+					if tc.cancelErr != nil {
+						return nil, nil, tc.cancelErr
+					}
+
+					return _booking, refund, nil
+				},
+			}
+
+			bookingHandler := handler.NewBookingHandler(&BookingFetchingOpMock{}, mockBookingManagingOp)
+
+			router := chi.NewRouter()
+			router.Post("/bookings/{id}:cancel", bookingHandler.CancelBooking("POST", "/bookings/{id}:cancel"))
+
+			request := httptest.NewRequest("POST", "/bookings/1:cancel", nil)
+			responseRecorder := httptest.NewRecorder()
+
+			router.ServeHTTP(responseRecorder, request)
+
+			if calls := mockBookingManagingOp.CancelBookingCalls(); len(calls) != 1 || calls[0].BookingID != 1 {
+				t.Fatalf("Unexpected calls to CancelBooking: %v", calls)
+			}
+
+			if responseRecorder.Code != tc.expectedCode {
+				t.Fatalf("Unexpected status code:\nexpected %d\ngot:      %d", tc.expectedCode, responseRecorder.Code)
+			}
+
+			if tc.cancelErr != nil {
+				return
+			}
+
+			responseBody := new(contract.CancelBookingResponse)
+			if err := json.NewDecoder(responseRecorder.Body).Decode(responseBody); err != nil {
+				t.Fatalf("Failed to decode response body: %v", err)
+			}
+
+			expected := contract.Refund{Policy: "moderate", Percent: 50, Amount: 25000}
+			if !cmp.Equal(responseBody.Refund, expected) {
+				t.Fatalf("Unexpected refund:\nexpected: %v\ngot:      %v", expected, responseBody.Refund)
+			}
+		})
+	}
+}
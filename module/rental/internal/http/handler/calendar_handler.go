@@ -0,0 +1,185 @@
+package handler
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/chi"
+	"github.com/gorilla/schema"
+
+	"github.com/dragonator/rental-service/module/rental/internal/http/contract"
+	"github.com/dragonator/rental-service/module/rental/internal/http/service/svc"
+	"github.com/dragonator/rental-service/module/rental/internal/model"
+)
+
+const (
+	_monthLayout       = "2006-01"
+	_maxCalendarMonths = 12
+)
+
+// CalendarManagingOp is a contract to a calendar managing operation.
+//
+//go:generate moq -rm -pkg handler_test -out calendar_managing_op_mock_test.go . CalendarManagingOp
+type CalendarManagingOp interface {
+	GetCalendar(ctx context.Context, rentalID int, start, end time.Time) (*model.Calendar, error)
+	UpdateCalendar(ctx context.Context, rentalID int, rules *model.StayRules, start, end time.Time, blockedDays []time.Time) (*model.Calendar, error)
+}
+
+// CalendarHandler holds implementation of handlers for rental calendars.
+type CalendarHandler struct {
+	calendarManagingOp CalendarManagingOp
+}
+
+// NewCalendarHandler is a construction function for CalendarHandler.
+func NewCalendarHandler(calendarManagingOp CalendarManagingOp) *CalendarHandler {
+	return &CalendarHandler{
+		calendarManagingOp: calendarManagingOp,
+	}
+}
+
+// GetCalendar returns a handle that is fetching the calendar of a rental for the requested months.
+func (ch *CalendarHandler) GetCalendar(method, path string) func(w http.ResponseWriter, r *http.Request) {
+	return func(w http.ResponseWriter, r *http.Request) {
+		rentalID, err := strconv.Atoi(chi.URLParam(r, "id"))
+		if err != nil {
+			errorResponse(w, fmt.Errorf("%w: id", svc.ErrInvalidQueryParameters))
+			return
+		}
+
+		start, end, err := calendarMonthsFromRequest(r)
+		if err != nil {
+			errorResponse(w, err)
+			return
+		}
+
+		calendar, err := ch.calendarManagingOp.GetCalendar(r.Context(), rentalID, start, end)
+		if err != nil {
+			errorResponse(w, err)
+			return
+		}
+
+		successResponse(w, toCalendarContract(calendar))
+
+		return
+	}
+}
+
+// UpdateCalendar returns a handle that is replacing the stay rules of a rental and its blocked days
+// within the requested months.
+func (ch *CalendarHandler) UpdateCalendar(method, path string) func(w http.ResponseWriter, r *http.Request) {
+	return func(w http.ResponseWriter, r *http.Request) {
+		rentalID, err := strconv.Atoi(chi.URLParam(r, "id"))
+		if err != nil {
+			errorResponse(w, fmt.Errorf("%w: id", svc.ErrInvalidQueryParameters))
+			return
+		}
+
+		start, end, err := calendarMonthsFromRequest(r)
+		if err != nil {
+			errorResponse(w, err)
+			return
+		}
+
+		var req contract.UpdateCalendarRequest
+		if err := decodeJSONBody(r, &req); err != nil {
+			errorResponse(w, err)
+			return
+		}
+
+		blockedDays := make([]time.Time, 0, len(req.BlockedDays))
+		for _, d := range req.BlockedDays {
+			day, err := time.Parse(time.DateOnly, d)
+			if err != nil {
+				errorResponse(w, fmt.Errorf("%w: blocked_days: expected format YYYY-MM-DD", svc.ErrInvalidRequestBody))
+				return
+			}
+
+			blockedDays = append(blockedDays, day)
+		}
+
+		rules := &model.StayRules{
+			MinNights: req.MinNights,
+			MaxNights: req.MaxNights,
+		}
+
+		calendar, err := ch.calendarManagingOp.UpdateCalendar(r.Context(), rentalID, rules, start, end, blockedDays)
+		if err != nil {
+			errorResponse(w, err)
+			return
+		}
+
+		successResponse(w, toCalendarContract(calendar))
+
+		return
+	}
+}
+
+// calendarMonthsFromRequest returns the first day of the from month and the first day after the to month.
+// Both default to the current month.
+func calendarMonthsFromRequest(r *http.Request) (time.Time, time.Time, error) {
+	var query contract.CalendarQuery
+
+	if err := r.ParseForm(); err != nil {
+		return time.Time{}, time.Time{}, fmt.Errorf("%w: parsing form: %w", svc.ErrInvalidQueryParameters, err)
+	}
+
+	if err := schema.NewDecoder().Decode(&query, r.Form); err != nil {
+		return time.Time{}, time.Time{}, fmt.Errorf("%w: unmashalling query: %w", svc.ErrInvalidQueryParameters, err)
+	}
+
+	now := time.Now().UTC()
+	from := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
+
+	if query.From != nil {
+		month, err := time.Parse(_monthLayout, *query.From)
+		if err != nil {
+			return time.Time{}, time.Time{}, fmt.Errorf("%w: from: expected format YYYY-MM", svc.ErrInvalidQueryParameters)
+		}
+
+		from = month
+	}
+
+	to := from
+
+	if query.To != nil {
+		month, err := time.Parse(_monthLayout, *query.To)
+		if err != nil {
+			return time.Time{}, time.Time{}, fmt.Errorf("%w: to: expected format YYYY-MM", svc.ErrInvalidQueryParameters)
+		}
+
+		to = month
+	}
+
+	if to.Before(from) {
+		return time.Time{}, time.Time{}, fmt.Errorf("%w: to must not be before from", svc.ErrInvalidQueryParameters)
+	}
+
+	end := to.AddDate(0, 1, 0)
+	if end.After(from.AddDate(0, _maxCalendarMonths, 0)) {
+		return time.Time{}, time.Time{}, fmt.Errorf("%w: at most %d months can be requested",
+			svc.ErrInvalidQueryParameters, _maxCalendarMonths)
+	}
+
+	return from, end, nil
+}
+
+func toCalendarContract(calendar *model.Calendar) *contract.Calendar {
+	resp := &contract.Calendar{
+		RentalID:  calendar.RentalID,
+		MinNights: calendar.StayRules.MinNights,
+		MaxNights: calendar.StayRules.MaxNights,
+		Days:      make([]contract.CalendarDay, 0, len(calendar.Days)),
+	}
+
+	for _, day := range calendar.Days {
+		resp.Days = append(resp.Days, contract.CalendarDay{
+			Date:   day.Date.Format(time.DateOnly),
+			Status: string(day.Status),
+		})
+	}
+
+	return resp
+}
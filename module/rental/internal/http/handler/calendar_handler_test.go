@@ -0,0 +1,192 @@
+package handler_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi"
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/dragonator/rental-service/module/rental/internal/http/contract"
+	"github.com/dragonator/rental-service/module/rental/internal/http/handler"
+	"github.com/dragonator/rental-service/module/rental/internal/model"
+)
+
+var _calendar = &model.Calendar{
+	RentalID:  2,
+	StayRules: model.StayRules{MinNights: 2},
+	Days: []model.CalendarDay{
+		{Date: time.Date(2023, 7, 1, 0, 0, 0, 0, time.UTC), Status: model.DayStatusAvailable},
+		{Date: time.Date(2023, 7, 2, 0, 0, 0, 0, time.UTC), Status: model.DayStatusBlocked},
+	},
+}
+
+func TestCalendarHandler_GetCalendar(t *testing.T) {
+	testCases := []struct {
+		name          string
+		query         string
+		expectedStart time.Time
+		expectedEnd   time.Time
+		expectedCalls int
+		expectedCode  int
+	}{
+		{
+			name:          "Single month",
+			query:         "?from=2023-07",
+			expectedStart: time.Date(2023, 7, 1, 0, 0, 0, 0, time.UTC),
+			expectedEnd:   time.Date(2023, 8, 1, 0, 0, 0, 0, time.UTC),
+			expectedCalls: 1,
+			expectedCode:  http.StatusOK,
+		},
+		{
+			name:          "Month range",
+			query:         "?from=2023-11&to=2024-01",
+			expectedStart: time.Date(2023, 11, 1, 0, 0, 0, 0, time.UTC),
+			expectedEnd:   time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC),
+			expectedCalls: 1,
+			expectedCode:  http.StatusOK,
+		},
+		{
+			name:          "Invalid month",
+			query:         "?from=2023-07-01",
+			expectedCalls: 0,
+			expectedCode:  http.StatusBadRequest,
+		},
+		{
+			name:          "Reversed months",
+			query:         "?from=2023-07&to=2023-06",
+			expectedCalls: 0,
+			expectedCode:  http.StatusBadRequest,
+		},
+		{
+			name:          "Too many months",
+			query:         "?from=2023-01&to=2024-01",
+			expectedCalls: 0,
+			expectedCode:  http.StatusBadRequest,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockCalendarManagingOp := &CalendarManagingOpMock{
+				GetCalendarFunc: func(ctx context.Context, rentalID int, start, end time.Time) (*model.Calendar, error) {
+					return _calendar, nil
+				},
+			}
+
+			calendarHandler := handler.NewCalendarHandler(mockCalendarManagingOp)
+
+			router := chi.NewRouter()
+			router.Get("/rentals/{id}/calendar", calendarHandler.GetCalendar("GET", "/rentals/{id}/calendar"))
+
+			request := httptest.NewRequest("GET", "/rentals/2/calendar"+tc.query, nil)
+			responseRecorder := httptest.NewRecorder()
+
+			router.ServeHTTP(responseRecorder, request)
+
+			calls := mockCalendarManagingOp.GetCalendarCalls()
+			if tc.expectedCalls != len(calls) {
+				t.Fatalf("Unexpected number of calls to GetCalendar:\nexpected: %d\ngot      %d", tc.expectedCalls, len(calls))
+			}
+
+			if tc.expectedCalls > 0 && (!calls[0].Start.Equal(tc.expectedStart) || !calls[0].End.Equal(tc.expectedEnd)) {
+				t.Fatalf("Unexpected period:\nexpected: %v - %v\ngot:      %v - %v", tc.expectedStart, tc.expectedEnd, calls[0].Start, calls[0].End)
+			}
+
+			if responseRecorder.Code != tc.expectedCode {
+				t.Fatalf("Unexpected status code:\nexpected %d\ngot:      %d", tc.expectedCode, responseRecorder.Code)
+			}
+
+			if tc.expectedCode == http.StatusOK {
+				var responseBody contract.GetCalendarResponse
+
+				if err := json.NewDecoder(responseRecorder.Body).Decode(&responseBody); err != nil {
+					t.Fatalf("Failed to decode response body: %v", err)
+				}
+
+				expected := contract.GetCalendarResponse{Calendar: contract.Calendar{
+					RentalID:  2,
+					MinNights: 2,
+					Days: []contract.CalendarDay{
+						{Date: "2023-07-01", Status: "available"},
+						{Date: "2023-07-02", Status: "blocked"},
+					},
+				}}
+
+				if !cmp.Equal(responseBody, expected) {
+					t.Fatalf("Unexpected calendar:\nexpected: %v\ngot:      %v", expected, responseBody)
+				}
+			}
+		})
+	}
+}
+
+func TestCalendarHandler_UpdateCalendar(t *testing.T) {
+	testCases := []struct {
+		name                string
+		body                string
+		expectedRules       *model.StayRules
+		expectedBlockedDays []time.Time
+		expectedCalls       int
+		expectedCode        int
+	}{
+		{
+			name:                "Valid calendar",
+			body:                `{"min_nights":2,"max_nights":14,"blocked_days":["2023-07-02"]}`,
+			expectedRules:       &model.StayRules{MinNights: 2, MaxNights: toPtr(14)},
+			expectedBlockedDays: []time.Time{time.Date(2023, 7, 2, 0, 0, 0, 0, time.UTC)},
+			expectedCalls:       1,
+			expectedCode:        http.StatusOK,
+		},
+		{
+			name:          "Invalid blocked day",
+			body:          `{"min_nights":2,"blocked_days":["07/02/2023"]}`,
+			expectedCalls: 0,
+			expectedCode:  http.StatusBadRequest,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockCalendarManagingOp := &CalendarManagingOpMock{
+				UpdateCalendarFunc: func(ctx context.Context, rentalID int, rules *model.StayRules, start, end time.Time, blockedDays []time.Time) (*model.Calendar, error) {
+					return _calendar, nil
+				},
+			}
+
+			calendarHandler := handler.NewCalendarHandler(mockCalendarManagingOp)
+
+			router := chi.NewRouter()
+			router.Put("/rentals/{id}/calendar", calendarHandler.UpdateCalendar("PUT", "/rentals/{id}/calendar"))
+
+			request := httptest.NewRequest("PUT", "/rentals/2/calendar?from=2023-07", strings.NewReader(tc.body))
+			responseRecorder := httptest.NewRecorder()
+
+			router.ServeHTTP(responseRecorder, request)
+
+			calls := mockCalendarManagingOp.UpdateCalendarCalls()
+			if tc.expectedCalls != len(calls) {
+				t.Fatalf("Unexpected number of calls to UpdateCalendar:\nexpected: %d\ngot      %d", tc.expectedCalls, len(calls))
+			}
+
+			if tc.expectedCalls > 0 {
+				if !cmp.Equal(calls[0].Rules, tc.expectedRules) {
+					t.Fatalf("Unexpected rules:\nexpected: %v\ngot:      %v", tc.expectedRules, calls[0].Rules)
+				}
+
+				if !cmp.Equal(calls[0].BlockedDays, tc.expectedBlockedDays) {
+					t.Fatalf("Unexpected blocked days:\nexpected: %v\ngot:      %v", tc.expectedBlockedDays, calls[0].BlockedDays)
+				}
+			}
+
+			if responseRecorder.Code != tc.expectedCode {
+				t.Fatalf("Unexpected status code:\nexpected %d\ngot:      %d", tc.expectedCode, responseRecorder.Code)
+			}
+		})
+	}
+}
@@ -0,0 +1,312 @@
+package handler
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi"
+	"github.com/gorilla/schema"
+
+	"github.com/dragonator/rental-service/module/rental/internal/http/contract"
+	"github.com/dragonator/rental-service/module/rental/internal/http/service/svc"
+	"github.com/dragonator/rental-service/module/rental/internal/model"
+	"github.com/dragonator/rental-service/module/rental/internal/storage"
+)
+
+// ConversationManagingOp is a contract to a conversation managing operation.
+//
+//go:generate moq -rm -pkg handler_test -out conversation_managing_op_mock_test.go . ConversationManagingOp
+type ConversationManagingOp interface {
+	GetConversationByID(ctx context.Context, conversationID int) (*model.Conversation, error)
+	ListConversations(ctx context.Context, userID int) (model.Conversations, error)
+	CountUnread(ctx context.Context, userID int) (int, error)
+	StartConversation(ctx context.Context, rentalID int, message *model.Message) (*model.Conversation, error)
+	GetMessage(ctx context.Context, conversationID, messageID int) (*model.Message, error)
+	ListMessages(ctx context.Context, conversationID int, page *storage.CursorPagination) (model.Messages, *int32, error)
+	PostMessage(ctx context.Context, message *model.Message) (*model.Message, error)
+	MarkRead(ctx context.Context, conversationID, userID int) error
+}
+
+// ConversationHandler holds implementation of handlers for conversations and their messages.
+type ConversationHandler struct {
+	conversationManagingOp ConversationManagingOp
+}
+
+// NewConversationHandler is a construction function for ConversationHandler.
+func NewConversationHandler(conversationManagingOp ConversationManagingOp) *ConversationHandler {
+	return &ConversationHandler{
+		conversationManagingOp: conversationManagingOp,
+	}
+}
+
+// StartConversation returns a handle that is starting a conversation of a renter with the owner of a rental.
+func (ch *ConversationHandler) StartConversation(method, path string) func(w http.ResponseWriter, r *http.Request) {
+	return func(w http.ResponseWriter, r *http.Request) {
+		rentalID, err := strconv.Atoi(chi.URLParam(r, "id"))
+		if err != nil {
+			errorResponse(w, fmt.Errorf("%w: id", svc.ErrInvalidQueryParameters))
+			return
+		}
+
+		var req contract.PostMessageRequest
+		if err := decodeJSONBody(r, &req); err != nil {
+			errorResponse(w, err)
+			return
+		}
+
+		message := &model.Message{
+			SenderID: req.SenderID,
+			Body:     req.Body,
+		}
+
+		conversation, err := ch.conversationManagingOp.StartConversation(r.Context(), rentalID, message)
+		if err != nil {
+			errorResponse(w, err)
+			return
+		}
+
+		createdResponse(w, fmt.Sprintf("/conversations/%d", conversation.ID), toConversationContract(conversation))
+
+		return
+	}
+}
+
+// GetConversationByID returns a handle that is fetching a conversation by id.
+func (ch *ConversationHandler) GetConversationByID(method, path string) func(w http.ResponseWriter, r *http.Request) {
+	return func(w http.ResponseWriter, r *http.Request) {
+		conversationID, err := strconv.Atoi(chi.URLParam(r, "id"))
+		if err != nil {
+			errorResponse(w, fmt.Errorf("%w: id", svc.ErrInvalidQueryParameters))
+			return
+		}
+
+		conversation, err := ch.conversationManagingOp.GetConversationByID(r.Context(), conversationID)
+		if err != nil {
+			errorResponse(w, err)
+			return
+		}
+
+		successResponse(w, toConversationContract(conversation))
+
+		return
+	}
+}
+
+// ListConversations returns a handle that is listing the conversations of a user.
+func (ch *ConversationHandler) ListConversations(method, path string) func(w http.ResponseWriter, r *http.Request) {
+	return func(w http.ResponseWriter, r *http.Request) {
+		userID, err := strconv.Atoi(chi.URLParam(r, "id"))
+		if err != nil {
+			errorResponse(w, fmt.Errorf("%w: id", svc.ErrInvalidQueryParameters))
+			return
+		}
+
+		conversations, err := ch.conversationManagingOp.ListConversations(r.Context(), userID)
+		if err != nil {
+			errorResponse(w, err)
+			return
+		}
+
+		resp := make(contract.ListConversationsResponse, 0, len(conversations))
+		for _, conversation := range conversations {
+			resp = append(resp, toConversationContract(conversation))
+		}
+
+		successResponse(w, resp)
+
+		return
+	}
+}
+
+// CountUnread returns a handle that is counting the messages unread by a user.
+func (ch *ConversationHandler) CountUnread(method, path string) func(w http.ResponseWriter, r *http.Request) {
+	return func(w http.ResponseWriter, r *http.Request) {
+		userID, err := strconv.Atoi(chi.URLParam(r, "id"))
+		if err != nil {
+			errorResponse(w, fmt.Errorf("%w: id", svc.ErrInvalidQueryParameters))
+			return
+		}
+
+		count, err := ch.conversationManagingOp.CountUnread(r.Context(), userID)
+		if err != nil {
+			errorResponse(w, err)
+			return
+		}
+
+		successResponse(w, &contract.UnreadCountResponse{UnreadCount: count})
+
+		return
+	}
+}
+
+// ListMessages returns a handle that is listing a page of the messages of a conversation.
+func (ch *ConversationHandler) ListMessages(method, path string) func(w http.ResponseWriter, r *http.Request) {
+	return func(w http.ResponseWriter, r *http.Request) {
+		conversationID, err := strconv.Atoi(chi.URLParam(r, "id"))
+		if err != nil {
+			errorResponse(w, fmt.Errorf("%w: id", svc.ErrInvalidQueryParameters))
+			return
+		}
+
+		var query contract.ListMessagesQuery
+
+		if err := r.ParseForm(); err != nil {
+			errorResponse(w, fmt.Errorf("%w: parsing form: %w", svc.ErrInvalidQueryParameters, err))
+			return
+		}
+
+		if err := schema.NewDecoder().Decode(&query, r.Form); err != nil {
+			errorResponse(w, fmt.Errorf("%w: unmashalling query: %w", svc.ErrInvalidQueryParameters, err))
+			return
+		}
+
+		page := new(storage.CursorPagination)
+
+		if query.Limit != nil {
+			page.Limit = *query.Limit
+		}
+
+		if query.Cursor != nil {
+			after, err := decodeCursor(*query.Cursor)
+			if err != nil {
+				errorResponse(w, err)
+				return
+			}
+
+			page.After = &after
+		}
+
+		messages, next, err := ch.conversationManagingOp.ListMessages(r.Context(), conversationID, page)
+		if err != nil {
+			errorResponse(w, err)
+			return
+		}
+
+		resp := &contract.ListMessagesResponse{
+			Messages: make([]*contract.Message, 0, len(messages)),
+		}
+
+		for _, message := range messages {
+			resp.Messages = append(resp.Messages, toMessageContract(message))
+		}
+
+		if next != nil {
+			cursor := encodeCursor(*next)
+			resp.NextCursor = &cursor
+		}
+
+		successResponse(w, resp)
+
+		return
+	}
+}
+
+// GetMessage returns a handle that is fetching a message of a conversation by id.
+func (ch *ConversationHandler) GetMessage(method, path string) func(w http.ResponseWriter, r *http.Request) {
+	return func(w http.ResponseWriter, r *http.Request) {
+		conversationID, err := strconv.Atoi(chi.URLParam(r, "id"))
+		if err != nil {
+			errorResponse(w, fmt.Errorf("%w: id", svc.ErrInvalidQueryParameters))
+			return
+		}
+
+		messageID, err := strconv.Atoi(chi.URLParam(r, "message_id"))
+		if err != nil {
+			errorResponse(w, fmt.Errorf("%w: message_id", svc.ErrInvalidQueryParameters))
+			return
+		}
+
+		message, err := ch.conversationManagingOp.GetMessage(r.Context(), conversationID, messageID)
+		if err != nil {
+			errorResponse(w, err)
+			return
+		}
+
+		successResponse(w, toMessageContract(message))
+
+		return
+	}
+}
+
+// PostMessage returns a handle that is posting a message to a conversation.
+func (ch *ConversationHandler) PostMessage(method, path string) func(w http.ResponseWriter, r *http.Request) {
+	return func(w http.ResponseWriter, r *http.Request) {
+		conversationID, err := strconv.Atoi(chi.URLParam(r, "id"))
+		if err != nil {
+			errorResponse(w, fmt.Errorf("%w: id", svc.ErrInvalidQueryParameters))
+			return
+		}
+
+		var req contract.PostMessageRequest
+		if err := decodeJSONBody(r, &req); err != nil {
+			errorResponse(w, err)
+			return
+		}
+
+		message := &model.Message{
+			ConversationID: int32(conversationID),
+			SenderID:       req.SenderID,
+			Body:           req.Body,
+		}
+
+		created, err := ch.conversationManagingOp.PostMessage(r.Context(), message)
+		if err != nil {
+			errorResponse(w, err)
+			return
+		}
+
+		createdResponse(w, fmt.Sprintf("/conversations/%d/messages/%d", conversationID, created.ID), toMessageContract(created))
+
+		return
+	}
+}
+
+// MarkRead returns a handle that is marking the messages of a conversation as read by one of its participants.
+func (ch *ConversationHandler) MarkRead(method, path string) func(w http.ResponseWriter, r *http.Request) {
+	return func(w http.ResponseWriter, r *http.Request) {
+		conversationID, err := strconv.Atoi(chi.URLParam(r, "id"))
+		if err != nil {
+			errorResponse(w, fmt.Errorf("%w: id", svc.ErrInvalidQueryParameters))
+			return
+		}
+
+		var req contract.MarkReadRequest
+		if err := decodeJSONBody(r, &req); err != nil {
+			errorResponse(w, err)
+			return
+		}
+
+		if err := ch.conversationManagingOp.MarkRead(r.Context(), conversationID, int(req.UserID)); err != nil {
+			errorResponse(w, err)
+			return
+		}
+
+		noContentResponse(w)
+
+		return
+	}
+}
+
+func toConversationContract(conversation *model.Conversation) *contract.Conversation {
+	return &contract.Conversation{
+		ID:            conversation.ID,
+		RentalID:      conversation.RentalID,
+		RenterID:      conversation.RenterID,
+		OwnerID:       conversation.OwnerID,
+		UnreadCount:   conversation.UnreadCount,
+		LastMessageAt: conversation.LastMessageAt,
+		Created:       conversation.Created,
+	}
+}
+
+func toMessageContract(message *model.Message) *contract.Message {
+	return &contract.Message{
+		ID:             message.ID,
+		ConversationID: message.ConversationID,
+		SenderID:       message.SenderID,
+		Body:           message.Body,
+		Created:        message.Created,
+	}
+}
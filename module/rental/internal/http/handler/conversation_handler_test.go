@@ -0,0 +1,113 @@
+package handler_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi"
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/dragonator/rental-service/module/rental/internal/http/contract"
+	"github.com/dragonator/rental-service/module/rental/internal/http/handler"
+	"github.com/dragonator/rental-service/module/rental/internal/model"
+	"github.com/dragonator/rental-service/module/rental/internal/storage"
+)
+
+func TestConversationHandler_ListMessages(t *testing.T) {
+	created := time.Date(2023, 7, 1, 10, 0, 0, 0, time.UTC)
+	messages := model.Messages{
+		{ID: 9, ConversationID: 4, SenderID: 3, Body: "Yes, pets are welcome", Created: created},
+		{ID: 8, ConversationID: 4, SenderID: 2, Body: "Is the van pet friendly?", Created: created},
+	}
+
+	testCases := []struct {
+		name         string
+		query        string
+		next         *int32
+		expectedPage *storage.CursorPagination
+		expectedCode int
+		expectedBody *contract.ListMessagesResponse
+	}{
+		{
+			name:         "First page",
+			query:        "?limit=2",
+			next:         toPtr(int32(8)),
+			expectedPage: &storage.CursorPagination{Limit: 2},
+			expectedCode: http.StatusOK,
+			expectedBody: &contract.ListMessagesResponse{
+				Messages: []*contract.Message{
+					{ID: 9, ConversationID: 4, SenderID: 3, Body: "Yes, pets are welcome", Created: created},
+					{ID: 8, ConversationID: 4, SenderID: 2, Body: "Is the van pet friendly?", Created: created},
+				},
+				NextCursor: toPtr("OA"),
+			},
+		},
+		{
+			name:         "Last page",
+			query:        "?limit=2&cursor=MTA",
+			expectedPage: &storage.CursorPagination{Limit: 2, After: toPtr(int32(10))},
+			expectedCode: http.StatusOK,
+			expectedBody: &contract.ListMessagesResponse{
+				Messages: []*contract.Message{
+					{ID: 9, ConversationID: 4, SenderID: 3, Body: "Yes, pets are welcome", Created: created},
+					{ID: 8, ConversationID: 4, SenderID: 2, Body: "Is the van pet friendly?", Created: created},
+				},
+			},
+		},
+		{
+			name:         "Invalid cursor",
+			query:        "?cursor=abc",
+			expectedCode: http.StatusBadRequest,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockConversationManagingOp := &ConversationManagingOpMock{
+				ListMessagesFunc: func(ctx context.Context, conversationID int, page *storage.CursorPagination) (model.Messages, *int32, error) {
+					return messages, tc.next, nil
+				},
+			}
+
+			conversationHandler := handler.NewConversationHandler(mockConversationManagingOp)
+
+			router := chi.NewRouter()
+			router.Get("/conversations/{id}/messages", conversationHandler.ListMessages("GET", "/conversations/{id}/messages"))
+
+			request := httptest.NewRequest("GET", "/conversations/4/messages"+tc.query, nil)
+			responseRecorder := httptest.NewRecorder()
+
+			router.ServeHTTP(responseRecorder, request)
+
+			if responseRecorder.Code != tc.expectedCode {
+				t.Fatalf("Unexpected status code:\nexpected %d\ngot:      %d", tc.expectedCode, responseRecorder.Code)
+			}
+
+			calls := mockConversationManagingOp.ListMessagesCalls()
+			if tc.expectedPage == nil {
+				if len(calls) != 0 {
+					t.Fatalf("Unexpected calls to ListMessages: %v", calls)
+				}
+
+				return
+			}
+
+			if len(calls) != 1 || calls[0].ConversationID != 4 || !cmp.Equal(calls[0].Page, tc.expectedPage) {
+				t.Fatalf("Unexpected calls to ListMessages:\nexpected: 4 %v\ngot:      %v", tc.expectedPage, calls)
+			}
+
+			responseBody := new(contract.ListMessagesResponse)
+			if err := json.NewDecoder(responseRecorder.Body).Decode(responseBody); err != nil {
+				t.Fatalf("Failed to decode response body: %v", err)
+			}
+
+			if !cmp.Equal(responseBody, tc.expectedBody) {
+				t.Fatalf("Unexpected messages:\nexpected: %v\ngot:      %v", tc.expectedBody, responseBody)
+			}
+		})
+	}
+}
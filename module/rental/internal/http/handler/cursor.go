@@ -0,0 +1,220 @@
+package handler
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/dragonator/rental-service/module/rental/internal/http/service/svc"
+	"github.com/dragonator/rental-service/module/rental/internal/model"
+	"github.com/dragonator/rental-service/module/rental/internal/storage"
+)
+
+const _nextCursorHeaderName = "X-Next-Cursor"
+
+// encodeCursor returns an opaque cursor pointing after the result with the given id.
+func encodeCursor(id int32) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(strconv.Itoa(int(id))))
+}
+
+// decodeCursor returns the id of the result a cursor returned by encodeCursor points after.
+func decodeCursor(cursor string) (int32, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return 0, fmt.Errorf("%w: cursor", svc.ErrInvalidQueryParameters)
+	}
+
+	id, err := strconv.ParseInt(string(raw), 10, 32)
+	if err != nil || id <= 0 {
+		return 0, fmt.Errorf("%w: cursor", svc.ErrInvalidQueryParameters)
+	}
+
+	return int32(id), nil
+}
+
+// rentalCursor is the content of a cursor pointing after a rental. It holds the sort the rentals
+// are ordered by, the values of the sort fields of the rental and its id.
+type rentalCursor struct {
+	Sort   string            `json:"s"`
+	Values []json.RawMessage `json:"v"`
+	ID     int32             `json:"id"`
+}
+
+// encodeRentalCursor returns an opaque cursor pointing after the given rental in rentals ordered by sort.
+func encodeRentalCursor(sort []storage.SortTerm, rental *model.Rental) (string, error) {
+	cursor := rentalCursor{
+		Sort:   sortSpec(sort),
+		Values: make([]json.RawMessage, 0, len(sort)),
+		ID:     rental.ID,
+	}
+
+	for _, term := range sort {
+		value, err := json.Marshal(sortValue(rental, term.Field))
+		if err != nil {
+			return "", fmt.Errorf("encoding cursor: %w", err)
+		}
+
+		cursor.Values = append(cursor.Values, value)
+	}
+
+	raw, err := json.Marshal(cursor)
+	if err != nil {
+		return "", fmt.Errorf("encoding cursor: %w", err)
+	}
+
+	return base64.RawURLEncoding.EncodeToString(raw), nil
+}
+
+// decodeRentalCursor returns the key of the rental a cursor returned by encodeRentalCursor points after.
+// The cursor has to be used with the sort it was returned for.
+func decodeRentalCursor(cursor string, sort []storage.SortTerm) (*storage.RentalKey, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return nil, fmt.Errorf("%w: cursor", svc.ErrInvalidQueryParameters)
+	}
+
+	var decoded rentalCursor
+	if err := json.Unmarshal(raw, &decoded); err != nil || decoded.ID <= 0 || len(decoded.Values) != len(sort) {
+		return nil, fmt.Errorf("%w: cursor", svc.ErrInvalidQueryParameters)
+	}
+
+	if decoded.Sort != sortSpec(sort) {
+		return nil, fmt.Errorf("%w: cursor was returned for another sort", svc.ErrInvalidQueryParameters)
+	}
+
+	key := &storage.RentalKey{
+		Values: make([]any, 0, len(sort)),
+		ID:     decoded.ID,
+	}
+
+	for i, term := range sort {
+		value, err := decodeSortValue(term.Field, decoded.Values[i])
+		if err != nil {
+			return nil, fmt.Errorf("%w: cursor", svc.ErrInvalidQueryParameters)
+		}
+
+		key.Values = append(key.Values, value)
+	}
+
+	return key, nil
+}
+
+// setNextCursor sets the cursor of the page following the listed rentals. Only full pages of rentals
+// listed without an offset have a following page, and rentals ranked by relevance cannot be paged by cursor.
+func setNextCursor(w http.ResponseWriter, filters *storage.RentalFilters, rentals model.Rentals) error {
+	if filters.Limit == nil || *filters.Limit <= 0 || len(rentals) < *filters.Limit || filters.Offset != nil {
+		return nil
+	}
+
+	if filters.Search != nil && len(filters.OrderBy) == 0 {
+		return nil
+	}
+
+	cursor, err := encodeRentalCursor(filters.OrderBy, rentals[len(rentals)-1])
+	if err != nil {
+		return err
+	}
+
+	w.Header().Set(_nextCursorHeaderName, cursor)
+
+	return nil
+}
+
+func sortSpec(sort []storage.SortTerm) string {
+	fields := make([]string, 0, len(sort))
+	for _, term := range sort {
+		if term.Descending {
+			fields = append(fields, "-"+term.Field)
+		} else {
+			fields = append(fields, term.Field)
+		}
+	}
+
+	return strings.Join(fields, ",")
+}
+
+// sortValue returns the value of the given sort field of a rental.
+func sortValue(rental *model.Rental, field string) any {
+	switch field {
+	case "id":
+		return rental.ID
+	case "name":
+		return rental.Name
+	case "type":
+		return rental.Type
+	case "make":
+		return rental.VehicleMake
+	case "model":
+		return rental.VehicleModel
+	case "year":
+		return rental.VehicleYear
+	case "length":
+		// Lengths are kept with two decimals, which a float32 does not hold exactly.
+		return strconv.FormatFloat(float64(rental.VehicleLength), 'f', 2, 32)
+	case "sleeps":
+		return rental.Sleeps
+	case "price_per_day":
+		return rental.PricePerDay
+	case "rating":
+		if rental.RatingAverage == nil {
+			return nil
+		}
+
+		return *rental.RatingAverage
+	case "distance":
+		if rental.Distance == nil {
+			return nil
+		}
+
+		return *rental.Distance
+	}
+
+	return nil
+}
+
+// decodeSortValue decodes a value of the given sort field encoded by encodeRentalCursor
+// into the type sortValue returns for the field.
+func decodeSortValue(field string, raw json.RawMessage) (any, error) {
+	switch field {
+	case "id", "year", "sleeps":
+		var value int32
+		err := json.Unmarshal(raw, &value)
+		return value, err
+	case "name", "type", "make", "model":
+		var value string
+		err := json.Unmarshal(raw, &value)
+		return value, err
+	case "length":
+		var value string
+		if err := json.Unmarshal(raw, &value); err != nil {
+			return nil, err
+		}
+
+		if length, err := strconv.ParseFloat(value, 64); err != nil || math.IsNaN(length) || math.IsInf(length, 0) {
+			return nil, fmt.Errorf("invalid length %q", value)
+		}
+
+		return value, nil
+	case "price_per_day":
+		var value int64
+		err := json.Unmarshal(raw, &value)
+		return value, err
+	case "distance":
+		var value float64
+		err := json.Unmarshal(raw, &value)
+		return value, err
+	case "rating":
+		var value *float64
+		if err := json.Unmarshal(raw, &value); err != nil || value == nil {
+			return nil, err
+		}
+
+		return *value, nil
+	}
+
+	return nil, fmt.Errorf("unexpected sort field %q", field)
+}
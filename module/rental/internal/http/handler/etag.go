@@ -0,0 +1,46 @@
+package handler
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/dragonator/rental-service/module/rental/internal/http/service/svc"
+)
+
+const (
+	_etagHeaderName    = "ETag"
+	_ifMatchHeaderName = "If-Match"
+)
+
+// etag derives an entity tag from the time an entity was last updated.
+func etag(updated time.Time) string {
+	return fmt.Sprintf("%q", strconv.FormatInt(updated.UnixNano(), 36))
+}
+
+// versionFromIfMatch returns the last update time encoded in the If-Match header of the request.
+// It returns nil when the header is missing or matches any version.
+func versionFromIfMatch(r *http.Request) (*time.Time, error) {
+	ifMatch := strings.TrimSpace(r.Header.Get(_ifMatchHeaderName))
+	if ifMatch == "" || ifMatch == "*" {
+		return nil, nil
+	}
+
+	tag := strings.TrimPrefix(ifMatch, "W/")
+
+	unquoted, err := strconv.Unquote(tag)
+	if err != nil {
+		return nil, fmt.Errorf("%w: malformed If-Match header", svc.ErrPreconditionFailed)
+	}
+
+	nanos, err := strconv.ParseInt(unquoted, 36, 64)
+	if err != nil {
+		return nil, fmt.Errorf("%w: malformed If-Match header", svc.ErrPreconditionFailed)
+	}
+
+	version := time.Unix(0, nanos).UTC()
+
+	return &version, nil
+}
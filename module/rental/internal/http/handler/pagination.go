@@ -0,0 +1,119 @@
+package handler
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/dragonator/rental-service/module/rental/internal/http/contract"
+	"github.com/dragonator/rental-service/module/rental/internal/model"
+	"github.com/dragonator/rental-service/module/rental/internal/storage"
+)
+
+const _linkHeaderName = "Link"
+
+// respondRentalsPage responds with a page of rentals listed by the filters of the request.
+// When the request asks for an envelope, the rentals are wrapped along with pagination metadata
+// and Link headers point to the first, previous, next and last pages.
+func respondRentalsPage(
+	w http.ResponseWriter,
+	r *http.Request,
+	rentalFetchingOp RentalFetchingOp,
+	filters *storage.RentalFilters,
+	rentals model.Rentals,
+) {
+	if err := setNextCursor(w, filters, rentals); err != nil {
+		errorResponse(w, err)
+		return
+	}
+
+	// The envelope value has been validated while decoding the filters of the request.
+	if envelope, _ := strconv.ParseBool(r.Form.Get("envelope")); !envelope {
+		successResponse(w, toListRentalsResponse(rentals))
+		return
+	}
+
+	total, err := rentalFetchingOp.CountRentals(r.Context(), filters)
+	if err != nil {
+		errorResponse(w, err)
+		return
+	}
+
+	meta := contract.PageMeta{
+		Total: total,
+		Limit: filters.Limit,
+	}
+
+	if filters.Offset != nil {
+		meta.Offset = *filters.Offset
+	}
+
+	nextCursor := w.Header().Get(_nextCursorHeaderName)
+	if nextCursor != "" {
+		meta.NextCursor = &nextCursor
+	}
+
+	if links := pageLinks(r.URL, meta, nextCursor, filters.After != nil); len(links) > 0 {
+		w.Header().Set(_linkHeaderName, strings.Join(links, ", "))
+	}
+
+	successResponse(w, &contract.ListRentalsEnvelope{
+		Data: *toListRentalsResponse(rentals),
+		Meta: meta,
+	})
+}
+
+// pageLinks returns RFC 8288 links to the pages around the page of the given metadata. Pages
+// requested by cursor link to the first page and the next one only, as there is no way back.
+func pageLinks(u *url.URL, meta contract.PageMeta, nextCursor string, byCursor bool) []string {
+	if meta.Limit == nil || *meta.Limit <= 0 {
+		return nil
+	}
+
+	limit := *meta.Limit
+	links := []string{pageLink(u, "first", "", "")}
+
+	if byCursor {
+		if nextCursor != "" {
+			links = append(links, pageLink(u, "next", "cursor", nextCursor))
+		}
+
+		return links
+	}
+
+	if meta.Offset > 0 {
+		prev := meta.Offset - limit
+		if prev < 0 {
+			prev = 0
+		}
+
+		links = append(links, pageLink(u, "prev", "offset", strconv.Itoa(prev)))
+	}
+
+	if meta.Offset+limit < meta.Total {
+		links = append(links, pageLink(u, "next", "offset", strconv.Itoa(meta.Offset+limit)))
+	}
+
+	last := 0
+	if meta.Total > 0 {
+		last = (meta.Total - 1) / limit * limit
+	}
+
+	return append(links, pageLink(u, "last", "offset", strconv.Itoa(last)))
+}
+
+// pageLink returns a link of the given relation to the request URL, paged by either offset or cursor.
+// Without a paging parameter the link points to the first page.
+func pageLink(u *url.URL, rel, param, value string) string {
+	query := u.Query()
+	query.Del("offset")
+	query.Del("cursor")
+
+	if param != "" {
+		query.Set(param, value)
+	}
+
+	return fmt.Sprintf(`<%s?%s>; rel="%s"`, u.Path, query.Encode(), rel)
+}
@@ -0,0 +1,216 @@
+package handler
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/chi"
+
+	"github.com/dragonator/rental-service/module/rental/internal/http/contract"
+	"github.com/dragonator/rental-service/module/rental/internal/http/service/svc"
+	"github.com/dragonator/rental-service/module/rental/internal/model"
+)
+
+// PricingRuleManagingOp is a contract to a pricing rule managing operation.
+//
+//go:generate moq -rm -pkg handler_test -out pricing_rule_managing_op_mock_test.go . PricingRuleManagingOp
+type PricingRuleManagingOp interface {
+	ListPricingRules(ctx context.Context, rentalID int) (model.PricingRules, error)
+	CreatePricingRule(ctx context.Context, rule *model.PricingRule) (*model.PricingRule, error)
+	UpdatePricingRule(ctx context.Context, rule *model.PricingRule) (*model.PricingRule, error)
+	DeletePricingRule(ctx context.Context, rentalID, ruleID int) error
+}
+
+// PricingRuleHandler holds implementation of handlers for the pricing rules of rentals.
+type PricingRuleHandler struct {
+	pricingRuleManagingOp PricingRuleManagingOp
+}
+
+// NewPricingRuleHandler is a construction function for PricingRuleHandler.
+func NewPricingRuleHandler(pricingRuleManagingOp PricingRuleManagingOp) *PricingRuleHandler {
+	return &PricingRuleHandler{
+		pricingRuleManagingOp: pricingRuleManagingOp,
+	}
+}
+
+// ListPricingRules returns a handle that is listing the pricing rules of a rental.
+func (ph *PricingRuleHandler) ListPricingRules(method, path string) func(w http.ResponseWriter, r *http.Request) {
+	return func(w http.ResponseWriter, r *http.Request) {
+		rentalID, err := strconv.Atoi(chi.URLParam(r, "id"))
+		if err != nil {
+			errorResponse(w, fmt.Errorf("%w: id", svc.ErrInvalidQueryParameters))
+			return
+		}
+
+		rules, err := ph.pricingRuleManagingOp.ListPricingRules(r.Context(), rentalID)
+		if err != nil {
+			errorResponse(w, err)
+			return
+		}
+
+		resp := make(contract.ListPricingRulesResponse, 0, len(rules))
+		for _, rule := range rules {
+			resp = append(resp, toPricingRuleContract(rule))
+		}
+
+		successResponse(w, resp)
+
+		return
+	}
+}
+
+// CreatePricingRule returns a handle that is creating a pricing rule of a rental.
+func (ph *PricingRuleHandler) CreatePricingRule(method, path string) func(w http.ResponseWriter, r *http.Request) {
+	return func(w http.ResponseWriter, r *http.Request) {
+		rentalID, err := strconv.Atoi(chi.URLParam(r, "id"))
+		if err != nil {
+			errorResponse(w, fmt.Errorf("%w: id", svc.ErrInvalidQueryParameters))
+			return
+		}
+
+		var req contract.PricingRuleRequest
+		if err := decodeJSONBody(r, &req); err != nil {
+			errorResponse(w, err)
+			return
+		}
+
+		rule, err := toPricingRuleModel(rentalID, 0, &req)
+		if err != nil {
+			errorResponse(w, err)
+			return
+		}
+
+		created, err := ph.pricingRuleManagingOp.CreatePricingRule(r.Context(), rule)
+		if err != nil {
+			errorResponse(w, err)
+			return
+		}
+
+		location := fmt.Sprintf("/rentals/%d/pricing-rules/%d", created.RentalID, created.ID)
+		createdResponse(w, location, toPricingRuleContract(created))
+
+		return
+	}
+}
+
+// UpdatePricingRule returns a handle that is replacing a pricing rule of a rental.
+func (ph *PricingRuleHandler) UpdatePricingRule(method, path string) func(w http.ResponseWriter, r *http.Request) {
+	return func(w http.ResponseWriter, r *http.Request) {
+		rentalID, ruleID, err := pricingRuleIDsFromRequest(r)
+		if err != nil {
+			errorResponse(w, err)
+			return
+		}
+
+		var req contract.PricingRuleRequest
+		if err := decodeJSONBody(r, &req); err != nil {
+			errorResponse(w, err)
+			return
+		}
+
+		rule, err := toPricingRuleModel(rentalID, ruleID, &req)
+		if err != nil {
+			errorResponse(w, err)
+			return
+		}
+
+		updated, err := ph.pricingRuleManagingOp.UpdatePricingRule(r.Context(), rule)
+		if err != nil {
+			errorResponse(w, err)
+			return
+		}
+
+		successResponse(w, toPricingRuleContract(updated))
+
+		return
+	}
+}
+
+// DeletePricingRule returns a handle that is deleting a pricing rule of a rental.
+func (ph *PricingRuleHandler) DeletePricingRule(method, path string) func(w http.ResponseWriter, r *http.Request) {
+	return func(w http.ResponseWriter, r *http.Request) {
+		rentalID, ruleID, err := pricingRuleIDsFromRequest(r)
+		if err != nil {
+			errorResponse(w, err)
+			return
+		}
+
+		if err := ph.pricingRuleManagingOp.DeletePricingRule(r.Context(), rentalID, ruleID); err != nil {
+			errorResponse(w, err)
+			return
+		}
+
+		noContentResponse(w)
+
+		return
+	}
+}
+
+func pricingRuleIDsFromRequest(r *http.Request) (int, int, error) {
+	rentalID, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		return 0, 0, fmt.Errorf("%w: id", svc.ErrInvalidQueryParameters)
+	}
+
+	ruleID, err := strconv.Atoi(chi.URLParam(r, "rule_id"))
+	if err != nil {
+		return 0, 0, fmt.Errorf("%w: rule_id", svc.ErrInvalidQueryParameters)
+	}
+
+	return rentalID, ruleID, nil
+}
+
+func toPricingRuleContract(rule *model.PricingRule) *contract.PricingRule {
+	resp := &contract.PricingRule{
+		ID:          rule.ID,
+		RentalID:    rule.RentalID,
+		Type:        string(rule.Type),
+		PricePerDay: rule.PricePerDay,
+		Percent:     rule.Percent,
+	}
+
+	if rule.StartDate != nil {
+		startDate := rule.StartDate.Format(time.DateOnly)
+		resp.StartDate = &startDate
+	}
+
+	if rule.EndDate != nil {
+		endDate := rule.EndDate.Format(time.DateOnly)
+		resp.EndDate = &endDate
+	}
+
+	return resp
+}
+
+func toPricingRuleModel(rentalID, ruleID int, req *contract.PricingRuleRequest) (*model.PricingRule, error) {
+	rule := &model.PricingRule{
+		ID:          int32(ruleID),
+		RentalID:    int32(rentalID),
+		Type:        model.PricingRuleType(req.Type),
+		PricePerDay: req.PricePerDay,
+		Percent:     req.Percent,
+	}
+
+	if req.StartDate != nil {
+		startDate, err := time.Parse(time.DateOnly, *req.StartDate)
+		if err != nil {
+			return nil, fmt.Errorf("%w: start_date: expected format YYYY-MM-DD", svc.ErrInvalidRequestBody)
+		}
+
+		rule.StartDate = &startDate
+	}
+
+	if req.EndDate != nil {
+		endDate, err := time.Parse(time.DateOnly, *req.EndDate)
+		if err != nil {
+			return nil, fmt.Errorf("%w: end_date: expected format YYYY-MM-DD", svc.ErrInvalidRequestBody)
+		}
+
+		rule.EndDate = &endDate
+	}
+
+	return rule, nil
+}
@@ -0,0 +1,203 @@
+package handler_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi"
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/dragonator/rental-service/module/rental/internal/http/contract"
+	"github.com/dragonator/rental-service/module/rental/internal/http/handler"
+	"github.com/dragonator/rental-service/module/rental/internal/http/service/svc"
+	"github.com/dragonator/rental-service/module/rental/internal/model"
+)
+
+func TestPricingRuleHandler_ListPricingRules(t *testing.T) {
+	rules := model.PricingRules{
+		{
+			ID:          1,
+			RentalID:    2,
+			Type:        model.PricingRuleSeason,
+			StartDate:   toPtr(time.Date(2023, 7, 1, 0, 0, 0, 0, time.UTC)),
+			EndDate:     toPtr(time.Date(2023, 9, 1, 0, 0, 0, 0, time.UTC)),
+			PricePerDay: toPtr[int64](20000),
+		},
+		{ID: 2, RentalID: 2, Type: model.PricingRuleWeeklyDiscount, Percent: toPtr(10.0)},
+	}
+
+	mockPricingRuleManagingOp := &PricingRuleManagingOpMock{
+		ListPricingRulesFunc: func(ctx context.Context, rentalID int) (model.PricingRules, error) {
+			return rules, nil
+		},
+	}
+
+	pricingRuleHandler := handler.NewPricingRuleHandler(mockPricingRuleManagingOp)
+
+	router := chi.NewRouter()
+	router.Get("/rentals/{id}/pricing-rules", pricingRuleHandler.ListPricingRules("GET", "/rentals/{id}/pricing-rules"))
+
+	request := httptest.NewRequest("GET", "/rentals/2/pricing-rules", nil)
+	responseRecorder := httptest.NewRecorder()
+
+	router.ServeHTTP(responseRecorder, request)
+
+	if responseRecorder.Code != http.StatusOK {
+		t.Fatalf("Unexpected status code:\nexpected %d\ngot:      %d", http.StatusOK, responseRecorder.Code)
+	}
+
+	var responseBody contract.ListPricingRulesResponse
+
+	if err := json.NewDecoder(responseRecorder.Body).Decode(&responseBody); err != nil {
+		t.Fatalf("Failed to decode response body: %v", err)
+	}
+
+	expectedRules := contract.ListPricingRulesResponse{
+		{ID: 1, RentalID: 2, Type: "season", StartDate: toPtr("2023-07-01"), EndDate: toPtr("2023-09-01"), PricePerDay: toPtr[int64](20000)},
+		{ID: 2, RentalID: 2, Type: "weekly_discount", Percent: toPtr(10.0)},
+	}
+	if !cmp.Equal(responseBody, expectedRules) {
+		t.Fatalf("Unexpected pricing rules:\nexpected: %v\ngot:      %v", expectedRules, responseBody)
+	}
+}
+
+func TestPricingRuleHandler_CreatePricingRule(t *testing.T) {
+	testCases := []struct {
+		name             string
+		body             string
+		createErr        error
+		expectedCalls    int
+		expectedRule     *model.PricingRule
+		expectedCode     int
+		expectedLocation string
+	}{
+		{
+			name:          "Season",
+			body:          `{"type":"season","start_date":"2023-07-01","end_date":"2023-09-01","price_per_day":20000}`,
+			expectedCalls: 1,
+			expectedRule: &model.PricingRule{
+				RentalID:    2,
+				Type:        model.PricingRuleSeason,
+				StartDate:   toPtr(time.Date(2023, 7, 1, 0, 0, 0, 0, time.UTC)),
+				EndDate:     toPtr(time.Date(2023, 9, 1, 0, 0, 0, 0, time.UTC)),
+				PricePerDay: toPtr[int64](20000),
+			},
+			expectedCode:     http.StatusCreated,
+			expectedLocation: "/rentals/2/pricing-rules/3",
+		},
+		{
+			name:          "Invalid date",
+			body:          `{"type":"season","start_date":"07/01/2023","end_date":"2023-09-01","price_per_day":20000}`,
+			expectedCalls: 0,
+			expectedCode:  http.StatusBadRequest,
+		},
+		{
+			name:          "Overlapping rule",
+			body:          `{"type":"weekly_discount","percent":10}`,
+			createErr:     svc.ErrConflict,
+			expectedCalls: 1,
+			expectedRule:  &model.PricingRule{RentalID: 2, Type: model.PricingRuleWeeklyDiscount, Percent: toPtr(10.0)},
+			expectedCode:  http.StatusConflict,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockPricingRuleManagingOp := &PricingRuleManagingOpMock{
+				CreatePricingRuleFunc: func(ctx context.Context, rule *model.PricingRule) (*model.PricingRule, error) {
+					if tc.createErr != nil {
+						return nil, tc.createErr
+					}
+
+					created := *rule
+					created.ID = 3
+
+					return &created, nil
+				},
+			}
+
+			pricingRuleHandler := handler.NewPricingRuleHandler(mockPricingRuleManagingOp)
+
+			router := chi.NewRouter()
+			router.Post("/rentals/{id}/pricing-rules", pricingRuleHandler.CreatePricingRule("POST", "/rentals/{id}/pricing-rules"))
+
+			request := httptest.NewRequest("POST", "/rentals/2/pricing-rules", strings.NewReader(tc.body))
+			responseRecorder := httptest.NewRecorder()
+
+			router.ServeHTTP(responseRecorder, request)
+
+			calls := mockPricingRuleManagingOp.CreatePricingRuleCalls()
+			if len(calls) != tc.expectedCalls {
+				t.Fatalf("Unexpected number of calls to CreatePricingRule:\nexpected: %d\ngot      %d", tc.expectedCalls, len(calls))
+			}
+
+			if tc.expectedCalls > 0 && !cmp.Equal(calls[0].Rule, tc.expectedRule) {
+				t.Fatalf("Unexpected pricing rule:\nexpected: %v\ngot:      %v", tc.expectedRule, calls[0].Rule)
+			}
+
+			if responseRecorder.Code != tc.expectedCode {
+				t.Fatalf("Unexpected status code:\nexpected %d\ngot:      %d", tc.expectedCode, responseRecorder.Code)
+			}
+
+			if location := responseRecorder.Header().Get("Location"); location != tc.expectedLocation {
+				t.Fatalf("Unexpected location:\nexpected: %s\ngot:      %s", tc.expectedLocation, location)
+			}
+		})
+	}
+}
+
+func TestPricingRuleHandler_DeletePricingRule(t *testing.T) {
+	testCases := []struct {
+		name         string
+		path         string
+		deleteErr    error
+		expectedCode int
+	}{
+		{
+			name:         "Existing rule",
+			path:         "/rentals/2/pricing-rules/3",
+			expectedCode: http.StatusNoContent,
+		},
+		{
+			name:         "Missing rule",
+			path:         "/rentals/2/pricing-rules/4",
+			deleteErr:    svc.ErrNotFound,
+			expectedCode: http.StatusNotFound,
+		},
+		{
+			name:         "Invalid rule id",
+			path:         "/rentals/2/pricing-rules/a",
+			expectedCode: http.StatusBadRequest,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockPricingRuleManagingOp := &PricingRuleManagingOpMock{
+				DeletePricingRuleFunc: func(ctx context.Context, rentalID, ruleID int) error {
+					return tc.deleteErr
+				},
+			}
+
+			pricingRuleHandler := handler.NewPricingRuleHandler(mockPricingRuleManagingOp)
+
+			router := chi.NewRouter()
+			router.Delete("/rentals/{id}/pricing-rules/{rule_id}",
+				pricingRuleHandler.DeletePricingRule("DELETE", "/rentals/{id}/pricing-rules/{rule_id}"))
+
+			request := httptest.NewRequest("DELETE", tc.path, nil)
+			responseRecorder := httptest.NewRecorder()
+
+			router.ServeHTTP(responseRecorder, request)
+
+			if responseRecorder.Code != tc.expectedCode {
+				t.Fatalf("Unexpected status code:\nexpected %d\ngot:      %d", tc.expectedCode, responseRecorder.Code)
+			}
+		})
+	}
+}
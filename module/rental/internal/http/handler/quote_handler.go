@@ -0,0 +1,118 @@
+package handler
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/chi"
+	"github.com/gorilla/schema"
+
+	"github.com/dragonator/rental-service/module/rental/internal/http/contract"
+	"github.com/dragonator/rental-service/module/rental/internal/http/service/svc"
+	"github.com/dragonator/rental-service/module/rental/internal/pricing"
+)
+
+// RentalQuotingOp is a contract to a rental quoting operation.
+//
+//go:generate moq -rm -pkg handler_test -out rental_quoting_op_mock_test.go . RentalQuotingOp
+type RentalQuotingOp interface {
+	QuoteRental(ctx context.Context, rentalID int, start, end time.Time) (*pricing.Quote, error)
+}
+
+// QuoteHandler holds implementation of handlers for price quotes.
+type QuoteHandler struct {
+	rentalQuotingOp RentalQuotingOp
+}
+
+// NewQuoteHandler is a construction function for QuoteHandler.
+func NewQuoteHandler(rentalQuotingOp RentalQuotingOp) *QuoteHandler {
+	return &QuoteHandler{
+		rentalQuotingOp: rentalQuotingOp,
+	}
+}
+
+// QuoteRental returns a handle that is pricing a stay at a rental.
+func (qh *QuoteHandler) QuoteRental(method, path string) func(w http.ResponseWriter, r *http.Request) {
+	return func(w http.ResponseWriter, r *http.Request) {
+		rentalID, err := strconv.Atoi(chi.URLParam(r, "id"))
+		if err != nil {
+			errorResponse(w, fmt.Errorf("%w: id", svc.ErrInvalidQueryParameters))
+			return
+		}
+
+		var query contract.QuoteRentalQuery
+
+		if err := r.ParseForm(); err != nil {
+			errorResponse(w, fmt.Errorf("%w: parsing form: %w", svc.ErrInvalidQueryParameters, err))
+			return
+		}
+
+		if err := schema.NewDecoder().Decode(&query, r.Form); err != nil {
+			errorResponse(w, fmt.Errorf("%w: unmashalling query: %w", svc.ErrInvalidQueryParameters, err))
+			return
+		}
+
+		start, err := parseDateParameter("start", query.Start)
+		if err != nil {
+			errorResponse(w, err)
+			return
+		}
+
+		end, err := parseDateParameter("end", query.End)
+		if err != nil {
+			errorResponse(w, err)
+			return
+		}
+
+		quote, err := qh.rentalQuotingOp.QuoteRental(r.Context(), rentalID, start, end)
+		if err != nil {
+			errorResponse(w, err)
+			return
+		}
+
+		successResponse(w, toQuoteContract(quote))
+
+		return
+	}
+}
+
+func parseDateParameter(name string, value *string) (time.Time, error) {
+	if value == nil {
+		return time.Time{}, fmt.Errorf("%w: %s is required", svc.ErrInvalidQueryParameters, name)
+	}
+
+	date, err := time.Parse(time.DateOnly, *value)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("%w: %s: expected format YYYY-MM-DD", svc.ErrInvalidQueryParameters, name)
+	}
+
+	return date, nil
+}
+
+func toQuoteContract(quote *pricing.Quote) *contract.Quote {
+	resp := &contract.Quote{
+		RentalID:  quote.RentalID,
+		StartDate: quote.StartDate.Format(time.DateOnly),
+		EndDate:   quote.EndDate.Format(time.DateOnly),
+		Nights:    quote.Nights,
+		LineItems: make([]contract.LineItem, 0, len(quote.LineItems)),
+		Subtotal:  quote.Subtotal,
+		Discounts: quote.Discounts,
+		Fees:      quote.Fees,
+		Taxes:     quote.Taxes,
+		Total:     quote.Total,
+	}
+
+	for _, item := range quote.LineItems {
+		resp.LineItems = append(resp.LineItems, contract.LineItem{
+			Type:        string(item.Type),
+			Description: item.Description,
+			Amount:      item.Amount,
+		})
+	}
+
+	return resp
+}
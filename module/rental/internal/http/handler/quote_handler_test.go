@@ -0,0 +1,120 @@
+package handler_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi"
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/dragonator/rental-service/module/rental/internal/http/contract"
+	"github.com/dragonator/rental-service/module/rental/internal/http/handler"
+	"github.com/dragonator/rental-service/module/rental/internal/http/service/svc"
+	"github.com/dragonator/rental-service/module/rental/internal/pricing"
+)
+
+func TestQuoteHandler_QuoteRental(t *testing.T) {
+	quote := &pricing.Quote{
+		RentalID:  2,
+		StartDate: time.Date(2023, 7, 1, 0, 0, 0, 0, time.UTC),
+		EndDate:   time.Date(2023, 7, 5, 0, 0, 0, 0, time.UTC),
+		Nights:    4,
+		LineItems: []pricing.LineItem{
+			{Type: pricing.LineItemNights, Description: "4 nights x 169.00", Amount: 67600},
+			{Type: pricing.LineItemTax, Description: "Taxes (10%)", Amount: 6760},
+		},
+		Subtotal: 67600,
+		Taxes:    6760,
+		Total:    74360,
+	}
+
+	testCases := []struct {
+		name          string
+		query         string
+		quoteErr      error
+		expectedCalls int
+		expectedCode  int
+		expectedQuote contract.QuoteRentalResponse
+	}{
+		{
+			name:          "Valid dates",
+			query:         "?start=2023-07-01&end=2023-07-05",
+			expectedCalls: 1,
+			expectedCode:  http.StatusOK,
+			expectedQuote: contract.QuoteRentalResponse{Quote: contract.Quote{
+				RentalID:  2,
+				StartDate: "2023-07-01",
+				EndDate:   "2023-07-05",
+				Nights:    4,
+				LineItems: []contract.LineItem{
+					{Type: "nights", Description: "4 nights x 169.00", Amount: 67600},
+					{Type: "tax", Description: "Taxes (10%)", Amount: 6760},
+				},
+				Subtotal: 67600,
+				Taxes:    6760,
+				Total:    74360,
+			}},
+		},
+		{
+			name:          "Missing end date",
+			query:         "?start=2023-07-01",
+			expectedCalls: 0,
+			expectedCode:  http.StatusBadRequest,
+		},
+		{
+			name:          "Missing rental",
+			query:         "?start=2023-07-01&end=2023-07-05",
+			quoteErr:      svc.ErrNotFound,
+			expectedCalls: 1,
+			expectedCode:  http.StatusNotFound,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockRentalQuotingOp := &RentalQuotingOpMock{
+				QuoteRentalFunc: func(ctx context.Context, rentalID int, start, end time.Time) (*pricing.Quote, error) {
+					if tc.quoteErr != nil {
+						return nil, tc.quoteErr
+					}
+
+					return quote, nil
+				},
+			}
+
+			quoteHandler := handler.NewQuoteHandler(mockRentalQuotingOp)
+
+			router := chi.NewRouter()
+			router.Get("/rentals/{id}/quote", quoteHandler.QuoteRental("GET", "/rentals/{id}/quote"))
+
+			request := httptest.NewRequest("GET", "/rentals/2/quote"+tc.query, nil)
+			responseRecorder := httptest.NewRecorder()
+
+			router.ServeHTTP(responseRecorder, request)
+
+			if calls := mockRentalQuotingOp.QuoteRentalCalls(); tc.expectedCalls != len(calls) {
+				t.Fatalf("Unexpected number of calls to QuoteRental:\nexpected: %d\ngot      %d", tc.expectedCalls, len(calls))
+			}
+
+			if responseRecorder.Code != tc.expectedCode {
+				t.Fatalf("Unexpected status code:\nexpected %d\ngot:      %d", tc.expectedCode, responseRecorder.Code)
+			}
+
+			if tc.expectedCode == http.StatusOK {
+				var responseBody contract.QuoteRentalResponse
+
+				if err := json.NewDecoder(responseRecorder.Body).Decode(&responseBody); err != nil {
+					t.Fatalf("Failed to decode response body: %v", err)
+				}
+
+				if !cmp.Equal(responseBody, tc.expectedQuote) {
+					t.Fatalf("Unexpected quote:\nexpected: %v\ngot:      %v", tc.expectedQuote, responseBody)
+				}
+			}
+		})
+	}
+}
@@ -1,10 +1,15 @@
 package handler
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
 	"fmt"
+	"io"
 	"net/http"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/go-chi/chi"
 	"github.com/gorilla/schema"
@@ -21,17 +26,51 @@ import (
 type RentalFetchingOp interface {
 	GetRentalByID(ctx context.Context, rentalID int) (*model.Rental, error)
 	ListRentals(ctx context.Context, filters *storage.RentalFilters) (model.Rentals, error)
+	CountRentals(ctx context.Context, filters *storage.RentalFilters) (int, error)
+}
+
+// RentalCreatingOp is a contract to a rental creating operation.
+//
+//go:generate moq -rm -pkg handler_test -out rental_creating_op_mock_test.go . RentalCreatingOp
+type RentalCreatingOp interface {
+	CreateRental(ctx context.Context, rental *model.Rental) (*model.Rental, error)
+}
+
+// RentalUpdatingOp is a contract to a rental updating operation.
+//
+//go:generate moq -rm -pkg handler_test -out rental_updating_op_mock_test.go . RentalUpdatingOp
+type RentalUpdatingOp interface {
+	UpdateRental(ctx context.Context, rental *model.Rental, version *time.Time) (*model.Rental, error)
+}
+
+// RentalArchivingOp is a contract to a rental archiving operation.
+//
+//go:generate moq -rm -pkg handler_test -out rental_archiving_op_mock_test.go . RentalArchivingOp
+type RentalArchivingOp interface {
+	ArchiveRental(ctx context.Context, rentalID int) error
+	RestoreRental(ctx context.Context, rentalID int) (*model.Rental, error)
 }
 
 // RentalHandler holds implementation of handlers for rentals.
 type RentalHandler struct {
-	rentalFetchingOp RentalFetchingOp
+	rentalFetchingOp  RentalFetchingOp
+	rentalCreatingOp  RentalCreatingOp
+	rentalUpdatingOp  RentalUpdatingOp
+	rentalArchivingOp RentalArchivingOp
 }
 
 // NewRentalHandler is a construction function for RentalHandler.
-func NewRentalHandler(rentalFetchingOp RentalFetchingOp) *RentalHandler {
+func NewRentalHandler(
+	rentalFetchingOp RentalFetchingOp,
+	rentalCreatingOp RentalCreatingOp,
+	rentalUpdatingOp RentalUpdatingOp,
+	rentalArchivingOp RentalArchivingOp,
+) *RentalHandler {
 	return &RentalHandler{
-		rentalFetchingOp: rentalFetchingOp,
+		rentalFetchingOp:  rentalFetchingOp,
+		rentalCreatingOp:  rentalCreatingOp,
+		rentalUpdatingOp:  rentalUpdatingOp,
+		rentalArchivingOp: rentalArchivingOp,
 	}
 }
 
@@ -50,6 +89,7 @@ func (rh *RentalHandler) GetRentalByID(method, path string) func(w http.Response
 			return
 		}
 
+		w.Header().Set(_etagHeaderName, etag(rental.Updated))
 		successResponse(w, toRentalContract(rental))
 
 		return
@@ -71,12 +111,192 @@ func (rh *RentalHandler) ListRentals(method, path string) func(w http.ResponseWr
 			return
 		}
 
-		successResponse(w, toListRentalsResponse(rentals))
+		respondRentalsPage(w, r, rh.rentalFetchingOp, filters, rentals)
+
+		return
+	}
+}
+
+// CreateRental returns a handle that is creating a new rental.
+func (rh *RentalHandler) CreateRental(method, path string) func(w http.ResponseWriter, r *http.Request) {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req contract.CreateRentalRequest
+		if err := decodeJSONBody(r, &req); err != nil {
+			errorResponse(w, err)
+			return
+		}
+
+		rental, err := rh.rentalCreatingOp.CreateRental(r.Context(), toRentalModel(&req.Rental))
+		if err != nil {
+			errorResponse(w, err)
+			return
+		}
+
+		createdResponse(w, fmt.Sprintf("/rentals/%d", rental.ID), toRentalContract(rental))
+
+		return
+	}
+}
+
+// UpdateRental returns a handle that is replacing a rental. If the request carries an If-Match
+// header the rental is replaced only if it was not modified since the given entity tag.
+func (rh *RentalHandler) UpdateRental(method, path string) func(w http.ResponseWriter, r *http.Request) {
+	return func(w http.ResponseWriter, r *http.Request) {
+		rentalID, err := strconv.Atoi(chi.URLParam(r, "id"))
+		if err != nil {
+			errorResponse(w, fmt.Errorf("%w: id", svc.ErrInvalidQueryParameters))
+			return
+		}
+
+		version, err := versionFromIfMatch(r)
+		if err != nil {
+			errorResponse(w, err)
+			return
+		}
+
+		var req contract.UpdateRentalRequest
+		if err := decodeJSONBody(r, &req); err != nil {
+			errorResponse(w, err)
+			return
+		}
+
+		rental := toRentalModel(&req.Rental)
+		rental.ID = int32(rentalID)
+
+		updated, err := rh.rentalUpdatingOp.UpdateRental(r.Context(), rental, version)
+		if err != nil {
+			errorResponse(w, err)
+			return
+		}
+
+		w.Header().Set(_etagHeaderName, etag(updated.Updated))
+		successResponse(w, toRentalContract(updated))
+
+		return
+	}
+}
+
+// PatchRental returns a handle that is applying a JSON merge patch to a rental. The patch is
+// applied to the current representation of the rental, which is then stored only if the rental
+// was not modified in the meantime.
+func (rh *RentalHandler) PatchRental(method, path string) func(w http.ResponseWriter, r *http.Request) {
+	return func(w http.ResponseWriter, r *http.Request) {
+		rentalID, err := strconv.Atoi(chi.URLParam(r, "id"))
+		if err != nil {
+			errorResponse(w, fmt.Errorf("%w: id", svc.ErrInvalidQueryParameters))
+			return
+		}
+
+		version, err := versionFromIfMatch(r)
+		if err != nil {
+			errorResponse(w, err)
+			return
+		}
+
+		patch, err := io.ReadAll(r.Body)
+		if err != nil {
+			errorResponse(w, fmt.Errorf("%w: %w", svc.ErrInvalidRequestBody, err))
+			return
+		}
+
+		current, err := rh.rentalFetchingOp.GetRentalByID(r.Context(), rentalID)
+		if err != nil {
+			errorResponse(w, err)
+			return
+		}
+
+		if version != nil && !version.Equal(current.Updated) {
+			errorResponse(w, fmt.Errorf("%w: rental with id %d was modified", svc.ErrPreconditionFailed, rentalID))
+			return
+		}
+
+		rental, err := applyRentalPatch(current, patch)
+		if err != nil {
+			errorResponse(w, err)
+			return
+		}
+
+		updated, err := rh.rentalUpdatingOp.UpdateRental(r.Context(), rental, &current.Updated)
+		if err != nil {
+			errorResponse(w, err)
+			return
+		}
+
+		w.Header().Set(_etagHeaderName, etag(updated.Updated))
+		successResponse(w, toRentalContract(updated))
+
+		return
+	}
+}
+
+// DeleteRental returns a handle that is archiving a rental.
+func (rh *RentalHandler) DeleteRental(method, path string) func(w http.ResponseWriter, r *http.Request) {
+	return func(w http.ResponseWriter, r *http.Request) {
+		rentalID, err := strconv.Atoi(chi.URLParam(r, "id"))
+		if err != nil {
+			errorResponse(w, fmt.Errorf("%w: id", svc.ErrInvalidQueryParameters))
+			return
+		}
+
+		if err := rh.rentalArchivingOp.ArchiveRental(r.Context(), rentalID); err != nil {
+			errorResponse(w, err)
+			return
+		}
+
+		noContentResponse(w)
+
+		return
+	}
+}
+
+// RestoreRental returns a handle that is restoring an archived rental.
+func (rh *RentalHandler) RestoreRental(method, path string) func(w http.ResponseWriter, r *http.Request) {
+	return func(w http.ResponseWriter, r *http.Request) {
+		rentalID, err := strconv.Atoi(chi.URLParam(r, "id"))
+		if err != nil {
+			errorResponse(w, fmt.Errorf("%w: id", svc.ErrInvalidQueryParameters))
+			return
+		}
+
+		rental, err := rh.rentalArchivingOp.RestoreRental(r.Context(), rentalID)
+		if err != nil {
+			errorResponse(w, err)
+			return
+		}
+
+		w.Header().Set(_etagHeaderName, etag(rental.Updated))
+		successResponse(w, toRentalContract(rental))
 
 		return
 	}
 }
 
+func applyRentalPatch(rental *model.Rental, patch []byte) (*model.Rental, error) {
+	document, err := json.Marshal(toRentalContract(rental))
+	if err != nil {
+		return nil, fmt.Errorf("marshalling rental: %w", err)
+	}
+
+	patched, err := mergePatch(document, patch)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", svc.ErrInvalidRequestBody, err)
+	}
+
+	var patchedRental contract.Rental
+
+	decoder := json.NewDecoder(bytes.NewReader(patched))
+	decoder.DisallowUnknownFields()
+
+	if err := decoder.Decode(&patchedRental); err != nil {
+		return nil, fmt.Errorf("%w: %w", svc.ErrInvalidRequestBody, err)
+	}
+
+	result := toRentalModel(&patchedRental)
+	result.ID = rental.ID
+
+	return result, nil
+}
+
 func rentalFiltersFromRequest(r *http.Request) (*storage.RentalFilters, error) {
 	var query contract.ListRentalsQuery
 
@@ -88,24 +308,67 @@ func rentalFiltersFromRequest(r *http.Request) (*storage.RentalFilters, error) {
 		return nil, fmt.Errorf("%w: unmashalling query: %w", svc.ErrInvalidQueryParameters, err)
 	}
 
-	if query.Sort != nil && !storage.SortFieldAllowed(*query.Sort) {
-		return nil, fmt.Errorf("%w: unexpected sort field: expected one of %v",
-			svc.ErrInvalidQueryParameters,
-			storage.RentalSortFields,
-		)
+	var orderBy []storage.SortTerm
+
+	if query.Sort != nil {
+		var err error
+		if orderBy, err = parseSort(*query.Sort); err != nil {
+			return nil, err
+		}
 	}
 
 	filters := &storage.RentalFilters{
-		IDs:      query.Ids,
-		PriceMin: query.PriceMin,
-		PriceMax: query.PriceMax,
-		OrderBy:  query.Sort,
+		IDs:        query.Ids,
+		UserIDs:    query.UserIDs,
+		WishlistID: query.WishlistID,
+		Amenities:  splitCommaValues(query.Amenities),
+		Types:      splitCommaValues(query.Types),
+		Make:       query.Make,
+		Model:      query.Model,
+		YearMin:    query.YearMin,
+		YearMax:    query.YearMax,
+		LengthMin:  query.LengthMin,
+		LengthMax:  query.LengthMax,
+		SleepsMin:  query.SleepsMin,
+		PriceMin:   query.PriceMin,
+		PriceMax:   query.PriceMax,
+		RatingMin:  query.RatingMin,
+		OrderBy:    orderBy,
 		Pagination: storage.Pagination{
 			Limit:  query.Limit,
 			Offset: query.Offset,
 		},
 	}
 
+	if query.Q != nil {
+		if search := strings.TrimSpace(*query.Q); search != "" {
+			filters.Search = &search
+		}
+	}
+
+	if query.IncludeDeleted != nil && *query.IncludeDeleted {
+		if !svc.IsAdmin(r.Context()) {
+			return nil, fmt.Errorf("%w: include_deleted is available to administrators only", svc.ErrForbidden)
+		}
+
+		filters.IncludeDeleted = true
+	}
+
+	if query.AvailableFrom != nil || query.AvailableTo != nil {
+		if err := setAvailabilityFilter(filters, query.AvailableFrom, query.AvailableTo); err != nil {
+			return nil, err
+		}
+	}
+
+	if query.EffectivePrice != nil && *query.EffectivePrice {
+		if filters.AvailableFrom == nil {
+			return nil, fmt.Errorf("%w: effective_price requires available_from and available_to",
+				svc.ErrInvalidQueryParameters)
+		}
+
+		filters.EffectivePrice = true
+	}
+
 	if len(query.Near) > 0 {
 		if len(query.Near) != 2 {
 			return nil, fmt.Errorf("%w: invalid number of values for near (expected 2)", svc.ErrInvalidQueryParameters)
@@ -117,21 +380,140 @@ func rentalFiltersFromRequest(r *http.Request) (*storage.RentalFilters, error) {
 		}
 	}
 
+	if query.Radius != nil {
+		if filters.Near == nil {
+			return nil, fmt.Errorf("%w: radius requires near", svc.ErrInvalidQueryParameters)
+		}
+
+		if *query.Radius <= 0 {
+			return nil, fmt.Errorf("%w: radius must be positive", svc.ErrInvalidQueryParameters)
+		}
+
+		filters.Radius = query.Radius
+	}
+
+	if filters.Near == nil {
+		for _, term := range filters.OrderBy {
+			if term.Field == "distance" {
+				return nil, fmt.Errorf("%w: sorting by distance requires near", svc.ErrInvalidQueryParameters)
+			}
+		}
+	}
+
+	if query.Cursor != nil {
+		if err := setCursor(filters, *query.Cursor); err != nil {
+			return nil, err
+		}
+	}
+
 	return filters, nil
 }
 
+func setCursor(filters *storage.RentalFilters, cursor string) error {
+	if filters.Offset != nil {
+		return fmt.Errorf("%w: cursor and offset cannot be used together", svc.ErrInvalidQueryParameters)
+	}
+
+	if filters.Search != nil && len(filters.OrderBy) == 0 {
+		return fmt.Errorf("%w: rentals ranked by relevance cannot be paged by cursor, use sort", svc.ErrInvalidQueryParameters)
+	}
+
+	after, err := decodeRentalCursor(cursor, filters.OrderBy)
+	if err != nil {
+		return err
+	}
+
+	filters.After = after
+
+	return nil
+}
+
+func setAvailabilityFilter(filters *storage.RentalFilters, from, to *string) error {
+	if from == nil || to == nil {
+		return fmt.Errorf("%w: available_from and available_to must be given together", svc.ErrInvalidQueryParameters)
+	}
+
+	availableFrom, err := time.Parse(time.DateOnly, *from)
+	if err != nil {
+		return fmt.Errorf("%w: available_from: expected format YYYY-MM-DD", svc.ErrInvalidQueryParameters)
+	}
+
+	availableTo, err := time.Parse(time.DateOnly, *to)
+	if err != nil {
+		return fmt.Errorf("%w: available_to: expected format YYYY-MM-DD", svc.ErrInvalidQueryParameters)
+	}
+
+	if !availableTo.After(availableFrom) {
+		return fmt.Errorf("%w: available_to must be after available_from", svc.ErrInvalidQueryParameters)
+	}
+
+	filters.AvailableFrom = &availableFrom
+	filters.AvailableTo = &availableTo
+
+	return nil
+}
+
+// parseSort parses a comma separated list of sort fields, where a leading - sorts by the field
+// in descending order, e.g. -price_per_day,year.
+func parseSort(sort string) ([]storage.SortTerm, error) {
+	fields := strings.Split(sort, ",")
+	terms := make([]storage.SortTerm, 0, len(fields))
+
+	for _, field := range fields {
+		term := storage.SortTerm{Field: strings.TrimSpace(field)}
+		if strings.HasPrefix(term.Field, "-") {
+			term.Field = term.Field[1:]
+			term.Descending = true
+		}
+
+		if !storage.SortFieldAllowed(term.Field) {
+			return nil, fmt.Errorf("%w: unexpected sort field %q: expected one of %v",
+				svc.ErrInvalidQueryParameters,
+				term.Field,
+				storage.RentalSortFields,
+			)
+		}
+
+		terms = append(terms, term)
+	}
+
+	return terms, nil
+}
+
+// splitCommaValues splits comma separated query values, so that both type=a,b
+// and type=a&type=b are accepted. Empty values are dropped.
+func splitCommaValues(values []string) []string {
+	var result []string
+
+	for _, value := range values {
+		for _, item := range strings.Split(value, ",") {
+			if item = strings.TrimSpace(item); item != "" {
+				result = append(result, item)
+			}
+		}
+	}
+
+	return result
+}
+
 func toRentalContract(rental *model.Rental) *contract.Rental {
 	return &contract.Rental{
-		ID:              rental.ID,
-		Name:            rental.Name,
-		Description:     rental.Description,
-		Type:            rental.Type,
-		Make:            rental.VehicleMake,
-		Model:           rental.VehicleModel,
-		Year:            rental.VehicleYear,
-		Length:          rental.VehicleLength,
-		Sleeps:          rental.Sleeps,
-		PrimaryImageURL: rental.PrimaryImageURL,
+		ID:                 rental.ID,
+		Name:               rental.Name,
+		Description:        rental.Description,
+		Type:               rental.Type,
+		Make:               rental.VehicleMake,
+		Model:              rental.VehicleModel,
+		Year:               rental.VehicleYear,
+		Length:             rental.VehicleLength,
+		Sleeps:             rental.Sleeps,
+		PrimaryImageURL:    rental.PrimaryImage(),
+		CancellationPolicy: string(rental.CancellationPolicy),
+		RatingAverage:      rental.RatingAverage,
+		RatingCount:        rental.RatingCount,
+		Images:             toImagesContract(rental.Images),
+		Amenities:          toAmenitiesContract(rental.Amenities),
+		Distance:           rental.Distance,
 		Price: contract.Price{
 			Day: rental.PricePerDay,
 		},
@@ -143,11 +525,38 @@ func toRentalContract(rental *model.Rental) *contract.Rental {
 			Latitude:  rental.Latitude,
 			Longitude: rental.Longitude,
 		},
-		User: contract.User{
-			ID:        rental.User.ID,
-			FirstName: rental.User.FirstName,
-			LastName:  rental.User.LastName,
-		},
+		User: *toUserContract(rental.User),
+	}
+}
+
+// toRentalModel converts a rental from a client request. Rentals which do not declare
+// a cancellation policy get the default one.
+func toRentalModel(rental *contract.Rental) *model.Rental {
+	cancellationPolicy := model.CancellationPolicy(rental.CancellationPolicy)
+	if cancellationPolicy == "" {
+		cancellationPolicy = model.DefaultCancellationPolicy
+	}
+
+	return &model.Rental{
+		ID:                 rental.ID,
+		UserID:             rental.User.ID,
+		Name:               rental.Name,
+		Type:               rental.Type,
+		Description:        rental.Description,
+		Sleeps:             rental.Sleeps,
+		PricePerDay:        rental.Price.Day,
+		HomeCity:           rental.Location.City,
+		HomeState:          rental.Location.State,
+		HomeZip:            rental.Location.Zip,
+		HomeCountry:        rental.Location.Country,
+		VehicleMake:        rental.Make,
+		VehicleModel:       rental.Model,
+		VehicleYear:        rental.Year,
+		VehicleLength:      rental.Length,
+		Latitude:           rental.Location.Latitude,
+		Longitude:          rental.Location.Longitude,
+		PrimaryImageURL:    rental.PrimaryImageURL,
+		CancellationPolicy: cancellationPolicy,
 	}
 }
 
@@ -3,9 +3,13 @@ package handler_test
 import (
 	"context"
 	"encoding/json"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"strconv"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/go-chi/chi"
 	"github.com/google/go-cmp/cmp"
@@ -20,24 +24,26 @@ import (
 var (
 	_rentals = model.Rentals{
 		{
-			ID:              1,
-			UserID:          2,
-			Name:            "Rental 1",
-			Type:            "Type 1",
-			Description:     "Description 1",
-			Sleeps:          4,
-			PricePerDay:     1000,
-			HomeCity:        "City 1",
-			HomeState:       "State 1",
-			HomeZip:         "Zip 1",
-			HomeCountry:     "Country 1",
-			VehicleMake:     "Make 1",
-			VehicleModel:    "Model 1",
-			VehicleYear:     2022,
-			VehicleLength:   10.5,
-			Latitude:        40.1234,
-			Longitude:       -75.5678,
-			PrimaryImageURL: "ImageURL 1",
+			ID:                 1,
+			UserID:             2,
+			Name:               "Rental 1",
+			Type:               "Type 1",
+			Description:        "Description 1",
+			Sleeps:             4,
+			PricePerDay:        1000,
+			HomeCity:           "City 1",
+			HomeState:          "State 1",
+			HomeZip:            "Zip 1",
+			HomeCountry:        "Country 1",
+			VehicleMake:        "Make 1",
+			VehicleModel:       "Model 1",
+			VehicleYear:        2022,
+			VehicleLength:      10.5,
+			Latitude:           40.1234,
+			Longitude:          -75.5678,
+			PrimaryImageURL:    "ImageURL 1",
+			CancellationPolicy: model.CancellationPolicyModerate,
+			Updated:            time.Date(2023, 7, 2, 10, 0, 0, 0, time.UTC),
 			User: &model.User{
 				ID:        2,
 				FirstName: "FirstName 1",
@@ -125,7 +131,7 @@ func TestRentalHandler_GetRentalByID(t *testing.T) {
 
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
-			rentalHandler := handler.NewRentalHandler(tc.mockRentalFetchingOp)
+			rentalHandler := handler.NewRentalHandler(tc.mockRentalFetchingOp, &RentalCreatingOpMock{}, &RentalUpdatingOpMock{}, &RentalArchivingOpMock{})
 
 			router := chi.NewRouter()
 			router.Get("/rentals/{id}", rentalHandler.GetRentalByID("GET", "/rentals/{id}"))
@@ -181,6 +187,7 @@ func TestRentalHandler_ListRentals(t *testing.T) {
 	testCases := []struct {
 		name                 string
 		query                string
+		admin                bool
 		mockRentalFetchingOp *RentalFetchingOpMock
 		expectedFilters      *storage.RentalFilters
 		expectedCalls        int
@@ -206,6 +213,190 @@ func TestRentalHandler_ListRentals(t *testing.T) {
 				toRentalContract(_rentals[1]),
 			},
 		},
+		{
+			name:  "Valid user IDs",
+			query: "?user_id=2,3",
+			mockRentalFetchingOp: &RentalFetchingOpMock{
+				ListRentalsFunc: func(ctx context.Context, filters *storage.RentalFilters) (model.Rentals, error) {
+					return _rentals, nil
+				},
+			},
+			expectedFilters: &storage.RentalFilters{
+				UserIDs: []int32{2, 3},
+			},
+			expectedCalls: 1,
+			expectedCode:  http.StatusOK,
+			expectedRental: contract.ListRentalsResponse{
+				toRentalContract(_rentals[0]),
+				toRentalContract(_rentals[1]),
+			},
+		},
+		{
+			name:  "Wishlist",
+			query: "?wishlist_id=4&sort=price_per_day&limit=10",
+			mockRentalFetchingOp: &RentalFetchingOpMock{
+				ListRentalsFunc: func(ctx context.Context, filters *storage.RentalFilters) (model.Rentals, error) {
+					return _rentals, nil
+				},
+			},
+			expectedFilters: &storage.RentalFilters{
+				WishlistID: toPtr(int32(4)),
+				OrderBy:    []storage.SortTerm{{Field: "price_per_day"}},
+				Pagination: storage.Pagination{
+					Limit: toPtr(10),
+				},
+			},
+			expectedCalls: 1,
+			expectedCode:  http.StatusOK,
+			expectedRental: contract.ListRentalsResponse{
+				toRentalContract(_rentals[0]),
+				toRentalContract(_rentals[1]),
+			},
+		},
+		{
+			name:  "Amenities",
+			query: "?amenities=shower,solar",
+			mockRentalFetchingOp: &RentalFetchingOpMock{
+				ListRentalsFunc: func(ctx context.Context, filters *storage.RentalFilters) (model.Rentals, error) {
+					return _rentals[:1], nil
+				},
+			},
+			expectedFilters: &storage.RentalFilters{
+				Amenities: []string{"shower", "solar"},
+			},
+			expectedCalls: 1,
+			expectedCode:  http.StatusOK,
+			expectedRental: contract.ListRentalsResponse{
+				toRentalContract(_rentals[0]),
+			},
+		},
+		{
+			name:  "Attributes",
+			query: "?type=camper-van,trailer&make=Ford&model=Transit&year_min=2015&year_max=2022&length_min=7.5&length_max=12&sleeps_min=4",
+			mockRentalFetchingOp: &RentalFetchingOpMock{
+				ListRentalsFunc: func(ctx context.Context, filters *storage.RentalFilters) (model.Rentals, error) {
+					return _rentals[:1], nil
+				},
+			},
+			expectedFilters: &storage.RentalFilters{
+				Types:     []string{"camper-van", "trailer"},
+				Make:      toPtr("Ford"),
+				Model:     toPtr("Transit"),
+				YearMin:   toPtr(int32(2015)),
+				YearMax:   toPtr(int32(2022)),
+				LengthMin: toPtr(float32(7.5)),
+				LengthMax: toPtr(float32(12)),
+				SleepsMin: toPtr(int32(4)),
+			},
+			expectedCalls: 1,
+			expectedCode:  http.StatusOK,
+			expectedRental: contract.ListRentalsResponse{
+				toRentalContract(_rentals[0]),
+			},
+		},
+		{
+			name:                 "Invalid year",
+			query:                "?year_min=new",
+			mockRentalFetchingOp: &RentalFetchingOpMock{},
+			expectedCalls:        0,
+			expectedCode:         http.StatusBadRequest,
+			expectedError: contract.ErrorResponse{
+				Message: "invalid query parameters: unmashalling query: schema: error converting value for \"year_min\"",
+			},
+		},
+		{
+			name:  "Near within radius by distance",
+			query: "?near=33.64,-117.93&radius=25.5&sort=distance",
+			mockRentalFetchingOp: &RentalFetchingOpMock{
+				ListRentalsFunc: func(ctx context.Context, filters *storage.RentalFilters) (model.Rentals, error) {
+					rental := *_rentals[0]
+					rental.Distance = toPtr(12.25)
+
+					return model.Rentals{&rental}, nil
+				},
+			},
+			expectedFilters: &storage.RentalFilters{
+				Near: &storage.Location{
+					Latitude:  33.64,
+					Longitude: -117.93,
+				},
+				Radius:  toPtr(25.5),
+				OrderBy: []storage.SortTerm{{Field: "distance"}},
+			},
+			expectedCalls: 1,
+			expectedCode:  http.StatusOK,
+			expectedRental: contract.ListRentalsResponse{
+				func() *contract.Rental {
+					rental := toRentalContract(_rentals[0])
+					rental.Distance = toPtr(12.25)
+
+					return rental
+				}(),
+			},
+		},
+		{
+			name:                 "Radius without near",
+			query:                "?radius=25",
+			mockRentalFetchingOp: &RentalFetchingOpMock{},
+			expectedCalls:        0,
+			expectedCode:         http.StatusBadRequest,
+			expectedError: contract.ErrorResponse{
+				Message: "invalid query parameters: radius requires near",
+			},
+		},
+		{
+			name:                 "Non-positive radius",
+			query:                "?near=33.64,-117.93&radius=0",
+			mockRentalFetchingOp: &RentalFetchingOpMock{},
+			expectedCalls:        0,
+			expectedCode:         http.StatusBadRequest,
+			expectedError: contract.ErrorResponse{
+				Message: "invalid query parameters: radius must be positive",
+			},
+		},
+		{
+			name:                 "Sort by distance without near",
+			query:                "?sort=-distance",
+			mockRentalFetchingOp: &RentalFetchingOpMock{},
+			expectedCalls:        0,
+			expectedCode:         http.StatusBadRequest,
+			expectedError: contract.ErrorResponse{
+				Message: "invalid query parameters: sorting by distance requires near",
+			},
+		},
+		{
+			name:  "Search",
+			query: "?q=+westfalia+",
+			mockRentalFetchingOp: &RentalFetchingOpMock{
+				ListRentalsFunc: func(ctx context.Context, filters *storage.RentalFilters) (model.Rentals, error) {
+					return _rentals[:1], nil
+				},
+			},
+			expectedFilters: &storage.RentalFilters{
+				Search: toPtr("westfalia"),
+			},
+			expectedCalls: 1,
+			expectedCode:  http.StatusOK,
+			expectedRental: contract.ListRentalsResponse{
+				toRentalContract(_rentals[0]),
+			},
+		},
+		{
+			name:  "Blank search",
+			query: "?q=+",
+			mockRentalFetchingOp: &RentalFetchingOpMock{
+				ListRentalsFunc: func(ctx context.Context, filters *storage.RentalFilters) (model.Rentals, error) {
+					return _rentals, nil
+				},
+			},
+			expectedFilters: &storage.RentalFilters{},
+			expectedCalls:   1,
+			expectedCode:    http.StatusOK,
+			expectedRental: contract.ListRentalsResponse{
+				toRentalContract(_rentals[0]),
+				toRentalContract(_rentals[1]),
+			},
+		},
 		{
 			name:                 "Invalid IDs",
 			query:                "?ids=a,b",
@@ -216,6 +407,76 @@ func TestRentalHandler_ListRentals(t *testing.T) {
 				Message: "invalid query parameters: unmashalling query: schema: error converting value for index 0 of \"ids\"",
 			},
 		},
+		{
+			name:  "Availability",
+			query: "?available_from=2023-07-01&available_to=2023-07-05",
+			mockRentalFetchingOp: &RentalFetchingOpMock{
+				ListRentalsFunc: func(ctx context.Context, filters *storage.RentalFilters) (model.Rentals, error) {
+					return _rentals, nil
+				},
+			},
+			expectedFilters: &storage.RentalFilters{
+				AvailableFrom: toPtr(time.Date(2023, 7, 1, 0, 0, 0, 0, time.UTC)),
+				AvailableTo:   toPtr(time.Date(2023, 7, 5, 0, 0, 0, 0, time.UTC)),
+			},
+			expectedCalls: 1,
+			expectedCode:  http.StatusOK,
+			expectedRental: contract.ListRentalsResponse{
+				toRentalContract(_rentals[0]),
+				toRentalContract(_rentals[1]),
+			},
+		},
+		{
+			name:                 "Availability without end date",
+			query:                "?available_from=2023-07-01",
+			mockRentalFetchingOp: &RentalFetchingOpMock{},
+			expectedCalls:        0,
+			expectedCode:         http.StatusBadRequest,
+			expectedError: contract.ErrorResponse{
+				Message: "invalid query parameters: available_from and available_to must be given together",
+			},
+		},
+		{
+			name:                 "Availability with reversed dates",
+			query:                "?available_from=2023-07-05&available_to=2023-07-01",
+			mockRentalFetchingOp: &RentalFetchingOpMock{},
+			expectedCalls:        0,
+			expectedCode:         http.StatusBadRequest,
+			expectedError: contract.ErrorResponse{
+				Message: "invalid query parameters: available_to must be after available_from",
+			},
+		},
+		{
+			name:  "Effective price",
+			query: "?available_from=2023-07-01&available_to=2023-07-05&effective_price=true&price_max=20000",
+			mockRentalFetchingOp: &RentalFetchingOpMock{
+				ListRentalsFunc: func(ctx context.Context, filters *storage.RentalFilters) (model.Rentals, error) {
+					return _rentals, nil
+				},
+			},
+			expectedFilters: &storage.RentalFilters{
+				PriceMax:       toPtr[int64](20000),
+				AvailableFrom:  toPtr(time.Date(2023, 7, 1, 0, 0, 0, 0, time.UTC)),
+				AvailableTo:    toPtr(time.Date(2023, 7, 5, 0, 0, 0, 0, time.UTC)),
+				EffectivePrice: true,
+			},
+			expectedCalls: 1,
+			expectedCode:  http.StatusOK,
+			expectedRental: contract.ListRentalsResponse{
+				toRentalContract(_rentals[0]),
+				toRentalContract(_rentals[1]),
+			},
+		},
+		{
+			name:                 "Effective price without dates",
+			query:                "?effective_price=true&price_max=20000",
+			mockRentalFetchingOp: &RentalFetchingOpMock{},
+			expectedCalls:        0,
+			expectedCode:         http.StatusBadRequest,
+			expectedError: contract.ErrorResponse{
+				Message: "invalid query parameters: effective_price requires available_from and available_to",
+			},
+		},
 		{
 			name:  "PriceMin",
 			query: "?price_min=100",
@@ -234,6 +495,25 @@ func TestRentalHandler_ListRentals(t *testing.T) {
 				toRentalContract(_rentals[1]),
 			},
 		},
+		{
+			name:  "RatingMin sorted by rating",
+			query: "?rating_min=4.5&sort=rating",
+			mockRentalFetchingOp: &RentalFetchingOpMock{
+				ListRentalsFunc: func(ctx context.Context, filters *storage.RentalFilters) (model.Rentals, error) {
+					return _rentals, nil
+				},
+			},
+			expectedFilters: &storage.RentalFilters{
+				RatingMin: toPtr(4.5),
+				OrderBy:   []storage.SortTerm{{Field: "rating"}},
+			},
+			expectedCalls: 1,
+			expectedCode:  http.StatusOK,
+			expectedRental: contract.ListRentalsResponse{
+				toRentalContract(_rentals[0]),
+				toRentalContract(_rentals[1]),
+			},
+		},
 		{
 			name:                 "Invalid PriceMin",
 			query:                "?price_min=invalid",
@@ -382,7 +662,7 @@ func TestRentalHandler_ListRentals(t *testing.T) {
 				},
 			},
 			expectedFilters: &storage.RentalFilters{
-				OrderBy: toPtr("price_per_day"),
+				OrderBy: []storage.SortTerm{{Field: "price_per_day"}},
 			},
 			expectedCalls: 1,
 			expectedCode:  http.StatusOK,
@@ -398,7 +678,39 @@ func TestRentalHandler_ListRentals(t *testing.T) {
 			expectedCalls:        0,
 			expectedCode:         http.StatusBadRequest,
 			expectedError: contract.ErrorResponse{
-				Message: "invalid query parameters: unexpected sort field: expected one of [id name type make model year length sleeps price_per_day]",
+				Message: "invalid query parameters: unexpected sort field \"invalid\": expected one of [id name type make model year length sleeps price_per_day rating distance]",
+			},
+		},
+		{
+			name:                 "Invalid descending sort",
+			query:                "?sort=-price_per_day,-invalid",
+			mockRentalFetchingOp: &RentalFetchingOpMock{},
+			expectedCalls:        0,
+			expectedCode:         http.StatusBadRequest,
+			expectedError: contract.ErrorResponse{
+				Message: "invalid query parameters: unexpected sort field \"invalid\": expected one of [id name type make model year length sleeps price_per_day rating distance]",
+			},
+		},
+		{
+			name:  "Multiple sort fields",
+			query: "?sort=-price_per_day,year,id",
+			mockRentalFetchingOp: &RentalFetchingOpMock{
+				ListRentalsFunc: func(ctx context.Context, filters *storage.RentalFilters) (model.Rentals, error) {
+					return _rentals, nil
+				},
+			},
+			expectedFilters: &storage.RentalFilters{
+				OrderBy: []storage.SortTerm{
+					{Field: "price_per_day", Descending: true},
+					{Field: "year"},
+					{Field: "id"},
+				},
+			},
+			expectedCalls: 1,
+			expectedCode:  http.StatusOK,
+			expectedRental: contract.ListRentalsResponse{
+				toRentalContract(_rentals[0]),
+				toRentalContract(_rentals[1]),
 			},
 		},
 		{
@@ -421,7 +733,26 @@ func TestRentalHandler_ListRentals(t *testing.T) {
 					Limit:  toPtr(3),
 					Offset: toPtr(8),
 				},
-				OrderBy: toPtr("price_per_day"),
+				OrderBy: []storage.SortTerm{{Field: "price_per_day"}},
+			},
+			expectedCalls: 1,
+			expectedCode:  http.StatusOK,
+			expectedRental: contract.ListRentalsResponse{
+				toRentalContract(_rentals[0]),
+				toRentalContract(_rentals[1]),
+			},
+		},
+		{
+			name:  "IncludeDeleted as admin",
+			query: "?include_deleted=true",
+			admin: true,
+			mockRentalFetchingOp: &RentalFetchingOpMock{
+				ListRentalsFunc: func(ctx context.Context, filters *storage.RentalFilters) (model.Rentals, error) {
+					return _rentals, nil
+				},
+			},
+			expectedFilters: &storage.RentalFilters{
+				IncludeDeleted: true,
 			},
 			expectedCalls: 1,
 			expectedCode:  http.StatusOK,
@@ -430,6 +761,16 @@ func TestRentalHandler_ListRentals(t *testing.T) {
 				toRentalContract(_rentals[1]),
 			},
 		},
+		{
+			name:                 "IncludeDeleted without admin",
+			query:                "?include_deleted=true",
+			mockRentalFetchingOp: &RentalFetchingOpMock{},
+			expectedCalls:        0,
+			expectedCode:         http.StatusForbidden,
+			expectedError: contract.ErrorResponse{
+				Message: "forbidden: include_deleted is available to administrators only",
+			},
+		},
 		{
 			name:  "No results",
 			query: "",
@@ -447,12 +788,15 @@ func TestRentalHandler_ListRentals(t *testing.T) {
 
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
-			rentalHandler := handler.NewRentalHandler(tc.mockRentalFetchingOp)
+			rentalHandler := handler.NewRentalHandler(tc.mockRentalFetchingOp, &RentalCreatingOpMock{}, &RentalUpdatingOpMock{}, &RentalArchivingOpMock{})
 
 			router := chi.NewRouter()
 			router.Get("/rentals", rentalHandler.ListRentals("GET", "/rentals"))
 
 			request := httptest.NewRequest("GET", "/rentals"+tc.query, nil)
+			if tc.admin {
+				request = request.WithContext(svc.WithAdmin(request.Context()))
+			}
 			responseRecorder := httptest.NewRecorder()
 
 			router.ServeHTTP(responseRecorder, request)
@@ -499,36 +843,769 @@ func TestRentalHandler_ListRentals(t *testing.T) {
 	}
 }
 
-func toRentalContract(rental *model.Rental) *contract.Rental {
-	return &contract.Rental{
-		ID:              rental.ID,
-		Name:            rental.Name,
-		Description:     rental.Description,
-		Type:            rental.Type,
-		Make:            rental.VehicleMake,
-		Model:           rental.VehicleModel,
-		Year:            rental.VehicleYear,
-		Length:          rental.VehicleLength,
-		Sleeps:          rental.Sleeps,
-		PrimaryImageURL: rental.PrimaryImageURL,
-		Price: contract.Price{
-			Day: rental.PricePerDay,
-		},
-		Location: contract.Location{
-			City:      rental.HomeCity,
-			State:     rental.HomeState,
-			Zip:       rental.HomeZip,
-			Country:   rental.HomeCountry,
-			Latitude:  rental.Latitude,
-			Longitude: rental.Longitude,
+func TestRentalHandler_ListRentalsCursor(t *testing.T) {
+	mockRentalFetchingOp := &RentalFetchingOpMock{
+		ListRentalsFunc: func(ctx context.Context, filters *storage.RentalFilters) (model.Rentals, error) {
+			if filters.After == nil {
+				return _rentals[:1], nil
+			}
+
+			return _rentals[1:], nil
 		},
-		User: contract.User{
-			ID:        rental.User.ID,
+	}
+
+	rentalHandler := handler.NewRentalHandler(mockRentalFetchingOp, &RentalCreatingOpMock{}, &RentalUpdatingOpMock{}, &RentalArchivingOpMock{})
+
+	router := chi.NewRouter()
+	router.Get("/rentals", rentalHandler.ListRentals("GET", "/rentals"))
+
+	list := func(query string) *httptest.ResponseRecorder {
+		responseRecorder := httptest.NewRecorder()
+		router.ServeHTTP(responseRecorder, httptest.NewRequest("GET", "/rentals"+query, nil))
+
+		return responseRecorder
+	}
+
+	first := list("?limit=1&sort=-rating,length")
+	if first.Code != http.StatusOK {
+		t.Fatalf("Unexpected status code:\nexpected %d\ngot:      %d", http.StatusOK, first.Code)
+	}
+
+	cursor := first.Header().Get("X-Next-Cursor")
+	if cursor == "" {
+		t.Fatalf("Expected a next cursor for a full page")
+	}
+
+	second := list("?limit=2&sort=-rating,length&cursor=" + cursor)
+	if second.Code != http.StatusOK {
+		t.Fatalf("Unexpected status code:\nexpected %d\ngot:      %d", http.StatusOK, second.Code)
+	}
+
+	if next := second.Header().Get("X-Next-Cursor"); next != "" {
+		t.Fatalf("Unexpected next cursor for the last page: %s", next)
+	}
+
+	calls := mockRentalFetchingOp.ListRentalsCalls()
+	expectedKey := &storage.RentalKey{Values: []any{nil, "10.50"}, ID: 1}
+	if len(calls) != 2 || !cmp.Equal(calls[1].Filters.After, expectedKey) {
+		t.Fatalf("Unexpected key:\nexpected: %v\ngot:      %v", expectedKey, calls[len(calls)-1].Filters.After)
+	}
+
+	for _, query := range []string{
+		"?limit=2&sort=length&cursor=" + cursor,
+		"?limit=2&sort=-rating,length&offset=2&cursor=" + cursor,
+		"?limit=2&sort=-rating,length&cursor=invalid",
+		"?limit=2&q=sprinter&cursor=" + cursor,
+	} {
+		if responseRecorder := list(query); responseRecorder.Code != http.StatusBadRequest {
+			t.Fatalf("Unexpected status code for %s:\nexpected %d\ngot:      %d", query, http.StatusBadRequest, responseRecorder.Code)
+		}
+	}
+}
+
+func TestRentalHandler_ListRentalsEnvelope(t *testing.T) {
+	testCases := []struct {
+		name          string
+		query         string
+		total         int
+		expectedMeta  contract.PageMeta
+		nextCursor    bool
+		expectedLinks string
+	}{
+		{
+			name:         "Unlimited",
+			query:        "?envelope=true",
+			total:        2,
+			expectedMeta: contract.PageMeta{Total: 2},
+		},
+		{
+			name:         "First page",
+			query:        "?envelope=true&limit=2&sort=-year",
+			total:        5,
+			expectedMeta: contract.PageMeta{Total: 5, Limit: toPtr(2)},
+			nextCursor:   true,
+			expectedLinks: `</rentals?envelope=true&limit=2&sort=-year>; rel="first", ` +
+				`</rentals?envelope=true&limit=2&offset=2&sort=-year>; rel="next", ` +
+				`</rentals?envelope=true&limit=2&offset=4&sort=-year>; rel="last"`,
+		},
+		{
+			name:         "Middle page",
+			query:        "?envelope=true&limit=2&offset=3",
+			total:        6,
+			expectedMeta: contract.PageMeta{Total: 6, Limit: toPtr(2), Offset: 3},
+			expectedLinks: `</rentals?envelope=true&limit=2>; rel="first", ` +
+				`</rentals?envelope=true&limit=2&offset=1>; rel="prev", ` +
+				`</rentals?envelope=true&limit=2&offset=5>; rel="next", ` +
+				`</rentals?envelope=true&limit=2&offset=4>; rel="last"`,
+		},
+		{
+			name:         "Last page",
+			query:        "?envelope=true&limit=2&offset=2",
+			total:        4,
+			expectedMeta: contract.PageMeta{Total: 4, Limit: toPtr(2), Offset: 2},
+			expectedLinks: `</rentals?envelope=true&limit=2>; rel="first", ` +
+				`</rentals?envelope=true&limit=2&offset=0>; rel="prev", ` +
+				`</rentals?envelope=true&limit=2&offset=2>; rel="last"`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockRentalFetchingOp := &RentalFetchingOpMock{
+				ListRentalsFunc: func(ctx context.Context, filters *storage.RentalFilters) (model.Rentals, error) {
+					return _rentals, nil
+				},
+				CountRentalsFunc: func(ctx context.Context, filters *storage.RentalFilters) (int, error) {
+					return tc.total, nil
+				},
+			}
+
+			rentalHandler := handler.NewRentalHandler(mockRentalFetchingOp, &RentalCreatingOpMock{}, &RentalUpdatingOpMock{}, &RentalArchivingOpMock{})
+
+			router := chi.NewRouter()
+			router.Get("/rentals", rentalHandler.ListRentals("GET", "/rentals"))
+
+			responseRecorder := httptest.NewRecorder()
+			router.ServeHTTP(responseRecorder, httptest.NewRequest("GET", "/rentals"+tc.query, nil))
+
+			if responseRecorder.Code != http.StatusOK {
+				t.Fatalf("Unexpected status code:\nexpected %d\ngot:      %d", http.StatusOK, responseRecorder.Code)
+			}
+
+			if links := responseRecorder.Header().Get("Link"); links != tc.expectedLinks {
+				t.Fatalf("Unexpected links:\nexpected: %s\ngot:      %s", tc.expectedLinks, links)
+			}
+
+			var responseBody contract.ListRentalsEnvelope
+
+			if err := json.NewDecoder(responseRecorder.Body).Decode(&responseBody); err != nil {
+				t.Fatalf("Failed to decode response body: %v", err)
+			}
+
+			nextCursor := responseRecorder.Header().Get("X-Next-Cursor")
+			if tc.nextCursor != (nextCursor != "") {
+				t.Fatalf("Unexpected next cursor: %q", nextCursor)
+			}
+
+			if tc.nextCursor {
+				tc.expectedMeta.NextCursor = &nextCursor
+			}
+
+			if !cmp.Equal(responseBody.Meta, tc.expectedMeta) {
+				t.Fatalf("Unexpected meta:\nexpected: %v\ngot:      %v", tc.expectedMeta, responseBody.Meta)
+			}
+
+			expectedData := contract.ListRentalsResponse{toRentalContract(_rentals[0]), toRentalContract(_rentals[1])}
+			if !cmp.Equal(responseBody.Data, expectedData) {
+				t.Fatalf("Unexpected rentals:\nexpected: %v\ngot:      %v", expectedData, responseBody.Data)
+			}
+		})
+	}
+}
+
+func TestRentalHandler_CreateRental(t *testing.T) {
+	validBody := `{"name":"Rental 1","type":"Type 1","Price":{"day":1000},"Location":{"lat":40.1234,"lng":-75.5678},"User":{"id":2}}`
+
+	testCases := []struct {
+		name                 string
+		body                 string
+		mockRentalCreatingOp *RentalCreatingOpMock
+		expectedRental       *model.Rental
+		expectedCalls        int
+		expectedCode         int
+		expectedLocation     string
+		expectedResponse     contract.CreateRentalResponse
+		expectedError        contract.ErrorResponse
+	}{
+		{
+			name: "Valid rental",
+			body: validBody,
+			mockRentalCreatingOp: &RentalCreatingOpMock{
+				CreateRentalFunc: func(ctx context.Context, rental *model.Rental) (*model.Rental, error) {
+					return _rentals[0], nil
+				},
+			},
+			expectedRental: &model.Rental{
+				UserID:             2,
+				Name:               "Rental 1",
+				Type:               "Type 1",
+				PricePerDay:        1000,
+				Latitude:           40.1234,
+				Longitude:          -75.5678,
+				CancellationPolicy: model.CancellationPolicyModerate,
+			},
+			expectedCalls:    1,
+			expectedCode:     http.StatusCreated,
+			expectedLocation: "/rentals/1",
+			expectedResponse: contract.CreateRentalResponse{Rental: *toRentalContract(_rentals[0])},
+		},
+		{
+			name:                 "Malformed body",
+			body:                 `{"name":`,
+			mockRentalCreatingOp: &RentalCreatingOpMock{},
+			expectedCalls:        0,
+			expectedCode:         http.StatusBadRequest,
+			expectedError: contract.ErrorResponse{
+				Message: "invalid request body: unexpected EOF",
+			},
+		},
+		{
+			name:                 "Unknown field",
+			body:                 `{"color":"red"}`,
+			mockRentalCreatingOp: &RentalCreatingOpMock{},
+			expectedCalls:        0,
+			expectedCode:         http.StatusBadRequest,
+			expectedError: contract.ErrorResponse{
+				Message: "invalid request body: json: unknown field \"color\"",
+			},
+		},
+		{
+			name: "Validation error",
+			body: validBody,
+			mockRentalCreatingOp: &RentalCreatingOpMock{
+				CreateRentalFunc: func(ctx context.Context, rental *model.Rental) (*model.Rental, error) {
+					return nil, fmt.Errorf("%w: name is required", svc.ErrInvalidRequestBody)
+				},
+			},
+			expectedRental: &model.Rental{
+				UserID:             2,
+				Name:               "Rental 1",
+				Type:               "Type 1",
+				PricePerDay:        1000,
+				Latitude:           40.1234,
+				Longitude:          -75.5678,
+				CancellationPolicy: model.CancellationPolicyModerate,
+			},
+			expectedCalls: 1,
+			expectedCode:  http.StatusBadRequest,
+			expectedError: contract.ErrorResponse{
+				Message: "invalid request body: name is required",
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			rentalHandler := handler.NewRentalHandler(&RentalFetchingOpMock{}, tc.mockRentalCreatingOp, &RentalUpdatingOpMock{}, &RentalArchivingOpMock{})
+
+			router := chi.NewRouter()
+			router.Post("/rentals", rentalHandler.CreateRental("POST", "/rentals"))
+
+			request := httptest.NewRequest("POST", "/rentals", strings.NewReader(tc.body))
+			responseRecorder := httptest.NewRecorder()
+
+			router.ServeHTTP(responseRecorder, request)
+
+			calls := tc.mockRentalCreatingOp.CreateRentalCalls()
+			if tc.expectedCalls != len(calls) {
+				t.Fatalf("Unexpected number of calls to CreateRental:\nexpected: %d\ngot      %d", tc.expectedCalls, len(calls))
+			}
+
+			if tc.expectedCalls > 0 {
+				if !cmp.Equal(calls[0].Rental, tc.expectedRental) {
+					t.Fatalf("Unexpected rental:\n%s", cmp.Diff(tc.expectedRental, calls[0].Rental))
+				}
+			}
+
+			if responseRecorder.Code != tc.expectedCode {
+				t.Fatalf("Unexpected status code:\nexpected %d\ngot:      %d", tc.expectedCode, responseRecorder.Code)
+			}
+
+			if tc.expectedCode == http.StatusCreated {
+				if location := responseRecorder.Header().Get("Location"); location != tc.expectedLocation {
+					t.Fatalf("Unexpected location:\nexpected: %s\ngot:      %s", tc.expectedLocation, location)
+				}
+
+				var responseBody contract.CreateRentalResponse
+
+				err := json.NewDecoder(responseRecorder.Body).Decode(&responseBody)
+				if err != nil {
+					t.Fatalf("Failed to decode response body: %v", err)
+				}
+
+				if !cmp.Equal(responseBody, tc.expectedResponse) {
+					t.Fatalf("Unexpected rental:\nexpected: %v\ngot:      %v", tc.expectedResponse, responseBody)
+				}
+			} else {
+				var errorResponse contract.ErrorResponse
+
+				err := json.NewDecoder(responseRecorder.Body).Decode(&errorResponse)
+				if err != nil {
+					t.Fatalf("Failed to decode error response body: %v", err)
+				}
+
+				if !cmp.Equal(errorResponse, tc.expectedError) {
+					t.Fatalf("Unexpected error message:\nexpected: %s\ngot      %s", tc.expectedError, errorResponse.Message)
+				}
+			}
+		})
+	}
+}
+
+func TestRentalHandler_UpdateRental(t *testing.T) {
+	validBody := `{"name":"Rental 1","type":"Type 1","Price":{"day":1000},"Location":{"lat":40.1234,"lng":-75.5678},"User":{"id":2}}`
+	currentETag := strconv.Quote(strconv.FormatInt(_rentals[0].Updated.UnixNano(), 36))
+
+	testCases := []struct {
+		name                 string
+		rentalID             string
+		ifMatch              string
+		body                 string
+		mockRentalUpdatingOp *RentalUpdatingOpMock
+		expectedRental       *model.Rental
+		expectedVersion      *time.Time
+		expectedCalls        int
+		expectedCode         int
+		expectedError        contract.ErrorResponse
+	}{
+		{
+			name:     "Unconditional update",
+			rentalID: "1",
+			body:     validBody,
+			mockRentalUpdatingOp: &RentalUpdatingOpMock{
+				UpdateRentalFunc: func(ctx context.Context, rental *model.Rental, version *time.Time) (*model.Rental, error) {
+					return _rentals[0], nil
+				},
+			},
+			expectedRental: &model.Rental{
+				ID:                 1,
+				UserID:             2,
+				Name:               "Rental 1",
+				Type:               "Type 1",
+				PricePerDay:        1000,
+				Latitude:           40.1234,
+				Longitude:          -75.5678,
+				CancellationPolicy: model.CancellationPolicyModerate,
+			},
+			expectedCalls: 1,
+			expectedCode:  http.StatusOK,
+		},
+		{
+			name:     "Conditional update",
+			rentalID: "1",
+			ifMatch:  currentETag,
+			body:     validBody,
+			mockRentalUpdatingOp: &RentalUpdatingOpMock{
+				UpdateRentalFunc: func(ctx context.Context, rental *model.Rental, version *time.Time) (*model.Rental, error) {
+					return _rentals[0], nil
+				},
+			},
+			expectedRental: &model.Rental{
+				ID:                 1,
+				UserID:             2,
+				Name:               "Rental 1",
+				Type:               "Type 1",
+				PricePerDay:        1000,
+				Latitude:           40.1234,
+				Longitude:          -75.5678,
+				CancellationPolicy: model.CancellationPolicyModerate,
+			},
+			expectedVersion: &_rentals[0].Updated,
+			expectedCalls:   1,
+			expectedCode:    http.StatusOK,
+		},
+		{
+			name:                 "Malformed If-Match",
+			rentalID:             "1",
+			ifMatch:              "invalid",
+			body:                 validBody,
+			mockRentalUpdatingOp: &RentalUpdatingOpMock{},
+			expectedCalls:        0,
+			expectedCode:         http.StatusPreconditionFailed,
+			expectedError: contract.ErrorResponse{
+				Message: "precondition failed: malformed If-Match header",
+			},
+		},
+		{
+			name:     "Stale If-Match",
+			rentalID: "1",
+			ifMatch:  `"abc"`,
+			body:     validBody,
+			mockRentalUpdatingOp: &RentalUpdatingOpMock{
+				UpdateRentalFunc: func(ctx context.Context, rental *model.Rental, version *time.Time) (*model.Rental, error) {
+					return nil, fmt.Errorf("%w: rental with id 1 was modified", svc.ErrPreconditionFailed)
+				},
+			},
+			expectedRental: &model.Rental{
+				ID:                 1,
+				UserID:             2,
+				Name:               "Rental 1",
+				Type:               "Type 1",
+				PricePerDay:        1000,
+				Latitude:           40.1234,
+				Longitude:          -75.5678,
+				CancellationPolicy: model.CancellationPolicyModerate,
+			},
+			expectedVersion: toPtr(time.Unix(0, 13368).UTC()),
+			expectedCalls:   1,
+			expectedCode:    http.StatusPreconditionFailed,
+			expectedError: contract.ErrorResponse{
+				Message: "precondition failed: rental with id 1 was modified",
+			},
+		},
+		{
+			name:                 "Invalid rental ID",
+			rentalID:             "invalid",
+			body:                 validBody,
+			mockRentalUpdatingOp: &RentalUpdatingOpMock{},
+			expectedCalls:        0,
+			expectedCode:         http.StatusBadRequest,
+			expectedError: contract.ErrorResponse{
+				Message: "invalid query parameters: id",
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			rentalHandler := handler.NewRentalHandler(&RentalFetchingOpMock{}, &RentalCreatingOpMock{}, tc.mockRentalUpdatingOp, &RentalArchivingOpMock{})
+
+			router := chi.NewRouter()
+			router.Put("/rentals/{id}", rentalHandler.UpdateRental("PUT", "/rentals/{id}"))
+
+			request := httptest.NewRequest("PUT", "/rentals/"+tc.rentalID, strings.NewReader(tc.body))
+			if tc.ifMatch != "" {
+				request.Header.Set("If-Match", tc.ifMatch)
+			}
+			responseRecorder := httptest.NewRecorder()
+
+			router.ServeHTTP(responseRecorder, request)
+
+			calls := tc.mockRentalUpdatingOp.UpdateRentalCalls()
+			if tc.expectedCalls != len(calls) {
+				t.Fatalf("Unexpected number of calls to UpdateRental:\nexpected: %d\ngot      %d", tc.expectedCalls, len(calls))
+			}
+
+			if tc.expectedCalls > 0 {
+				if !cmp.Equal(calls[0].Rental, tc.expectedRental) {
+					t.Fatalf("Unexpected rental:\n%s", cmp.Diff(tc.expectedRental, calls[0].Rental))
+				}
+
+				if !cmp.Equal(calls[0].Version, tc.expectedVersion) {
+					t.Fatalf("Unexpected version:\nexpected: %v\ngot:      %v", tc.expectedVersion, calls[0].Version)
+				}
+			}
+
+			if responseRecorder.Code != tc.expectedCode {
+				t.Fatalf("Unexpected status code:\nexpected %d\ngot:      %d", tc.expectedCode, responseRecorder.Code)
+			}
+
+			if tc.expectedCode == http.StatusOK {
+				if etag := responseRecorder.Header().Get("ETag"); etag != currentETag {
+					t.Fatalf("Unexpected ETag:\nexpected: %s\ngot:      %s", currentETag, etag)
+				}
+			} else {
+				var errorResponse contract.ErrorResponse
+
+				err := json.NewDecoder(responseRecorder.Body).Decode(&errorResponse)
+				if err != nil {
+					t.Fatalf("Failed to decode error response body: %v", err)
+				}
+
+				if !cmp.Equal(errorResponse, tc.expectedError) {
+					t.Fatalf("Unexpected error message:\nexpected: %s\ngot      %s", tc.expectedError, errorResponse.Message)
+				}
+			}
+		})
+	}
+}
+
+func TestRentalHandler_PatchRental(t *testing.T) {
+	currentETag := strconv.Quote(strconv.FormatInt(_rentals[0].Updated.UnixNano(), 36))
+
+	patchedRental := *_rentals[0]
+	patchedRental.Name = "Renamed"
+	patchedRental.Description = ""
+	patchedRental.PricePerDay = 2000
+	patchedRental.Updated = time.Time{}
+	patchedRental.User = nil
+
+	testCases := []struct {
+		name                 string
+		ifMatch              string
+		body                 string
+		mockRentalUpdatingOp *RentalUpdatingOpMock
+		expectedRental       *model.Rental
+		expectedCalls        int
+		expectedCode         int
+		expectedError        contract.ErrorResponse
+	}{
+		{
+			name:    "Valid patch",
+			ifMatch: currentETag,
+			body:    `{"name":"Renamed","description":null,"Price":{"day":2000}}`,
+			mockRentalUpdatingOp: &RentalUpdatingOpMock{
+				UpdateRentalFunc: func(ctx context.Context, rental *model.Rental, version *time.Time) (*model.Rental, error) {
+					return _rentals[0], nil
+				},
+			},
+			expectedRental: &patchedRental,
+			expectedCalls:  1,
+			expectedCode:   http.StatusOK,
+		},
+		{
+			name:                 "Stale If-Match",
+			ifMatch:              `"abc"`,
+			body:                 `{"name":"Renamed"}`,
+			mockRentalUpdatingOp: &RentalUpdatingOpMock{},
+			expectedCalls:        0,
+			expectedCode:         http.StatusPreconditionFailed,
+			expectedError: contract.ErrorResponse{
+				Message: "precondition failed: rental with id 1 was modified",
+			},
+		},
+		{
+			name:                 "Unknown field",
+			body:                 `{"color":"red"}`,
+			mockRentalUpdatingOp: &RentalUpdatingOpMock{},
+			expectedCalls:        0,
+			expectedCode:         http.StatusBadRequest,
+			expectedError: contract.ErrorResponse{
+				Message: "invalid request body: json: unknown field \"color\"",
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockRentalFetchingOp := &RentalFetchingOpMock{
+				GetRentalByIDFunc: func(ctx context.Context, rentalID int) (*model.Rental, error) {
+					return _rentals[0], nil
+				},
+			}
+
+			rentalHandler := handler.NewRentalHandler(mockRentalFetchingOp, &RentalCreatingOpMock{}, tc.mockRentalUpdatingOp, &RentalArchivingOpMock{})
+
+			router := chi.NewRouter()
+			router.Patch("/rentals/{id}", rentalHandler.PatchRental("PATCH", "/rentals/{id}"))
+
+			request := httptest.NewRequest("PATCH", "/rentals/1", strings.NewReader(tc.body))
+			if tc.ifMatch != "" {
+				request.Header.Set("If-Match", tc.ifMatch)
+			}
+			responseRecorder := httptest.NewRecorder()
+
+			router.ServeHTTP(responseRecorder, request)
+
+			calls := tc.mockRentalUpdatingOp.UpdateRentalCalls()
+			if tc.expectedCalls != len(calls) {
+				t.Fatalf("Unexpected number of calls to UpdateRental:\nexpected: %d\ngot      %d", tc.expectedCalls, len(calls))
+			}
+
+			if tc.expectedCalls > 0 {
+				if !cmp.Equal(calls[0].Rental, tc.expectedRental) {
+					t.Fatalf("Unexpected rental:\n%s", cmp.Diff(tc.expectedRental, calls[0].Rental))
+				}
+
+				if !cmp.Equal(calls[0].Version, &_rentals[0].Updated) {
+					t.Fatalf("Unexpected version:\nexpected: %v\ngot:      %v", _rentals[0].Updated, calls[0].Version)
+				}
+			}
+
+			if responseRecorder.Code != tc.expectedCode {
+				t.Fatalf("Unexpected status code:\nexpected %d\ngot:      %d", tc.expectedCode, responseRecorder.Code)
+			}
+
+			if tc.expectedCode != http.StatusOK {
+				var errorResponse contract.ErrorResponse
+
+				err := json.NewDecoder(responseRecorder.Body).Decode(&errorResponse)
+				if err != nil {
+					t.Fatalf("Failed to decode error response body: %v", err)
+				}
+
+				if !cmp.Equal(errorResponse, tc.expectedError) {
+					t.Fatalf("Unexpected error message:\nexpected: %s\ngot      %s", tc.expectedError, errorResponse.Message)
+				}
+			}
+		})
+	}
+}
+
+func TestRentalHandler_DeleteRental(t *testing.T) {
+	testCases := []struct {
+		name                  string
+		rentalID              string
+		mockRentalArchivingOp *RentalArchivingOpMock
+		expectedCalls         int
+		expectedCode          int
+	}{
+		{
+			name:     "Active rental",
+			rentalID: "1",
+			mockRentalArchivingOp: &RentalArchivingOpMock{
+				ArchiveRentalFunc: func(ctx context.Context, rentalID int) error {
+					return nil
+				},
+			},
+			expectedCalls: 1,
+			expectedCode:  http.StatusNoContent,
+		},
+		{
+			name:     "Missing rental",
+			rentalID: "77",
+			mockRentalArchivingOp: &RentalArchivingOpMock{
+				ArchiveRentalFunc: func(ctx context.Context, rentalID int) error {
+					return svc.ErrNotFound
+				},
+			},
+			expectedCalls: 1,
+			expectedCode:  http.StatusNotFound,
+		},
+		{
+			name:                  "Invalid rental ID",
+			rentalID:              "invalid",
+			mockRentalArchivingOp: &RentalArchivingOpMock{},
+			expectedCalls:         0,
+			expectedCode:          http.StatusBadRequest,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			rentalHandler := handler.NewRentalHandler(&RentalFetchingOpMock{}, &RentalCreatingOpMock{}, &RentalUpdatingOpMock{}, tc.mockRentalArchivingOp)
+
+			router := chi.NewRouter()
+			router.Delete("/rentals/{id}", rentalHandler.DeleteRental("DELETE", "/rentals/{id}"))
+
+			request := httptest.NewRequest("DELETE", "/rentals/"+tc.rentalID, nil)
+			responseRecorder := httptest.NewRecorder()
+
+			router.ServeHTTP(responseRecorder, request)
+
+			calls := tc.mockRentalArchivingOp.ArchiveRentalCalls()
+			if tc.expectedCalls != len(calls) {
+				t.Fatalf("Unexpected number of calls to ArchiveRental:\nexpected: %d\ngot      %d", tc.expectedCalls, len(calls))
+			}
+
+			if responseRecorder.Code != tc.expectedCode {
+				t.Fatalf("Unexpected status code:\nexpected %d\ngot:      %d", tc.expectedCode, responseRecorder.Code)
+			}
+		})
+	}
+}
+
+func TestRentalHandler_RestoreRental(t *testing.T) {
+	testCases := []struct {
+		name                  string
+		rentalID              string
+		mockRentalArchivingOp *RentalArchivingOpMock
+		expectedRentalID      int
+		expectedCalls         int
+		expectedCode          int
+		expectedRental        contract.RestoreRentalResponse
+	}{
+		{
+			name:     "Archived rental",
+			rentalID: "1",
+			mockRentalArchivingOp: &RentalArchivingOpMock{
+				RestoreRentalFunc: func(ctx context.Context, rentalID int) (*model.Rental, error) {
+					return _rentals[0], nil
+				},
+			},
+			expectedRentalID: 1,
+			expectedCalls:    1,
+			expectedCode:     http.StatusOK,
+			expectedRental:   contract.RestoreRentalResponse{Rental: *toRentalContract(_rentals[0])},
+		},
+		{
+			name:     "Missing rental",
+			rentalID: "77",
+			mockRentalArchivingOp: &RentalArchivingOpMock{
+				RestoreRentalFunc: func(ctx context.Context, rentalID int) (*model.Rental, error) {
+					return nil, svc.ErrNotFound
+				},
+			},
+			expectedRentalID: 77,
+			expectedCalls:    1,
+			expectedCode:     http.StatusNotFound,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			rentalHandler := handler.NewRentalHandler(&RentalFetchingOpMock{}, &RentalCreatingOpMock{}, &RentalUpdatingOpMock{}, tc.mockRentalArchivingOp)
+
+			router := chi.NewRouter()
+			router.Post("/rentals/{id}:restore", rentalHandler.RestoreRental("POST", "/rentals/{id}:restore"))
+
+			request := httptest.NewRequest("POST", "/rentals/"+tc.rentalID+":restore", nil)
+			responseRecorder := httptest.NewRecorder()
+
+			router.ServeHTTP(responseRecorder, request)
+
+			calls := tc.mockRentalArchivingOp.RestoreRentalCalls()
+			if tc.expectedCalls != len(calls) {
+				t.Fatalf("Unexpected number of calls to RestoreRental:\nexpected: %d\ngot      %d", tc.expectedCalls, len(calls))
+			}
+
+			if tc.expectedCalls > 0 && calls[0].RentalID != tc.expectedRentalID {
+				t.Fatalf("Unexpected rental id:\nexpected: %v\ngot:      %v", tc.expectedRentalID, calls[0].RentalID)
+			}
+
+			if responseRecorder.Code != tc.expectedCode {
+				t.Fatalf("Unexpected status code:\nexpected %d\ngot:      %d", tc.expectedCode, responseRecorder.Code)
+			}
+
+			if tc.expectedCode == http.StatusOK {
+				var responseBody contract.RestoreRentalResponse
+
+				err := json.NewDecoder(responseRecorder.Body).Decode(&responseBody)
+				if err != nil {
+					t.Fatalf("Failed to decode response body: %v", err)
+				}
+
+				if !cmp.Equal(responseBody, tc.expectedRental) {
+					t.Fatalf("Unexpected rental:\nexpected: %v\ngot:      %v", tc.expectedRental, responseBody)
+				}
+			}
+		})
+	}
+}
+
+func toRentalContract(rental *model.Rental) *contract.Rental {
+	return &contract.Rental{
+		ID:                 rental.ID,
+		Name:               rental.Name,
+		Description:        rental.Description,
+		Type:               rental.Type,
+		Make:               rental.VehicleMake,
+		Model:              rental.VehicleModel,
+		Year:               rental.VehicleYear,
+		Length:             rental.VehicleLength,
+		Sleeps:             rental.Sleeps,
+		PrimaryImageURL:    rental.PrimaryImage(),
+		CancellationPolicy: string(rental.CancellationPolicy),
+		Images:             toImagesContract(rental.Images),
+		Amenities:          append([]string{}, rental.Amenities...),
+		Distance:           rental.Distance,
+		Price: contract.Price{
+			Day: rental.PricePerDay,
+		},
+		Location: contract.Location{
+			City:      rental.HomeCity,
+			State:     rental.HomeState,
+			Zip:       rental.HomeZip,
+			Country:   rental.HomeCountry,
+			Latitude:  rental.Latitude,
+			Longitude: rental.Longitude,
+		},
+		User: contract.User{
+			ID:        rental.User.ID,
 			FirstName: rental.User.FirstName,
 			LastName:  rental.User.LastName,
 		},
 	}
 }
+func toImagesContract(images model.RentalImages) []contract.Image {
+	resp := make([]contract.Image, 0, len(images))
+	for _, image := range images {
+		resp = append(resp, contract.Image{ID: image.ID, URL: image.URL, Caption: image.Caption, Position: image.Position, Thumbnails: image.Thumbnails})
+	}
+
+	return resp
+}
+
 func toPtr[T any](v T) *T {
 	return &v
 }
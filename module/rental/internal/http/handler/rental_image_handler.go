@@ -0,0 +1,252 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi"
+
+	"github.com/dragonator/rental-service/module/rental/internal/http/contract"
+	"github.com/dragonator/rental-service/module/rental/internal/http/service/svc"
+	"github.com/dragonator/rental-service/module/rental/internal/model"
+)
+
+// ImageManagingOp is a contract to a rental image managing operation.
+//
+//go:generate moq -rm -pkg handler_test -out image_managing_op_mock_test.go . ImageManagingOp
+type ImageManagingOp interface {
+	ListImages(ctx context.Context, rentalID int) (model.RentalImages, error)
+	GetImage(ctx context.Context, rentalID, imageID int) (*model.RentalImage, error)
+	AddImage(ctx context.Context, image *model.RentalImage) (*model.RentalImage, error)
+	UploadImage(ctx context.Context, rentalID int, caption string, file io.Reader) (*model.RentalImage, error)
+	ReorderImages(ctx context.Context, rentalID int, imageIDs []int32) (model.RentalImages, error)
+	RemoveImage(ctx context.Context, rentalID, imageID int) error
+}
+
+const (
+	_contentTypeMultipart = "multipart/form-data"
+	// _multipartOverhead is allowed on top of the upload size for the boundaries and the other form fields.
+	_multipartOverhead = 1 << 16
+	// _multipartMemory is the part of an upload kept in memory, the rest being buffered in a temporary file.
+	_multipartMemory = 1 << 20
+)
+
+// ImageHandler holds implementation of handlers for rental images.
+type ImageHandler struct {
+	imageManagingOp ImageManagingOp
+	maxUploadSize   int64
+}
+
+// NewImageHandler is a construction function for ImageHandler.
+func NewImageHandler(imageManagingOp ImageManagingOp, maxUploadSize int64) *ImageHandler {
+	return &ImageHandler{
+		imageManagingOp: imageManagingOp,
+		maxUploadSize:   maxUploadSize,
+	}
+}
+
+// ListImages returns a handle that is listing the images of a rental in their order.
+func (ih *ImageHandler) ListImages(method, path string) func(w http.ResponseWriter, r *http.Request) {
+	return func(w http.ResponseWriter, r *http.Request) {
+		rentalID, err := strconv.Atoi(chi.URLParam(r, "id"))
+		if err != nil {
+			errorResponse(w, fmt.Errorf("%w: id", svc.ErrInvalidQueryParameters))
+			return
+		}
+
+		images, err := ih.imageManagingOp.ListImages(r.Context(), rentalID)
+		if err != nil {
+			errorResponse(w, err)
+			return
+		}
+
+		successResponse(w, toListImagesResponse(images))
+
+		return
+	}
+}
+
+// GetImage returns a handle that is fetching an image of a rental by id.
+func (ih *ImageHandler) GetImage(method, path string) func(w http.ResponseWriter, r *http.Request) {
+	return func(w http.ResponseWriter, r *http.Request) {
+		rentalID, err := strconv.Atoi(chi.URLParam(r, "id"))
+		if err != nil {
+			errorResponse(w, fmt.Errorf("%w: id", svc.ErrInvalidQueryParameters))
+			return
+		}
+
+		imageID, err := strconv.Atoi(chi.URLParam(r, "image_id"))
+		if err != nil {
+			errorResponse(w, fmt.Errorf("%w: image_id", svc.ErrInvalidQueryParameters))
+			return
+		}
+
+		image, err := ih.imageManagingOp.GetImage(r.Context(), rentalID, imageID)
+		if err != nil {
+			errorResponse(w, err)
+			return
+		}
+
+		successResponse(w, toImageContract(image))
+
+		return
+	}
+}
+
+// AddImage returns a handle that is appending an image to the images of a rental. A JSON body links
+// an image by url, while a multipart form uploads the image from its "file" field.
+func (ih *ImageHandler) AddImage(method, path string) func(w http.ResponseWriter, r *http.Request) {
+	return func(w http.ResponseWriter, r *http.Request) {
+		rentalID, err := strconv.Atoi(chi.URLParam(r, "id"))
+		if err != nil {
+			errorResponse(w, fmt.Errorf("%w: id", svc.ErrInvalidQueryParameters))
+			return
+		}
+
+		if mediaType, _, _ := mime.ParseMediaType(r.Header.Get(_contentTypeHeaderName)); mediaType == _contentTypeMultipart {
+			ih.uploadImage(w, r, rentalID)
+			return
+		}
+
+		var req contract.AddImageRequest
+		if err := decodeJSONBody(r, &req); err != nil {
+			errorResponse(w, err)
+			return
+		}
+
+		image := &model.RentalImage{
+			RentalID: int32(rentalID),
+			URL:      req.URL,
+			Caption:  req.Caption,
+		}
+
+		created, err := ih.imageManagingOp.AddImage(r.Context(), image)
+		if err != nil {
+			errorResponse(w, err)
+			return
+		}
+
+		createdResponse(w, fmt.Sprintf("/rentals/%d/images/%d", rentalID, created.ID), toImageContract(created))
+
+		return
+	}
+}
+
+// ReorderImages returns a handle that is changing the order of the images of a rental.
+func (ih *ImageHandler) ReorderImages(method, path string) func(w http.ResponseWriter, r *http.Request) {
+	return func(w http.ResponseWriter, r *http.Request) {
+		rentalID, err := strconv.Atoi(chi.URLParam(r, "id"))
+		if err != nil {
+			errorResponse(w, fmt.Errorf("%w: id", svc.ErrInvalidQueryParameters))
+			return
+		}
+
+		var req contract.ReorderImagesRequest
+		if err := decodeJSONBody(r, &req); err != nil {
+			errorResponse(w, err)
+			return
+		}
+
+		images, err := ih.imageManagingOp.ReorderImages(r.Context(), rentalID, req.ImageIDs)
+		if err != nil {
+			errorResponse(w, err)
+			return
+		}
+
+		successResponse(w, toListImagesResponse(images))
+
+		return
+	}
+}
+
+// RemoveImage returns a handle that is removing an image from the images of a rental.
+func (ih *ImageHandler) RemoveImage(method, path string) func(w http.ResponseWriter, r *http.Request) {
+	return func(w http.ResponseWriter, r *http.Request) {
+		rentalID, err := strconv.Atoi(chi.URLParam(r, "id"))
+		if err != nil {
+			errorResponse(w, fmt.Errorf("%w: id", svc.ErrInvalidQueryParameters))
+			return
+		}
+
+		imageID, err := strconv.Atoi(chi.URLParam(r, "image_id"))
+		if err != nil {
+			errorResponse(w, fmt.Errorf("%w: image_id", svc.ErrInvalidQueryParameters))
+			return
+		}
+
+		if err := ih.imageManagingOp.RemoveImage(r.Context(), rentalID, imageID); err != nil {
+			errorResponse(w, err)
+			return
+		}
+
+		noContentResponse(w)
+
+		return
+	}
+}
+
+func (ih *ImageHandler) uploadImage(w http.ResponseWriter, r *http.Request, rentalID int) {
+	r.Body = http.MaxBytesReader(w, r.Body, ih.maxUploadSize+_multipartOverhead)
+
+	if err := r.ParseMultipartForm(_multipartMemory); err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			errorResponse(w, fmt.Errorf("%w: file is larger than %d bytes", svc.ErrPayloadTooLarge, ih.maxUploadSize))
+			return
+		}
+
+		errorResponse(w, fmt.Errorf("%w: parsing multipart form: %w", svc.ErrInvalidRequestBody, err))
+		return
+	}
+
+	defer r.MultipartForm.RemoveAll()
+
+	file, _, err := r.FormFile("file")
+	if err != nil {
+		errorResponse(w, fmt.Errorf("%w: file: %w", svc.ErrInvalidRequestBody, err))
+		return
+	}
+
+	defer file.Close()
+
+	created, err := ih.imageManagingOp.UploadImage(r.Context(), rentalID, r.FormValue("caption"), file)
+	if err != nil {
+		errorResponse(w, err)
+		return
+	}
+
+	createdResponse(w, fmt.Sprintf("/rentals/%d/images/%d", rentalID, created.ID), toImageContract(created))
+}
+
+func toImageContract(image *model.RentalImage) *contract.Image {
+	return &contract.Image{
+		ID:         image.ID,
+		URL:        image.URL,
+		Caption:    image.Caption,
+		Position:   image.Position,
+		Thumbnails: image.Thumbnails,
+	}
+}
+
+func toImagesContract(images model.RentalImages) []contract.Image {
+	resp := make([]contract.Image, 0, len(images))
+	for _, image := range images {
+		resp = append(resp, *toImageContract(image))
+	}
+
+	return resp
+}
+
+func toListImagesResponse(images model.RentalImages) contract.ListImagesResponse {
+	resp := make(contract.ListImagesResponse, 0, len(images))
+	for _, image := range images {
+		resp = append(resp, toImageContract(image))
+	}
+
+	return resp
+}
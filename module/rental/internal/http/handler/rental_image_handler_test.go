@@ -0,0 +1,219 @@
+package handler_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-chi/chi"
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/dragonator/rental-service/module/rental/internal/http/contract"
+	"github.com/dragonator/rental-service/module/rental/internal/http/handler"
+	"github.com/dragonator/rental-service/module/rental/internal/http/service/svc"
+	"github.com/dragonator/rental-service/module/rental/internal/model"
+)
+
+var _images = model.RentalImages{
+	{ID: 3, RentalID: 1, URL: "https://example.com/3.jpg", Caption: "Kitchen"},
+	{ID: 1, RentalID: 1, URL: "https://example.com/1.jpg", Position: 1},
+}
+
+func TestImageHandler_AddImage(t *testing.T) {
+	testCases := []struct {
+		name             string
+		body             string
+		addErr           error
+		expectedCalls    int
+		expectedCode     int
+		expectedLocation string
+	}{
+		{
+			name:             "Valid image",
+			body:             `{"url":"https://example.com/3.jpg","caption":"Kitchen"}`,
+			expectedCalls:    1,
+			expectedCode:     http.StatusCreated,
+			expectedLocation: "/rentals/1/images/3",
+		},
+		{
+			name:          "Unknown field",
+			body:          `{"url":"https://example.com/3.jpg","position":2}`,
+			expectedCalls: 0,
+			expectedCode:  http.StatusBadRequest,
+		},
+		{
+			name:          "Missing rental",
+			body:          `{"url":"https://example.com/3.jpg","caption":"Kitchen"}`,
+			addErr:        svc.ErrNotFound,
+			expectedCalls: 1,
+			expectedCode:  http.StatusNotFound,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockImageManagingOp := &ImageManagingOpMock{
+				AddImageFunc: func(ctx context.Context, image *model.RentalImage) (*model.RentalImage, error) {
+					if tc.addErr != nil {
+						return nil, tc.addErr
+					}
+
+					return _images[0], nil
+				},
+			}
+
+			imageHandler := handler.NewImageHandler(mockImageManagingOp, 1024)
+
+			router := chi.NewRouter()
+			router.Post("/rentals/{id}/images", imageHandler.AddImage("POST", "/rentals/{id}/images"))
+
+			request := httptest.NewRequest("POST", "/rentals/1/images", strings.NewReader(tc.body))
+			responseRecorder := httptest.NewRecorder()
+
+			router.ServeHTTP(responseRecorder, request)
+
+			calls := mockImageManagingOp.AddImageCalls()
+			if len(calls) != tc.expectedCalls {
+				t.Fatalf("Unexpected number of calls to AddImage:\nexpected: %d\ngot      %d", tc.expectedCalls, len(calls))
+			}
+
+			expectedImage := &model.RentalImage{RentalID: 1, URL: "https://example.com/3.jpg", Caption: "Kitchen"}
+			if tc.expectedCalls == 1 && !cmp.Equal(calls[0].Image, expectedImage) {
+				t.Fatalf("Unexpected image:\nexpected: %v\ngot:      %v", expectedImage, calls[0].Image)
+			}
+
+			if responseRecorder.Code != tc.expectedCode {
+				t.Fatalf("Unexpected status code:\nexpected %d\ngot:      %d", tc.expectedCode, responseRecorder.Code)
+			}
+
+			if location := responseRecorder.Header().Get("Location"); location != tc.expectedLocation {
+				t.Fatalf("Unexpected location:\nexpected: %s\ngot:      %s", tc.expectedLocation, location)
+			}
+		})
+	}
+}
+
+func TestImageHandler_UploadImage(t *testing.T) {
+	testCases := []struct {
+		name          string
+		fieldName     string
+		content       []byte
+		expectedCalls int
+		expectedCode  int
+	}{
+		{
+			name:          "Image file",
+			fieldName:     "file",
+			content:       []byte("image content"),
+			expectedCalls: 1,
+			expectedCode:  http.StatusCreated,
+		},
+		{
+			name:          "Missing file",
+			fieldName:     "photo",
+			content:       []byte("image content"),
+			expectedCalls: 0,
+			expectedCode:  http.StatusBadRequest,
+		},
+		{
+			name:          "Too large",
+			fieldName:     "file",
+			content:       bytes.Repeat([]byte("x"), 1024+1<<16),
+			expectedCalls: 0,
+			expectedCode:  http.StatusRequestEntityTooLarge,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockImageManagingOp := &ImageManagingOpMock{
+				UploadImageFunc: func(ctx context.Context, rentalID int, caption string, file io.Reader) (*model.RentalImage, error) {
+					return _images[0], nil
+				},
+			}
+
+			imageHandler := handler.NewImageHandler(mockImageManagingOp, 1024)
+
+			router := chi.NewRouter()
+			router.Post("/rentals/{id}/images", imageHandler.AddImage("POST", "/rentals/{id}/images"))
+
+			var body bytes.Buffer
+
+			form := multipart.NewWriter(&body)
+			_ = form.WriteField("caption", "Kitchen")
+			part, _ := form.CreateFormFile(tc.fieldName, "kitchen.jpg")
+			_, _ = part.Write(tc.content)
+			_ = form.Close()
+
+			request := httptest.NewRequest("POST", "/rentals/1/images", &body)
+			request.Header.Set("Content-Type", form.FormDataContentType())
+			responseRecorder := httptest.NewRecorder()
+
+			router.ServeHTTP(responseRecorder, request)
+
+			calls := mockImageManagingOp.UploadImageCalls()
+			if len(calls) != tc.expectedCalls {
+				t.Fatalf("Unexpected number of calls to UploadImage:\nexpected: %d\ngot      %d", tc.expectedCalls, len(calls))
+			}
+
+			if tc.expectedCalls == 1 && (calls[0].RentalID != 1 || calls[0].Caption != "Kitchen") {
+				t.Fatalf("Unexpected arguments:\nexpected: 1 Kitchen\ngot:      %d %s", calls[0].RentalID, calls[0].Caption)
+			}
+
+			if responseRecorder.Code != tc.expectedCode {
+				t.Fatalf("Unexpected status code:\nexpected %d\ngot:      %d", tc.expectedCode, responseRecorder.Code)
+			}
+		})
+	}
+}
+
+func TestImageHandler_ReorderImages(t *testing.T) {
+	mockImageManagingOp := &ImageManagingOpMock{
+		ReorderImagesFunc: func(ctx context.Context, rentalID int, imageIDs []int32) (model.RentalImages, error) {
+			return _images, nil
+		},
+	}
+
+	imageHandler := handler.NewImageHandler(mockImageManagingOp, 1024)
+
+	router := chi.NewRouter()
+	router.Post("/rentals/{id}/images:reorder", imageHandler.ReorderImages("POST", "/rentals/{id}/images:reorder"))
+
+	request := httptest.NewRequest("POST", "/rentals/1/images:reorder", strings.NewReader(`{"image_ids":[3,1]}`))
+	responseRecorder := httptest.NewRecorder()
+
+	router.ServeHTTP(responseRecorder, request)
+
+	calls := mockImageManagingOp.ReorderImagesCalls()
+	if len(calls) != 1 {
+		t.Fatalf("Unexpected number of calls to ReorderImages:\nexpected: 1\ngot      %d", len(calls))
+	}
+
+	if expectedIDs := []int32{3, 1}; calls[0].RentalID != 1 || !cmp.Equal(calls[0].ImageIDs, expectedIDs) {
+		t.Fatalf("Unexpected arguments:\nexpected: 1 %v\ngot:      %d %v", expectedIDs, calls[0].RentalID, calls[0].ImageIDs)
+	}
+
+	if responseRecorder.Code != http.StatusOK {
+		t.Fatalf("Unexpected status code:\nexpected %d\ngot:      %d", http.StatusOK, responseRecorder.Code)
+	}
+
+	var responseBody contract.ListImagesResponse
+
+	if err := json.NewDecoder(responseRecorder.Body).Decode(&responseBody); err != nil {
+		t.Fatalf("Failed to decode response body: %v", err)
+	}
+
+	expectedImages := contract.ListImagesResponse{
+		{ID: 3, URL: "https://example.com/3.jpg", Caption: "Kitchen"},
+		{ID: 1, URL: "https://example.com/1.jpg", Position: 1},
+	}
+	if !cmp.Equal(responseBody, expectedImages) {
+		t.Fatalf("Unexpected images:\nexpected: %v\ngot:      %v", expectedImages, responseBody)
+	}
+}
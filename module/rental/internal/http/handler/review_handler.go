@@ -0,0 +1,147 @@
+package handler
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi"
+	"github.com/gorilla/schema"
+
+	"github.com/dragonator/rental-service/module/rental/internal/http/contract"
+	"github.com/dragonator/rental-service/module/rental/internal/http/service/svc"
+	"github.com/dragonator/rental-service/module/rental/internal/model"
+	"github.com/dragonator/rental-service/module/rental/internal/storage"
+)
+
+// ReviewManagingOp is a contract to a review managing operation.
+//
+//go:generate moq -rm -pkg handler_test -out review_managing_op_mock_test.go . ReviewManagingOp
+type ReviewManagingOp interface {
+	GetReviewByID(ctx context.Context, reviewID int) (*model.Review, error)
+	ListReviews(ctx context.Context, rentalID int, page *storage.Pagination) (model.Reviews, error)
+	CreateReview(ctx context.Context, review *model.Review) (*model.Review, error)
+}
+
+// ReviewHandler holds implementation of handlers for reviews.
+type ReviewHandler struct {
+	reviewManagingOp ReviewManagingOp
+}
+
+// NewReviewHandler is a construction function for ReviewHandler.
+func NewReviewHandler(reviewManagingOp ReviewManagingOp) *ReviewHandler {
+	return &ReviewHandler{
+		reviewManagingOp: reviewManagingOp,
+	}
+}
+
+// CreateReview returns a handle that is reviewing a rental after a completed booking.
+func (rh *ReviewHandler) CreateReview(method, path string) func(w http.ResponseWriter, r *http.Request) {
+	return func(w http.ResponseWriter, r *http.Request) {
+		rentalID, err := strconv.Atoi(chi.URLParam(r, "id"))
+		if err != nil {
+			errorResponse(w, fmt.Errorf("%w: id", svc.ErrInvalidQueryParameters))
+			return
+		}
+
+		var req contract.CreateReviewRequest
+		if err := decodeJSONBody(r, &req); err != nil {
+			errorResponse(w, err)
+			return
+		}
+
+		review := &model.Review{
+			RentalID:  int32(rentalID),
+			BookingID: req.BookingID,
+			Rating:    req.Rating,
+			Comment:   req.Comment,
+		}
+
+		created, err := rh.reviewManagingOp.CreateReview(r.Context(), review)
+		if err != nil {
+			errorResponse(w, err)
+			return
+		}
+
+		createdResponse(w, fmt.Sprintf("/reviews/%d", created.ID), toReviewContract(created))
+
+		return
+	}
+}
+
+// GetReviewByID returns a handle that is fetching a review by id.
+func (rh *ReviewHandler) GetReviewByID(method, path string) func(w http.ResponseWriter, r *http.Request) {
+	return func(w http.ResponseWriter, r *http.Request) {
+		reviewID, err := strconv.Atoi(chi.URLParam(r, "id"))
+		if err != nil {
+			errorResponse(w, fmt.Errorf("%w: id", svc.ErrInvalidQueryParameters))
+			return
+		}
+
+		review, err := rh.reviewManagingOp.GetReviewByID(r.Context(), reviewID)
+		if err != nil {
+			errorResponse(w, err)
+			return
+		}
+
+		successResponse(w, toReviewContract(review))
+
+		return
+	}
+}
+
+// ListReviews returns a handle that is listing a page of the reviews of a rental.
+func (rh *ReviewHandler) ListReviews(method, path string) func(w http.ResponseWriter, r *http.Request) {
+	return func(w http.ResponseWriter, r *http.Request) {
+		rentalID, err := strconv.Atoi(chi.URLParam(r, "id"))
+		if err != nil {
+			errorResponse(w, fmt.Errorf("%w: id", svc.ErrInvalidQueryParameters))
+			return
+		}
+
+		var query contract.ListReviewsQuery
+
+		if err := r.ParseForm(); err != nil {
+			errorResponse(w, fmt.Errorf("%w: parsing form: %w", svc.ErrInvalidQueryParameters, err))
+			return
+		}
+
+		if err := schema.NewDecoder().Decode(&query, r.Form); err != nil {
+			errorResponse(w, fmt.Errorf("%w: unmashalling query: %w", svc.ErrInvalidQueryParameters, err))
+			return
+		}
+
+		page := &storage.Pagination{
+			Limit:  query.Limit,
+			Offset: query.Offset,
+		}
+
+		reviews, err := rh.reviewManagingOp.ListReviews(r.Context(), rentalID, page)
+		if err != nil {
+			errorResponse(w, err)
+			return
+		}
+
+		resp := make(contract.ListReviewsResponse, 0, len(reviews))
+		for _, review := range reviews {
+			resp = append(resp, toReviewContract(review))
+		}
+
+		successResponse(w, resp)
+
+		return
+	}
+}
+
+func toReviewContract(review *model.Review) *contract.Review {
+	return &contract.Review{
+		ID:        review.ID,
+		RentalID:  review.RentalID,
+		BookingID: review.BookingID,
+		UserID:    review.UserID,
+		Rating:    review.Rating,
+		Comment:   review.Comment,
+		Created:   review.Created,
+	}
+}
@@ -0,0 +1,161 @@
+package handler_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi"
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/dragonator/rental-service/module/rental/internal/http/contract"
+	"github.com/dragonator/rental-service/module/rental/internal/http/handler"
+	"github.com/dragonator/rental-service/module/rental/internal/http/service/svc"
+	"github.com/dragonator/rental-service/module/rental/internal/model"
+	"github.com/dragonator/rental-service/module/rental/internal/storage"
+)
+
+var _reviews = model.Reviews{
+	{
+		ID:        2,
+		RentalID:  1,
+		BookingID: 5,
+		UserID:    3,
+		Rating:    4,
+		Comment:   "Comfortable beds",
+		Created:   time.Date(2023, 7, 20, 10, 0, 0, 0, time.UTC),
+	},
+	{
+		ID:        1,
+		RentalID:  1,
+		BookingID: 4,
+		UserID:    2,
+		Rating:    5,
+		Comment:   "Great van",
+		Created:   time.Date(2023, 7, 10, 10, 0, 0, 0, time.UTC),
+	},
+}
+
+func TestReviewHandler_CreateReview(t *testing.T) {
+	testCases := []struct {
+		name             string
+		body             string
+		createErr        error
+		expectedCalls    int
+		expectedCode     int
+		expectedLocation string
+	}{
+		{
+			name:             "Completed booking",
+			body:             `{"booking_id":4,"rating":5,"comment":"Great van"}`,
+			expectedCalls:    1,
+			expectedCode:     http.StatusCreated,
+			expectedLocation: "/reviews/1",
+		},
+		{
+			name:          "Unknown field",
+			body:          `{"booking_id":4,"stars":5}`,
+			expectedCalls: 0,
+			expectedCode:  http.StatusBadRequest,
+		},
+		{
+			name:          "Booking not completed",
+			body:          `{"booking_id":4,"rating":5}`,
+			createErr:     svc.ErrConflict,
+			expectedCalls: 1,
+			expectedCode:  http.StatusConflict,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockReviewManagingOp := &ReviewManagingOpMock{
+				CreateReviewFunc: func(ctx context.Context, review *model.Review) (*model.Review, error) {
+					if tc.createErr != nil {
+						return nil, tc.createErr
+					}
+
+					return _reviews[1], nil
+				},
+			}
+
+			reviewHandler := handler.NewReviewHandler(mockReviewManagingOp)
+
+			router := chi.NewRouter()
+			router.Post("/rentals/{id}/reviews", reviewHandler.CreateReview("POST", "/rentals/{id}/reviews"))
+
+			request := httptest.NewRequest("POST", "/rentals/1/reviews", strings.NewReader(tc.body))
+			responseRecorder := httptest.NewRecorder()
+
+			router.ServeHTTP(responseRecorder, request)
+
+			calls := mockReviewManagingOp.CreateReviewCalls()
+			if len(calls) != tc.expectedCalls {
+				t.Fatalf("Unexpected number of calls to CreateReview:\nexpected: %d\ngot      %d", tc.expectedCalls, len(calls))
+			}
+
+			expectedReview := &model.Review{RentalID: 1, BookingID: 4, Rating: 5, Comment: "Great van"}
+			if tc.expectedCode == http.StatusCreated && !cmp.Equal(calls[0].Review, expectedReview) {
+				t.Fatalf("Unexpected review:\nexpected: %v\ngot:      %v", expectedReview, calls[0].Review)
+			}
+
+			if responseRecorder.Code != tc.expectedCode {
+				t.Fatalf("Unexpected status code:\nexpected %d\ngot:      %d", tc.expectedCode, responseRecorder.Code)
+			}
+
+			if location := responseRecorder.Header().Get("Location"); location != tc.expectedLocation {
+				t.Fatalf("Unexpected location:\nexpected: %s\ngot:      %s", tc.expectedLocation, location)
+			}
+		})
+	}
+}
+
+func TestReviewHandler_ListReviews(t *testing.T) {
+	mockReviewManagingOp := &ReviewManagingOpMock{
+		ListReviewsFunc: func(ctx context.Context, rentalID int, page *storage.Pagination) (model.Reviews, error) {
+			return _reviews, nil
+		},
+	}
+
+	reviewHandler := handler.NewReviewHandler(mockReviewManagingOp)
+
+	router := chi.NewRouter()
+	router.Get("/rentals/{id}/reviews", reviewHandler.ListReviews("GET", "/rentals/{id}/reviews"))
+
+	request := httptest.NewRequest("GET", "/rentals/1/reviews?limit=2&offset=4", nil)
+	responseRecorder := httptest.NewRecorder()
+
+	router.ServeHTTP(responseRecorder, request)
+
+	calls := mockReviewManagingOp.ListReviewsCalls()
+	if len(calls) != 1 {
+		t.Fatalf("Unexpected number of calls to ListReviews:\nexpected: 1\ngot      %d", len(calls))
+	}
+
+	expectedPage := &storage.Pagination{Limit: toPtr(2), Offset: toPtr(4)}
+	if calls[0].RentalID != 1 || !cmp.Equal(calls[0].Page, expectedPage) {
+		t.Fatalf("Unexpected arguments:\nexpected: 1 %v\ngot:      %d %v", expectedPage, calls[0].RentalID, calls[0].Page)
+	}
+
+	if responseRecorder.Code != http.StatusOK {
+		t.Fatalf("Unexpected status code:\nexpected %d\ngot:      %d", http.StatusOK, responseRecorder.Code)
+	}
+
+	var responseBody contract.ListReviewsResponse
+
+	if err := json.NewDecoder(responseRecorder.Body).Decode(&responseBody); err != nil {
+		t.Fatalf("Failed to decode response body: %v", err)
+	}
+
+	expectedReviews := contract.ListReviewsResponse{
+		{ID: 2, RentalID: 1, BookingID: 5, UserID: 3, Rating: 4, Comment: "Comfortable beds", Created: _reviews[0].Created},
+		{ID: 1, RentalID: 1, BookingID: 4, UserID: 2, Rating: 5, Comment: "Great van", Created: _reviews[1].Created},
+	}
+	if !cmp.Equal(responseBody, expectedReviews) {
+		t.Fatalf("Unexpected reviews:\nexpected: %v\ngot:      %v", expectedReviews, responseBody)
+	}
+}
@@ -0,0 +1,284 @@
+package handler
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi"
+	"github.com/gorilla/schema"
+
+	"github.com/dragonator/rental-service/module/rental/internal/http/contract"
+	"github.com/dragonator/rental-service/module/rental/internal/http/service/svc"
+	"github.com/dragonator/rental-service/module/rental/internal/model"
+	"github.com/dragonator/rental-service/module/rental/internal/storage"
+)
+
+// UserFetchingOp is a contract to a user fetching operation.
+//
+//go:generate moq -rm -pkg handler_test -out user_fetching_op_mock_test.go . UserFetchingOp
+type UserFetchingOp interface {
+	GetUserByID(ctx context.Context, userID int) (*model.User, error)
+	ListUsers(ctx context.Context, filters *storage.UserFilters) (model.Users, error)
+}
+
+// UserManagingOp is a contract to a user managing operation.
+//
+//go:generate moq -rm -pkg handler_test -out user_managing_op_mock_test.go . UserManagingOp
+type UserManagingOp interface {
+	CreateUser(ctx context.Context, user *model.User) (*model.User, error)
+	UpdateUser(ctx context.Context, user *model.User) (*model.User, error)
+	DeleteUser(ctx context.Context, userID int) error
+}
+
+// UserHandler holds implementation of handlers for users.
+type UserHandler struct {
+	userFetchingOp   UserFetchingOp
+	userManagingOp   UserManagingOp
+	rentalFetchingOp RentalFetchingOp
+}
+
+// NewUserHandler is a construction function for UserHandler.
+func NewUserHandler(
+	userFetchingOp UserFetchingOp,
+	userManagingOp UserManagingOp,
+	rentalFetchingOp RentalFetchingOp,
+) *UserHandler {
+	return &UserHandler{
+		userFetchingOp:   userFetchingOp,
+		userManagingOp:   userManagingOp,
+		rentalFetchingOp: rentalFetchingOp,
+	}
+}
+
+// GetUserByID returns a handle that is fetching a user by id.
+func (uh *UserHandler) GetUserByID(method, path string) func(w http.ResponseWriter, r *http.Request) {
+	return func(w http.ResponseWriter, r *http.Request) {
+		userID, err := strconv.Atoi(chi.URLParam(r, "id"))
+		if err != nil {
+			errorResponse(w, fmt.Errorf("%w: id", svc.ErrInvalidQueryParameters))
+			return
+		}
+
+		user, err := uh.userFetchingOp.GetUserByID(r.Context(), userID)
+		if err != nil {
+			errorResponse(w, err)
+			return
+		}
+
+		successResponse(w, toUserContract(user))
+
+		return
+	}
+}
+
+// ListUsers returns a handle that is listing users based on filters.
+func (uh *UserHandler) ListUsers(method, path string) func(w http.ResponseWriter, r *http.Request) {
+	return func(w http.ResponseWriter, r *http.Request) {
+		filters, err := userFiltersFromRequest(r)
+		if err != nil {
+			errorResponse(w, err)
+			return
+		}
+
+		users, err := uh.userFetchingOp.ListUsers(r.Context(), filters)
+		if err != nil {
+			errorResponse(w, err)
+			return
+		}
+
+		successResponse(w, toListUsersResponse(users))
+
+		return
+	}
+}
+
+// CreateUser returns a handle that is creating a new user.
+func (uh *UserHandler) CreateUser(method, path string) func(w http.ResponseWriter, r *http.Request) {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req contract.CreateUserRequest
+		if err := decodeJSONBody(r, &req); err != nil {
+			errorResponse(w, err)
+			return
+		}
+
+		user, err := uh.userManagingOp.CreateUser(r.Context(), toUserModel(&req.User))
+		if err != nil {
+			errorResponse(w, err)
+			return
+		}
+
+		createdResponse(w, fmt.Sprintf("/users/%d", user.ID), toUserContract(user))
+
+		return
+	}
+}
+
+// PatchUser returns a handle that is applying a JSON merge patch to a user.
+func (uh *UserHandler) PatchUser(method, path string) func(w http.ResponseWriter, r *http.Request) {
+	return func(w http.ResponseWriter, r *http.Request) {
+		userID, err := strconv.Atoi(chi.URLParam(r, "id"))
+		if err != nil {
+			errorResponse(w, fmt.Errorf("%w: id", svc.ErrInvalidQueryParameters))
+			return
+		}
+
+		patch, err := io.ReadAll(r.Body)
+		if err != nil {
+			errorResponse(w, fmt.Errorf("%w: %w", svc.ErrInvalidRequestBody, err))
+			return
+		}
+
+		current, err := uh.userFetchingOp.GetUserByID(r.Context(), userID)
+		if err != nil {
+			errorResponse(w, err)
+			return
+		}
+
+		user, err := applyUserPatch(current, patch)
+		if err != nil {
+			errorResponse(w, err)
+			return
+		}
+
+		updated, err := uh.userManagingOp.UpdateUser(r.Context(), user)
+		if err != nil {
+			errorResponse(w, err)
+			return
+		}
+
+		successResponse(w, toUserContract(updated))
+
+		return
+	}
+}
+
+// DeleteUser returns a handle that is deleting a user.
+func (uh *UserHandler) DeleteUser(method, path string) func(w http.ResponseWriter, r *http.Request) {
+	return func(w http.ResponseWriter, r *http.Request) {
+		userID, err := strconv.Atoi(chi.URLParam(r, "id"))
+		if err != nil {
+			errorResponse(w, fmt.Errorf("%w: id", svc.ErrInvalidQueryParameters))
+			return
+		}
+
+		if err := uh.userManagingOp.DeleteUser(r.Context(), userID); err != nil {
+			errorResponse(w, err)
+			return
+		}
+
+		noContentResponse(w)
+
+		return
+	}
+}
+
+// ListUserRentals returns a handle that is listing the rentals owned by a user based on filters.
+func (uh *UserHandler) ListUserRentals(method, path string) func(w http.ResponseWriter, r *http.Request) {
+	return func(w http.ResponseWriter, r *http.Request) {
+		userID, err := strconv.Atoi(chi.URLParam(r, "id"))
+		if err != nil {
+			errorResponse(w, fmt.Errorf("%w: id", svc.ErrInvalidQueryParameters))
+			return
+		}
+
+		filters, err := rentalFiltersFromRequest(r)
+		if err != nil {
+			errorResponse(w, err)
+			return
+		}
+
+		if _, err := uh.userFetchingOp.GetUserByID(r.Context(), userID); err != nil {
+			errorResponse(w, err)
+			return
+		}
+
+		filters.UserIDs = []int32{int32(userID)}
+
+		rentals, err := uh.rentalFetchingOp.ListRentals(r.Context(), filters)
+		if err != nil {
+			errorResponse(w, err)
+			return
+		}
+
+		respondRentalsPage(w, r, uh.rentalFetchingOp, filters, rentals)
+
+		return
+	}
+}
+
+func applyUserPatch(user *model.User, patch []byte) (*model.User, error) {
+	document, err := json.Marshal(toUserContract(user))
+	if err != nil {
+		return nil, fmt.Errorf("marshalling user: %w", err)
+	}
+
+	patched, err := mergePatch(document, patch)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", svc.ErrInvalidRequestBody, err)
+	}
+
+	var patchedUser contract.User
+
+	decoder := json.NewDecoder(bytes.NewReader(patched))
+	decoder.DisallowUnknownFields()
+
+	if err := decoder.Decode(&patchedUser); err != nil {
+		return nil, fmt.Errorf("%w: %w", svc.ErrInvalidRequestBody, err)
+	}
+
+	result := toUserModel(&patchedUser)
+	result.ID = user.ID
+
+	return result, nil
+}
+
+func userFiltersFromRequest(r *http.Request) (*storage.UserFilters, error) {
+	var query contract.ListUsersQuery
+
+	if err := r.ParseForm(); err != nil {
+		return nil, fmt.Errorf("%w: parsing form: %w", svc.ErrInvalidQueryParameters, err)
+	}
+
+	if err := schema.NewDecoder().Decode(&query, r.Form); err != nil {
+		return nil, fmt.Errorf("%w: unmashalling query: %w", svc.ErrInvalidQueryParameters, err)
+	}
+
+	return &storage.UserFilters{
+		Name: query.Name,
+		Pagination: storage.Pagination{
+			Limit:  query.Limit,
+			Offset: query.Offset,
+		},
+	}, nil
+}
+
+func toUserContract(user *model.User) *contract.User {
+	return &contract.User{
+		ID:        user.ID,
+		FirstName: user.FirstName,
+		LastName:  user.LastName,
+	}
+}
+
+func toUserModel(user *contract.User) *model.User {
+	return &model.User{
+		ID:        user.ID,
+		FirstName: user.FirstName,
+		LastName:  user.LastName,
+	}
+}
+
+func toListUsersResponse(users model.Users) *contract.ListUsersResponse {
+	resp := contract.ListUsersResponse{}
+
+	for _, u := range users {
+		resp = append(resp, toUserContract(u))
+	}
+
+	return &resp
+}
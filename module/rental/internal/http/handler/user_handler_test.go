@@ -0,0 +1,374 @@
+package handler_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-chi/chi"
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/dragonator/rental-service/module/rental/internal/http/contract"
+	"github.com/dragonator/rental-service/module/rental/internal/http/handler"
+	"github.com/dragonator/rental-service/module/rental/internal/http/service/svc"
+	"github.com/dragonator/rental-service/module/rental/internal/model"
+	"github.com/dragonator/rental-service/module/rental/internal/storage"
+)
+
+var _users = model.Users{
+	{
+		ID:        2,
+		FirstName: "FirstName 1",
+		LastName:  "LastName 1",
+	},
+	{
+		ID:        3,
+		FirstName: "FirstName 2",
+		LastName:  "LastName 2",
+	},
+}
+
+func TestUserHandler_GetUserByID(t *testing.T) {
+	testCases := []struct {
+		name               string
+		userID             string
+		mockUserFetchingOp *UserFetchingOpMock
+		expectedCalls      int
+		expectedCode       int
+		expectedUser       contract.GetUserByIDResponse
+	}{
+		{
+			name:   "Valid user ID",
+			userID: "2",
+			mockUserFetchingOp: &UserFetchingOpMock{
+				GetUserByIDFunc: func(ctx context.Context, userID int) (*model.User, error) {
+					return _users[0], nil
+				},
+			},
+			expectedCalls: 1,
+			expectedCode:  http.StatusOK,
+			expectedUser:  contract.GetUserByIDResponse{User: contract.User{ID: 2, FirstName: "FirstName 1", LastName: "LastName 1"}},
+		},
+		{
+			name:   "Missing user ID",
+			userID: "77",
+			mockUserFetchingOp: &UserFetchingOpMock{
+				GetUserByIDFunc: func(ctx context.Context, userID int) (*model.User, error) {
+					return nil, svc.ErrNotFound
+				},
+			},
+			expectedCalls: 1,
+			expectedCode:  http.StatusNotFound,
+		},
+		{
+			name:               "Invalid user ID",
+			userID:             "invalid",
+			mockUserFetchingOp: &UserFetchingOpMock{},
+			expectedCalls:      0,
+			expectedCode:       http.StatusBadRequest,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			userHandler := handler.NewUserHandler(tc.mockUserFetchingOp, &UserManagingOpMock{}, &RentalFetchingOpMock{})
+
+			router := chi.NewRouter()
+			router.Get("/users/{id}", userHandler.GetUserByID("GET", "/users/{id}"))
+
+			request := httptest.NewRequest("GET", "/users/"+tc.userID, nil)
+			responseRecorder := httptest.NewRecorder()
+
+			router.ServeHTTP(responseRecorder, request)
+
+			if calls := tc.mockUserFetchingOp.GetUserByIDCalls(); tc.expectedCalls != len(calls) {
+				t.Fatalf("Unexpected number of calls to GetUserByID:\nexpected: %d\ngot      %d", tc.expectedCalls, len(calls))
+			}
+
+			if responseRecorder.Code != tc.expectedCode {
+				t.Fatalf("Unexpected status code:\nexpected %d\ngot:      %d", tc.expectedCode, responseRecorder.Code)
+			}
+
+			if tc.expectedCode == http.StatusOK {
+				var responseBody contract.GetUserByIDResponse
+
+				if err := json.NewDecoder(responseRecorder.Body).Decode(&responseBody); err != nil {
+					t.Fatalf("Failed to decode response body: %v", err)
+				}
+
+				if !cmp.Equal(responseBody, tc.expectedUser) {
+					t.Fatalf("Unexpected user:\nexpected: %v\ngot:      %v", tc.expectedUser, responseBody)
+				}
+			}
+		})
+	}
+}
+
+func TestUserHandler_ListUsers(t *testing.T) {
+	testCases := []struct {
+		name            string
+		query           string
+		expectedFilters *storage.UserFilters
+		expectedCalls   int
+		expectedCode    int
+	}{
+		{
+			name:  "Name and pagination",
+			query: "?name=John&limit=3&offset=6",
+			expectedFilters: &storage.UserFilters{
+				Name: toPtr("John"),
+				Pagination: storage.Pagination{
+					Limit:  toPtr(3),
+					Offset: toPtr(6),
+				},
+			},
+			expectedCalls: 1,
+			expectedCode:  http.StatusOK,
+		},
+		{
+			name:          "Invalid limit",
+			query:         "?limit=invalid",
+			expectedCalls: 0,
+			expectedCode:  http.StatusBadRequest,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockUserFetchingOp := &UserFetchingOpMock{
+				ListUsersFunc: func(ctx context.Context, filters *storage.UserFilters) (model.Users, error) {
+					return _users, nil
+				},
+			}
+
+			userHandler := handler.NewUserHandler(mockUserFetchingOp, &UserManagingOpMock{}, &RentalFetchingOpMock{})
+
+			router := chi.NewRouter()
+			router.Get("/users", userHandler.ListUsers("GET", "/users"))
+
+			request := httptest.NewRequest("GET", "/users"+tc.query, nil)
+			responseRecorder := httptest.NewRecorder()
+
+			router.ServeHTTP(responseRecorder, request)
+
+			calls := mockUserFetchingOp.ListUsersCalls()
+			if tc.expectedCalls != len(calls) {
+				t.Fatalf("Unexpected number of calls to ListUsers:\nexpected: %d\ngot      %d", tc.expectedCalls, len(calls))
+			}
+
+			if tc.expectedCalls > 0 && !cmp.Equal(calls[0].Filters, tc.expectedFilters) {
+				t.Fatalf("Unexpected filters:\nexpected: %v\ngot:      %v", tc.expectedFilters, calls[0].Filters)
+			}
+
+			if responseRecorder.Code != tc.expectedCode {
+				t.Fatalf("Unexpected status code:\nexpected %d\ngot:      %d", tc.expectedCode, responseRecorder.Code)
+			}
+		})
+	}
+}
+
+func TestUserHandler_CreateUser(t *testing.T) {
+	mockUserManagingOp := &UserManagingOpMock{
+		CreateUserFunc: func(ctx context.Context, user *model.User) (*model.User, error) {
+			return _users[0], nil
+		},
+	}
+
+	userHandler := handler.NewUserHandler(&UserFetchingOpMock{}, mockUserManagingOp, &RentalFetchingOpMock{})
+
+	router := chi.NewRouter()
+	router.Post("/users", userHandler.CreateUser("POST", "/users"))
+
+	request := httptest.NewRequest("POST", "/users", strings.NewReader(`{"first_name":"FirstName 1","last_name":"LastName 1"}`))
+	responseRecorder := httptest.NewRecorder()
+
+	router.ServeHTTP(responseRecorder, request)
+
+	calls := mockUserManagingOp.CreateUserCalls()
+	if len(calls) != 1 {
+		t.Fatalf("Unexpected number of calls to CreateUser:\nexpected: 1\ngot      %d", len(calls))
+	}
+
+	expectedUser := &model.User{FirstName: "FirstName 1", LastName: "LastName 1"}
+	if !cmp.Equal(calls[0].User, expectedUser) {
+		t.Fatalf("Unexpected user:\nexpected: %v\ngot:      %v", expectedUser, calls[0].User)
+	}
+
+	if responseRecorder.Code != http.StatusCreated {
+		t.Fatalf("Unexpected status code:\nexpected %d\ngot:      %d", http.StatusCreated, responseRecorder.Code)
+	}
+
+	if location := responseRecorder.Header().Get("Location"); location != "/users/2" {
+		t.Fatalf("Unexpected location:\nexpected: /users/2\ngot:      %s", location)
+	}
+}
+
+func TestUserHandler_PatchUser(t *testing.T) {
+	mockUserFetchingOp := &UserFetchingOpMock{
+		GetUserByIDFunc: func(ctx context.Context, userID int) (*model.User, error) {
+			return _users[0], nil
+		},
+	}
+	mockUserManagingOp := &UserManagingOpMock{
+		UpdateUserFunc: func(ctx context.Context, user *model.User) (*model.User, error) {
+			return user, nil
+		},
+	}
+
+	userHandler := handler.NewUserHandler(mockUserFetchingOp, mockUserManagingOp, &RentalFetchingOpMock{})
+
+	router := chi.NewRouter()
+	router.Patch("/users/{id}", userHandler.PatchUser("PATCH", "/users/{id}"))
+
+	request := httptest.NewRequest("PATCH", "/users/2", strings.NewReader(`{"last_name":"Renamed","id":99}`))
+	responseRecorder := httptest.NewRecorder()
+
+	router.ServeHTTP(responseRecorder, request)
+
+	calls := mockUserManagingOp.UpdateUserCalls()
+	if len(calls) != 1 {
+		t.Fatalf("Unexpected number of calls to UpdateUser:\nexpected: 1\ngot      %d", len(calls))
+	}
+
+	expectedUser := &model.User{ID: 2, FirstName: "FirstName 1", LastName: "Renamed"}
+	if !cmp.Equal(calls[0].User, expectedUser) {
+		t.Fatalf("Unexpected user:\nexpected: %v\ngot:      %v", expectedUser, calls[0].User)
+	}
+
+	if responseRecorder.Code != http.StatusOK {
+		t.Fatalf("Unexpected status code:\nexpected %d\ngot:      %d", http.StatusOK, responseRecorder.Code)
+	}
+}
+
+func TestUserHandler_DeleteUser(t *testing.T) {
+	testCases := []struct {
+		name         string
+		deleteErr    error
+		expectedCode int
+	}{
+		{
+			name:         "User without rentals",
+			expectedCode: http.StatusNoContent,
+		},
+		{
+			name:         "User with rentals",
+			deleteErr:    svc.ErrConflict,
+			expectedCode: http.StatusConflict,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockUserManagingOp := &UserManagingOpMock{
+				DeleteUserFunc: func(ctx context.Context, userID int) error {
+					return tc.deleteErr
+				},
+			}
+
+			userHandler := handler.NewUserHandler(&UserFetchingOpMock{}, mockUserManagingOp, &RentalFetchingOpMock{})
+
+			router := chi.NewRouter()
+			router.Delete("/users/{id}", userHandler.DeleteUser("DELETE", "/users/{id}"))
+
+			request := httptest.NewRequest("DELETE", "/users/2", nil)
+			responseRecorder := httptest.NewRecorder()
+
+			router.ServeHTTP(responseRecorder, request)
+
+			calls := mockUserManagingOp.DeleteUserCalls()
+			if len(calls) != 1 || calls[0].UserID != 2 {
+				t.Fatalf("Unexpected calls to DeleteUser: %v", calls)
+			}
+
+			if responseRecorder.Code != tc.expectedCode {
+				t.Fatalf("Unexpected status code:\nexpected %d\ngot:      %d", tc.expectedCode, responseRecorder.Code)
+			}
+		})
+	}
+}
+
+func TestUserHandler_ListUserRentals(t *testing.T) {
+	testCases := []struct {
+		name            string
+		userID          string
+		query           string
+		getUserErr      error
+		expectedFilters *storage.RentalFilters
+		expectedCalls   int
+		expectedCode    int
+	}{
+		{
+			name:   "Existing user",
+			userID: "2",
+			query:  "?sort=price_per_day&limit=3&user_id=5",
+			expectedFilters: &storage.RentalFilters{
+				UserIDs: []int32{2},
+				OrderBy: []storage.SortTerm{{Field: "price_per_day"}},
+				Pagination: storage.Pagination{
+					Limit: toPtr(3),
+				},
+			},
+			expectedCalls: 1,
+			expectedCode:  http.StatusOK,
+		},
+		{
+			name:          "Missing user",
+			userID:        "77",
+			getUserErr:    svc.ErrNotFound,
+			expectedCalls: 0,
+			expectedCode:  http.StatusNotFound,
+		},
+		{
+			name:          "Invalid sort field",
+			userID:        "2",
+			query:         "?sort=invalid",
+			expectedCalls: 0,
+			expectedCode:  http.StatusBadRequest,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockUserFetchingOp := &UserFetchingOpMock{
+				GetUserByIDFunc: func(ctx context.Context, userID int) (*model.User, error) {
+					if tc.getUserErr != nil {
+						return nil, tc.getUserErr
+					}
+
+					return _users[0], nil
+				},
+			}
+			mockRentalFetchingOp := &RentalFetchingOpMock{
+				ListRentalsFunc: func(ctx context.Context, filters *storage.RentalFilters) (model.Rentals, error) {
+					return _rentals, nil
+				},
+			}
+
+			userHandler := handler.NewUserHandler(mockUserFetchingOp, &UserManagingOpMock{}, mockRentalFetchingOp)
+
+			router := chi.NewRouter()
+			router.Get("/users/{id}/rentals", userHandler.ListUserRentals("GET", "/users/{id}/rentals"))
+
+			request := httptest.NewRequest("GET", "/users/"+tc.userID+"/rentals"+tc.query, nil)
+			responseRecorder := httptest.NewRecorder()
+
+			router.ServeHTTP(responseRecorder, request)
+
+			calls := mockRentalFetchingOp.ListRentalsCalls()
+			if tc.expectedCalls != len(calls) {
+				t.Fatalf("Unexpected number of calls to ListRentals:\nexpected: %d\ngot      %d", tc.expectedCalls, len(calls))
+			}
+
+			if tc.expectedCalls > 0 && !cmp.Equal(calls[0].Filters, tc.expectedFilters) {
+				t.Fatalf("Unexpected filters:\nexpected: %v\ngot:      %v", tc.expectedFilters, calls[0].Filters)
+			}
+
+			if responseRecorder.Code != tc.expectedCode {
+				t.Fatalf("Unexpected status code:\nexpected %d\ngot:      %d", tc.expectedCode, responseRecorder.Code)
+			}
+		})
+	}
+}
@@ -3,6 +3,7 @@ package handler
 import (
 	"encoding/json"
 	"errors"
+	"fmt"
 	"net/http"
 
 	"github.com/dragonator/rental-service/module/rental/internal/http/contract"
@@ -14,8 +15,20 @@ const (
 	_contentTypeJSON       = "application/json"
 	_xContentTypeOptions   = "X-Content-Type-Options"
 	_noSniff               = "nosniff"
+	_locationHeaderName    = "Location"
 )
 
+func decodeJSONBody(r *http.Request, dst interface{}) error {
+	decoder := json.NewDecoder(r.Body)
+	decoder.DisallowUnknownFields()
+
+	if err := decoder.Decode(dst); err != nil {
+		return fmt.Errorf("%w: %w", svc.ErrInvalidRequestBody, err)
+	}
+
+	return nil
+}
+
 func errorResponse(w http.ResponseWriter, err error) {
 	er := &contract.ErrorResponse{Message: err.Error()}
 	w.Header().Set(_contentTypeHeaderName, _contentTypeJSON)
@@ -38,3 +51,14 @@ func successResponse(w http.ResponseWriter, resp interface{}) {
 	w.WriteHeader(http.StatusOK)
 	json.NewEncoder(w).Encode(resp)
 }
+
+func createdResponse(w http.ResponseWriter, location string, resp interface{}) {
+	w.Header().Set(_contentTypeHeaderName, _contentTypeJSON)
+	w.Header().Set(_locationHeaderName, location)
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(resp)
+}
+
+func noContentResponse(w http.ResponseWriter) {
+	w.WriteHeader(http.StatusNoContent)
+}
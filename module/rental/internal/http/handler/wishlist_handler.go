@@ -0,0 +1,201 @@
+package handler
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi"
+
+	"github.com/dragonator/rental-service/module/rental/internal/http/contract"
+	"github.com/dragonator/rental-service/module/rental/internal/http/service/svc"
+	"github.com/dragonator/rental-service/module/rental/internal/model"
+)
+
+// WishlistManagingOp is a contract to a wishlist managing operation.
+//
+//go:generate moq -rm -pkg handler_test -out wishlist_managing_op_mock_test.go . WishlistManagingOp
+type WishlistManagingOp interface {
+	GetWishlistByID(ctx context.Context, wishlistID int) (*model.Wishlist, error)
+	ListWishlists(ctx context.Context, userID int) (model.Wishlists, error)
+	CreateWishlist(ctx context.Context, wishlist *model.Wishlist) (*model.Wishlist, error)
+	DeleteWishlist(ctx context.Context, wishlistID int) error
+	AddRental(ctx context.Context, wishlistID, rentalID int) error
+	RemoveRental(ctx context.Context, wishlistID, rentalID int) error
+}
+
+// WishlistHandler holds implementation of handlers for wishlists.
+type WishlistHandler struct {
+	wishlistManagingOp WishlistManagingOp
+}
+
+// NewWishlistHandler is a construction function for WishlistHandler.
+func NewWishlistHandler(wishlistManagingOp WishlistManagingOp) *WishlistHandler {
+	return &WishlistHandler{
+		wishlistManagingOp: wishlistManagingOp,
+	}
+}
+
+// CreateWishlist returns a handle that is creating a wishlist of a user.
+func (wh *WishlistHandler) CreateWishlist(method, path string) func(w http.ResponseWriter, r *http.Request) {
+	return func(w http.ResponseWriter, r *http.Request) {
+		userID, err := strconv.Atoi(chi.URLParam(r, "id"))
+		if err != nil {
+			errorResponse(w, fmt.Errorf("%w: id", svc.ErrInvalidQueryParameters))
+			return
+		}
+
+		var req contract.CreateWishlistRequest
+		if err := decodeJSONBody(r, &req); err != nil {
+			errorResponse(w, err)
+			return
+		}
+
+		wishlist := &model.Wishlist{
+			UserID: int32(userID),
+			Name:   req.Name,
+		}
+
+		created, err := wh.wishlistManagingOp.CreateWishlist(r.Context(), wishlist)
+		if err != nil {
+			errorResponse(w, err)
+			return
+		}
+
+		createdResponse(w, fmt.Sprintf("/wishlists/%d", created.ID), toWishlistContract(created))
+
+		return
+	}
+}
+
+// ListWishlists returns a handle that is listing the wishlists of a user.
+func (wh *WishlistHandler) ListWishlists(method, path string) func(w http.ResponseWriter, r *http.Request) {
+	return func(w http.ResponseWriter, r *http.Request) {
+		userID, err := strconv.Atoi(chi.URLParam(r, "id"))
+		if err != nil {
+			errorResponse(w, fmt.Errorf("%w: id", svc.ErrInvalidQueryParameters))
+			return
+		}
+
+		wishlists, err := wh.wishlistManagingOp.ListWishlists(r.Context(), userID)
+		if err != nil {
+			errorResponse(w, err)
+			return
+		}
+
+		resp := make(contract.ListWishlistsResponse, 0, len(wishlists))
+		for _, wishlist := range wishlists {
+			resp = append(resp, toWishlistContract(wishlist))
+		}
+
+		successResponse(w, resp)
+
+		return
+	}
+}
+
+// GetWishlistByID returns a handle that is fetching a wishlist by id.
+func (wh *WishlistHandler) GetWishlistByID(method, path string) func(w http.ResponseWriter, r *http.Request) {
+	return func(w http.ResponseWriter, r *http.Request) {
+		wishlistID, err := strconv.Atoi(chi.URLParam(r, "id"))
+		if err != nil {
+			errorResponse(w, fmt.Errorf("%w: id", svc.ErrInvalidQueryParameters))
+			return
+		}
+
+		wishlist, err := wh.wishlistManagingOp.GetWishlistByID(r.Context(), wishlistID)
+		if err != nil {
+			errorResponse(w, err)
+			return
+		}
+
+		successResponse(w, toWishlistContract(wishlist))
+
+		return
+	}
+}
+
+// DeleteWishlist returns a handle that is deleting a wishlist.
+func (wh *WishlistHandler) DeleteWishlist(method, path string) func(w http.ResponseWriter, r *http.Request) {
+	return func(w http.ResponseWriter, r *http.Request) {
+		wishlistID, err := strconv.Atoi(chi.URLParam(r, "id"))
+		if err != nil {
+			errorResponse(w, fmt.Errorf("%w: id", svc.ErrInvalidQueryParameters))
+			return
+		}
+
+		if err := wh.wishlistManagingOp.DeleteWishlist(r.Context(), wishlistID); err != nil {
+			errorResponse(w, err)
+			return
+		}
+
+		noContentResponse(w)
+
+		return
+	}
+}
+
+// AddRental returns a handle that is saving a rental into a wishlist.
+func (wh *WishlistHandler) AddRental(method, path string) func(w http.ResponseWriter, r *http.Request) {
+	return func(w http.ResponseWriter, r *http.Request) {
+		wishlistID, rentalID, err := wishlistRentalParams(r)
+		if err != nil {
+			errorResponse(w, err)
+			return
+		}
+
+		if err := wh.wishlistManagingOp.AddRental(r.Context(), wishlistID, rentalID); err != nil {
+			errorResponse(w, err)
+			return
+		}
+
+		noContentResponse(w)
+
+		return
+	}
+}
+
+// RemoveRental returns a handle that is removing a rental from a wishlist.
+func (wh *WishlistHandler) RemoveRental(method, path string) func(w http.ResponseWriter, r *http.Request) {
+	return func(w http.ResponseWriter, r *http.Request) {
+		wishlistID, rentalID, err := wishlistRentalParams(r)
+		if err != nil {
+			errorResponse(w, err)
+			return
+		}
+
+		if err := wh.wishlistManagingOp.RemoveRental(r.Context(), wishlistID, rentalID); err != nil {
+			errorResponse(w, err)
+			return
+		}
+
+		noContentResponse(w)
+
+		return
+	}
+}
+
+func wishlistRentalParams(r *http.Request) (int, int, error) {
+	wishlistID, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		return 0, 0, fmt.Errorf("%w: id", svc.ErrInvalidQueryParameters)
+	}
+
+	rentalID, err := strconv.Atoi(chi.URLParam(r, "rental_id"))
+	if err != nil {
+		return 0, 0, fmt.Errorf("%w: rental_id", svc.ErrInvalidQueryParameters)
+	}
+
+	return wishlistID, rentalID, nil
+}
+
+func toWishlistContract(wishlist *model.Wishlist) *contract.Wishlist {
+	return &contract.Wishlist{
+		ID:          wishlist.ID,
+		UserID:      wishlist.UserID,
+		Name:        wishlist.Name,
+		RentalCount: wishlist.RentalCount,
+		Created:     wishlist.Created,
+	}
+}
@@ -0,0 +1,153 @@
+package handler_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-chi/chi"
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/dragonator/rental-service/module/rental/internal/http/handler"
+	"github.com/dragonator/rental-service/module/rental/internal/http/service/svc"
+	"github.com/dragonator/rental-service/module/rental/internal/model"
+)
+
+func TestWishlistHandler_CreateWishlist(t *testing.T) {
+	testCases := []struct {
+		name             string
+		body             string
+		createErr        error
+		expectedCalls    int
+		expectedCode     int
+		expectedLocation string
+	}{
+		{
+			name:             "Valid wishlist",
+			body:             `{"name":"Summer trips"}`,
+			expectedCalls:    1,
+			expectedCode:     http.StatusCreated,
+			expectedLocation: "/wishlists/2",
+		},
+		{
+			name:          "Unknown field",
+			body:          `{"name":"Summer trips","rentals":[1]}`,
+			expectedCalls: 0,
+			expectedCode:  http.StatusBadRequest,
+		},
+		{
+			name:          "Missing user",
+			body:          `{"name":"Summer trips"}`,
+			createErr:     svc.ErrNotFound,
+			expectedCalls: 1,
+			expectedCode:  http.StatusNotFound,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockWishlistManagingOp := &WishlistManagingOpMock{
+				CreateWishlistFunc: func(ctx context.Context, wishlist *model.Wishlist) (*model.Wishlist, error) {
+					if tc.createErr != nil {
+						return nil, tc.createErr
+					}
+
+					return &model.Wishlist{ID: 2, UserID: wishlist.UserID, Name: wishlist.Name}, nil
+				},
+			}
+
+			wishlistHandler := handler.NewWishlistHandler(mockWishlistManagingOp)
+
+			router := chi.NewRouter()
+			router.Post("/users/{id}/wishlists", wishlistHandler.CreateWishlist("POST", "/users/{id}/wishlists"))
+
+			request := httptest.NewRequest("POST", "/users/1/wishlists", strings.NewReader(tc.body))
+			responseRecorder := httptest.NewRecorder()
+
+			router.ServeHTTP(responseRecorder, request)
+
+			calls := mockWishlistManagingOp.CreateWishlistCalls()
+			if len(calls) != tc.expectedCalls {
+				t.Fatalf("Unexpected number of calls to CreateWishlist:\nexpected: %d\ngot      %d", tc.expectedCalls, len(calls))
+			}
+
+			expectedWishlist := &model.Wishlist{UserID: 1, Name: "Summer trips"}
+			if tc.expectedCalls == 1 && !cmp.Equal(calls[0].Wishlist, expectedWishlist) {
+				t.Fatalf("Unexpected wishlist:\nexpected: %v\ngot:      %v", expectedWishlist, calls[0].Wishlist)
+			}
+
+			if responseRecorder.Code != tc.expectedCode {
+				t.Fatalf("Unexpected status code:\nexpected %d\ngot:      %d", tc.expectedCode, responseRecorder.Code)
+			}
+
+			if location := responseRecorder.Header().Get("Location"); location != tc.expectedLocation {
+				t.Fatalf("Unexpected location:\nexpected: %s\ngot:      %s", tc.expectedLocation, location)
+			}
+		})
+	}
+}
+
+func TestWishlistHandler_AddRental(t *testing.T) {
+	testCases := []struct {
+		name          string
+		path          string
+		addErr        error
+		expectedCalls int
+		expectedCode  int
+	}{
+		{
+			name:          "Existing rental",
+			path:          "/wishlists/2/rentals/5",
+			expectedCalls: 1,
+			expectedCode:  http.StatusNoContent,
+		},
+		{
+			name:          "Invalid rental id",
+			path:          "/wishlists/2/rentals/abc",
+			expectedCalls: 0,
+			expectedCode:  http.StatusBadRequest,
+		},
+		{
+			name:          "Missing rental",
+			path:          "/wishlists/2/rentals/5",
+			addErr:        svc.ErrNotFound,
+			expectedCalls: 1,
+			expectedCode:  http.StatusNotFound,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockWishlistManagingOp := &WishlistManagingOpMock{
+				AddRentalFunc: func(ctx context.Context, wishlistID, rentalID int) error {
+					return tc.addErr
+				},
+			}
+
+			wishlistHandler := handler.NewWishlistHandler(mockWishlistManagingOp)
+
+			router := chi.NewRouter()
+			router.Put("/wishlists/{id}/rentals/{rental_id}", wishlistHandler.AddRental("PUT", "/wishlists/{id}/rentals/{rental_id}"))
+
+			request := httptest.NewRequest("PUT", tc.path, nil)
+			responseRecorder := httptest.NewRecorder()
+
+			router.ServeHTTP(responseRecorder, request)
+
+			calls := mockWishlistManagingOp.AddRentalCalls()
+			if len(calls) != tc.expectedCalls {
+				t.Fatalf("Unexpected number of calls to AddRental:\nexpected: %d\ngot      %d", tc.expectedCalls, len(calls))
+			}
+
+			if tc.expectedCalls == 1 && (calls[0].WishlistID != 2 || calls[0].RentalID != 5) {
+				t.Fatalf("Unexpected arguments:\nexpected: 2 5\ngot:      %d %d", calls[0].WishlistID, calls[0].RentalID)
+			}
+
+			if responseRecorder.Code != tc.expectedCode {
+				t.Fatalf("Unexpected status code:\nexpected %d\ngot:      %d", tc.expectedCode, responseRecorder.Code)
+			}
+		})
+	}
+}
@@ -0,0 +1,24 @@
+package service
+
+import (
+	"crypto/subtle"
+	"net/http"
+
+	"github.com/dragonator/rental-service/module/rental/internal/http/service/svc"
+)
+
+const _adminTokenHeaderName = "X-Admin-Token"
+
+// adminAuthenticator marks requests carrying the configured admin token as made by an administrator.
+func adminAuthenticator(adminToken string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			token := r.Header.Get(_adminTokenHeaderName)
+			if adminToken != "" && subtle.ConstantTimeCompare([]byte(token), []byte(adminToken)) == 1 {
+				r = r.WithContext(svc.WithAdmin(r.Context()))
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
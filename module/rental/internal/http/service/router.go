@@ -4,17 +4,121 @@ import (
 	"net/http"
 
 	"github.com/go-chi/chi"
+
+	"github.com/dragonator/rental-service/pkg/config"
 )
 
 // RentalHandler is a contract to a rental handler.
 type RentalHandler interface {
 	GetRentalByID(method, path string) func(w http.ResponseWriter, r *http.Request)
 	ListRentals(method, path string) func(w http.ResponseWriter, r *http.Request)
+	CreateRental(method, path string) func(w http.ResponseWriter, r *http.Request)
+	UpdateRental(method, path string) func(w http.ResponseWriter, r *http.Request)
+	PatchRental(method, path string) func(w http.ResponseWriter, r *http.Request)
+	DeleteRental(method, path string) func(w http.ResponseWriter, r *http.Request)
+	RestoreRental(method, path string) func(w http.ResponseWriter, r *http.Request)
+}
+
+// UserHandler is a contract to a user handler.
+type UserHandler interface {
+	GetUserByID(method, path string) func(w http.ResponseWriter, r *http.Request)
+	ListUsers(method, path string) func(w http.ResponseWriter, r *http.Request)
+	CreateUser(method, path string) func(w http.ResponseWriter, r *http.Request)
+	PatchUser(method, path string) func(w http.ResponseWriter, r *http.Request)
+	DeleteUser(method, path string) func(w http.ResponseWriter, r *http.Request)
+	ListUserRentals(method, path string) func(w http.ResponseWriter, r *http.Request)
+}
+
+// BookingHandler is a contract to a booking handler.
+type BookingHandler interface {
+	CreateBooking(method, path string) func(w http.ResponseWriter, r *http.Request)
+	GetBookingByID(method, path string) func(w http.ResponseWriter, r *http.Request)
+	CancelBooking(method, path string) func(w http.ResponseWriter, r *http.Request)
+	GetBookingPayment(method, path string) func(w http.ResponseWriter, r *http.Request)
+}
+
+// CalendarHandler is a contract to a rental calendar handler.
+type CalendarHandler interface {
+	GetCalendar(method, path string) func(w http.ResponseWriter, r *http.Request)
+	UpdateCalendar(method, path string) func(w http.ResponseWriter, r *http.Request)
+}
+
+// QuoteHandler is a contract to a price quote handler.
+type QuoteHandler interface {
+	QuoteRental(method, path string) func(w http.ResponseWriter, r *http.Request)
+}
+
+// PricingRuleHandler is a contract to a rental pricing rule handler.
+type PricingRuleHandler interface {
+	ListPricingRules(method, path string) func(w http.ResponseWriter, r *http.Request)
+	CreatePricingRule(method, path string) func(w http.ResponseWriter, r *http.Request)
+	UpdatePricingRule(method, path string) func(w http.ResponseWriter, r *http.Request)
+	DeletePricingRule(method, path string) func(w http.ResponseWriter, r *http.Request)
+}
+
+// ReviewHandler is a contract to a review handler.
+type ReviewHandler interface {
+	CreateReview(method, path string) func(w http.ResponseWriter, r *http.Request)
+	GetReviewByID(method, path string) func(w http.ResponseWriter, r *http.Request)
+	ListReviews(method, path string) func(w http.ResponseWriter, r *http.Request)
+}
+
+// ImageHandler is a contract to a rental image handler.
+type ImageHandler interface {
+	ListImages(method, path string) func(w http.ResponseWriter, r *http.Request)
+	GetImage(method, path string) func(w http.ResponseWriter, r *http.Request)
+	AddImage(method, path string) func(w http.ResponseWriter, r *http.Request)
+	ReorderImages(method, path string) func(w http.ResponseWriter, r *http.Request)
+	RemoveImage(method, path string) func(w http.ResponseWriter, r *http.Request)
+}
+
+// WishlistHandler is a contract to a wishlist handler.
+type WishlistHandler interface {
+	CreateWishlist(method, path string) func(w http.ResponseWriter, r *http.Request)
+	ListWishlists(method, path string) func(w http.ResponseWriter, r *http.Request)
+	GetWishlistByID(method, path string) func(w http.ResponseWriter, r *http.Request)
+	DeleteWishlist(method, path string) func(w http.ResponseWriter, r *http.Request)
+	AddRental(method, path string) func(w http.ResponseWriter, r *http.Request)
+	RemoveRental(method, path string) func(w http.ResponseWriter, r *http.Request)
+}
+
+// ConversationHandler is a contract to a conversation handler.
+type ConversationHandler interface {
+	StartConversation(method, path string) func(w http.ResponseWriter, r *http.Request)
+	GetConversationByID(method, path string) func(w http.ResponseWriter, r *http.Request)
+	ListConversations(method, path string) func(w http.ResponseWriter, r *http.Request)
+	CountUnread(method, path string) func(w http.ResponseWriter, r *http.Request)
+	ListMessages(method, path string) func(w http.ResponseWriter, r *http.Request)
+	GetMessage(method, path string) func(w http.ResponseWriter, r *http.Request)
+	PostMessage(method, path string) func(w http.ResponseWriter, r *http.Request)
+	MarkRead(method, path string) func(w http.ResponseWriter, r *http.Request)
+}
+
+// AmenityHandler is a contract to an amenity handler.
+type AmenityHandler interface {
+	ListAmenities(method, path string) func(w http.ResponseWriter, r *http.Request)
+	SetRentalAmenities(method, path string) func(w http.ResponseWriter, r *http.Request)
 }
 
-// NewRouter is a construction function for router that handles operations for rentals.
-func NewRouter(rh RentalHandler) http.Handler {
+// Handlers groups the handlers served by the router.
+type Handlers struct {
+	Rental       RentalHandler
+	User         UserHandler
+	Booking      BookingHandler
+	Calendar     CalendarHandler
+	Quote        QuoteHandler
+	PricingRule  PricingRuleHandler
+	Review       ReviewHandler
+	Image        ImageHandler
+	Wishlist     WishlistHandler
+	Conversation ConversationHandler
+	Amenity      AmenityHandler
+}
+
+// NewRouter is a construction function for router that handles operations for rentals, users and bookings.
+func NewRouter(config *config.Config, h *Handlers) http.Handler {
 	router := chi.NewRouter()
+	router.Use(adminAuthenticator(config.AdminToken))
 
 	api := []struct {
 		MethodFunc func(pattern string, handlerFn http.HandlerFunc)
@@ -22,13 +126,61 @@ func NewRouter(rh RentalHandler) http.Handler {
 		Path       string
 		HandleFunc func(string, string) func(w http.ResponseWriter, r *http.Request)
 	}{
-		{router.Get, "GET", "/rentals/{id}", rh.GetRentalByID},
-		{router.Get, "GET", "/rentals", rh.ListRentals},
+		{router.Get, "GET", "/rentals/{id}", h.Rental.GetRentalByID},
+		{router.Get, "GET", "/rentals", h.Rental.ListRentals},
+		{router.Post, "POST", "/rentals", h.Rental.CreateRental},
+		{router.Put, "PUT", "/rentals/{id}", h.Rental.UpdateRental},
+		{router.Patch, "PATCH", "/rentals/{id}", h.Rental.PatchRental},
+		{router.Delete, "DELETE", "/rentals/{id}", h.Rental.DeleteRental},
+		{router.Post, "POST", "/rentals/{id}:restore", h.Rental.RestoreRental},
+		{router.Get, "GET", "/users/{id}", h.User.GetUserByID},
+		{router.Get, "GET", "/users", h.User.ListUsers},
+		{router.Post, "POST", "/users", h.User.CreateUser},
+		{router.Patch, "PATCH", "/users/{id}", h.User.PatchUser},
+		{router.Delete, "DELETE", "/users/{id}", h.User.DeleteUser},
+		{router.Get, "GET", "/users/{id}/rentals", h.User.ListUserRentals},
+		{router.Post, "POST", "/rentals/{id}/bookings", h.Booking.CreateBooking},
+		{router.Get, "GET", "/bookings/{id}", h.Booking.GetBookingByID},
+		{router.Post, "POST", "/bookings/{id}:cancel", h.Booking.CancelBooking},
+		{router.Get, "GET", "/bookings/{id}/payment", h.Booking.GetBookingPayment},
+		{router.Get, "GET", "/rentals/{id}/calendar", h.Calendar.GetCalendar},
+		{router.Put, "PUT", "/rentals/{id}/calendar", h.Calendar.UpdateCalendar},
+		{router.Get, "GET", "/rentals/{id}/quote", h.Quote.QuoteRental},
+		{router.Get, "GET", "/rentals/{id}/pricing-rules", h.PricingRule.ListPricingRules},
+		{router.Post, "POST", "/rentals/{id}/pricing-rules", h.PricingRule.CreatePricingRule},
+		{router.Put, "PUT", "/rentals/{id}/pricing-rules/{rule_id}", h.PricingRule.UpdatePricingRule},
+		{router.Delete, "DELETE", "/rentals/{id}/pricing-rules/{rule_id}", h.PricingRule.DeletePricingRule},
+		{router.Post, "POST", "/rentals/{id}/reviews", h.Review.CreateReview},
+		{router.Get, "GET", "/rentals/{id}/reviews", h.Review.ListReviews},
+		{router.Get, "GET", "/reviews/{id}", h.Review.GetReviewByID},
+		{router.Get, "GET", "/rentals/{id}/images", h.Image.ListImages},
+		{router.Post, "POST", "/rentals/{id}/images", h.Image.AddImage},
+		{router.Post, "POST", "/rentals/{id}/images:reorder", h.Image.ReorderImages},
+		{router.Get, "GET", "/rentals/{id}/images/{image_id}", h.Image.GetImage},
+		{router.Delete, "DELETE", "/rentals/{id}/images/{image_id}", h.Image.RemoveImage},
+		{router.Post, "POST", "/users/{id}/wishlists", h.Wishlist.CreateWishlist},
+		{router.Get, "GET", "/users/{id}/wishlists", h.Wishlist.ListWishlists},
+		{router.Get, "GET", "/wishlists/{id}", h.Wishlist.GetWishlistByID},
+		{router.Delete, "DELETE", "/wishlists/{id}", h.Wishlist.DeleteWishlist},
+		{router.Put, "PUT", "/wishlists/{id}/rentals/{rental_id}", h.Wishlist.AddRental},
+		{router.Delete, "DELETE", "/wishlists/{id}/rentals/{rental_id}", h.Wishlist.RemoveRental},
+		{router.Post, "POST", "/rentals/{id}/conversations", h.Conversation.StartConversation},
+		{router.Get, "GET", "/users/{id}/conversations", h.Conversation.ListConversations},
+		{router.Get, "GET", "/users/{id}/unread-count", h.Conversation.CountUnread},
+		{router.Get, "GET", "/conversations/{id}", h.Conversation.GetConversationByID},
+		{router.Post, "POST", "/conversations/{id}:read", h.Conversation.MarkRead},
+		{router.Get, "GET", "/conversations/{id}/messages", h.Conversation.ListMessages},
+		{router.Post, "POST", "/conversations/{id}/messages", h.Conversation.PostMessage},
+		{router.Get, "GET", "/conversations/{id}/messages/{message_id}", h.Conversation.GetMessage},
+		{router.Get, "GET", "/amenities", h.Amenity.ListAmenities},
+		{router.Put, "PUT", "/rentals/{id}/amenities", h.Amenity.SetRentalAmenities},
 	}
 
 	for _, endpoint := range api {
 		endpoint.MethodFunc(endpoint.Path, endpoint.HandleFunc(endpoint.Method, endpoint.Path))
 	}
 
+	router.Get(_uploadsPath+"*", http.StripPrefix(_uploadsPath, staticFiles(config.Uploads.Dir)).ServeHTTP)
+
 	return router
 }
@@ -0,0 +1,23 @@
+package service
+
+import (
+	"net/http"
+	"strings"
+)
+
+// _uploadsPath is the path under which uploaded files are served.
+const _uploadsPath = "/uploads/"
+
+// staticFiles serves the files under dir without listing the content of its directories.
+func staticFiles(dir string) http.Handler {
+	fileServer := http.FileServer(http.Dir(dir))
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "" || strings.HasSuffix(r.URL.Path, "/") {
+			http.NotFound(w, r)
+			return
+		}
+
+		fileServer.ServeHTTP(w, r)
+	})
+}
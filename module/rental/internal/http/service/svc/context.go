@@ -0,0 +1,18 @@
+package svc
+
+import "context"
+
+type contextKey int
+
+const _adminContextKey contextKey = iota
+
+// WithAdmin returns a copy of ctx marking the request as made by an administrator.
+func WithAdmin(ctx context.Context) context.Context {
+	return context.WithValue(ctx, _adminContextKey, true)
+}
+
+// IsAdmin reports whether the request was made by an administrator.
+func IsAdmin(ctx context.Context) bool {
+	admin, _ := ctx.Value(_adminContextKey).(bool)
+	return admin
+}
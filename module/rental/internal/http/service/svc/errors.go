@@ -6,6 +6,13 @@ import "net/http"
 var (
 	ErrNotFound               = &Error{StatusCode: http.StatusNotFound, Message: "not found"}
 	ErrInvalidQueryParameters = &Error{StatusCode: http.StatusBadRequest, Message: "invalid query parameters"}
+	ErrInvalidRequestBody     = &Error{StatusCode: http.StatusBadRequest, Message: "invalid request body"}
+	ErrPreconditionFailed     = &Error{StatusCode: http.StatusPreconditionFailed, Message: "precondition failed"}
+	ErrForbidden              = &Error{StatusCode: http.StatusForbidden, Message: "forbidden"}
+	ErrConflict               = &Error{StatusCode: http.StatusConflict, Message: "conflict"}
+	ErrPaymentRequired        = &Error{StatusCode: http.StatusPaymentRequired, Message: "payment required"}
+	ErrPayloadTooLarge        = &Error{StatusCode: http.StatusRequestEntityTooLarge, Message: "payload too large"}
+	ErrUnsupportedMediaType   = &Error{StatusCode: http.StatusUnsupportedMediaType, Message: "unsupported media type"}
 )
 
 // Error represets a server error.
@@ -0,0 +1,184 @@
+// Package imaging contains the image processing applied to uploaded rental images.
+package imaging
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"image/png"
+	"io"
+
+	// Registers the GIF decoder used by image.Decode.
+	_ "image/gif"
+)
+
+const _jpegQuality = 85
+
+// MaxPixels is the number of pixels of the largest image Decode accepts. Images are decoded into
+// memory whole, so their size is bounded by pixels rather than by the bytes they are encoded in.
+const MaxPixels = 25 * 1000 * 1000
+
+// Errors returned by Decode.
+var (
+	// ErrTooManyPixels is returned for images of more than MaxPixels pixels.
+	ErrTooManyPixels = errors.New("image has too many pixels")
+	// ErrEmptyImage is returned for images without pixels.
+	ErrEmptyImage = errors.New("image has no pixels")
+)
+
+// Variant is a named width to which thumbnails of uploaded images are scaled down.
+type Variant struct {
+	Name  string
+	Width int
+}
+
+// Variants lists the thumbnails produced for every uploaded image.
+var Variants = []Variant{
+	{Name: "small", Width: 320},
+	{Name: "medium", Width: 960},
+}
+
+// Decode decodes a JPEG, PNG or GIF image and returns it along with its format name.
+// The dimensions of the image are checked to be positive and within MaxPixels before its pixels are decoded.
+func Decode(r io.Reader) (image.Image, string, error) {
+	var header bytes.Buffer
+
+	config, _, err := image.DecodeConfig(io.TeeReader(r, &header))
+	if err != nil {
+		return nil, "", fmt.Errorf("decoding image: %w", err)
+	}
+
+	if config.Width <= 0 || config.Height <= 0 {
+		return nil, "", fmt.Errorf("decoding image of %dx%d pixels: %w", config.Width, config.Height, ErrEmptyImage)
+	}
+
+	if int64(config.Width)*int64(config.Height) > MaxPixels {
+		return nil, "", fmt.Errorf("decoding image of %dx%d pixels: %w, expected at most %d",
+			config.Width, config.Height, ErrTooManyPixels, MaxPixels)
+	}
+
+	img, format, err := image.Decode(io.MultiReader(&header, r))
+	if err != nil {
+		return nil, "", fmt.Errorf("decoding image: %w", err)
+	}
+
+	return img, format, nil
+}
+
+// Encode writes img to w as a JPEG when format is "jpeg" and as a PNG otherwise,
+// so that transparency of PNG and GIF images is kept.
+func Encode(w io.Writer, img image.Image, format string) error {
+	var err error
+	if format == "jpeg" {
+		err = jpeg.Encode(w, img, &jpeg.Options{Quality: _jpegQuality})
+	} else {
+		err = png.Encode(w, img)
+	}
+
+	if err != nil {
+		return fmt.Errorf("encoding image: %w", err)
+	}
+
+	return nil
+}
+
+// Extension returns the file extension of images written by Encode in the given format.
+func Extension(format string) string {
+	if format == "jpeg" {
+		return ".jpg"
+	}
+
+	return ".png"
+}
+
+// Thumbnail scales src down to the given width keeping its aspect ratio. Each pixel of the
+// thumbnail is the average of the pixels of src it covers. Images which are not wider than
+// width keep their size, and images without pixels result in an empty thumbnail.
+func Thumbnail(src image.Image, width int) image.Image {
+	bounds := src.Bounds()
+	if bounds.Empty() || width <= 0 {
+		return image.NewRGBA64(image.Rectangle{})
+	}
+
+	if bounds.Dx() < width {
+		width = bounds.Dx()
+	}
+
+	height := bounds.Dy() * width / bounds.Dx()
+	if height < 1 {
+		height = 1
+	}
+
+	dst := image.NewRGBA64(image.Rect(0, 0, width, height))
+	rgba := pixelReader(src)
+
+	for y := 0; y < height; y++ {
+		y0, y1 := span(bounds.Min.Y, bounds.Dy(), height, y)
+
+		for x := 0; x < width; x++ {
+			x0, x1 := span(bounds.Min.X, bounds.Dx(), width, x)
+
+			var r, g, b, a, n uint64
+
+			for sy := y0; sy < y1; sy++ {
+				for sx := x0; sx < x1; sx++ {
+					sr, sg, sb, sa := rgba(sx, sy)
+					r, g, b, a = r+uint64(sr), g+uint64(sg), b+uint64(sb), a+uint64(sa)
+					n++
+				}
+			}
+
+			dst.SetRGBA64(x, y, color.RGBA64{R: uint16(r / n), G: uint16(g / n), B: uint16(b / n), A: uint16(a / n)})
+		}
+	}
+
+	return dst
+}
+
+// pixelReader returns a function reading the alpha-premultiplied color of a pixel of src. The
+// image types produced by the decoders are read through their concrete accessors, which unlike
+// At do not allocate a color for every pixel.
+func pixelReader(src image.Image) func(x, y int) (r, g, b, a uint32) {
+	switch img := src.(type) {
+	case *image.YCbCr:
+		return func(x, y int) (uint32, uint32, uint32, uint32) { return img.YCbCrAt(x, y).RGBA() }
+	case *image.RGBA:
+		return func(x, y int) (uint32, uint32, uint32, uint32) { return img.RGBAAt(x, y).RGBA() }
+	case *image.NRGBA:
+		return func(x, y int) (uint32, uint32, uint32, uint32) { return img.NRGBAAt(x, y).RGBA() }
+	case *image.Gray:
+		return func(x, y int) (uint32, uint32, uint32, uint32) { return img.GrayAt(x, y).RGBA() }
+	case *image.Paletted:
+		palette := make([][4]uint32, len(img.Palette))
+		for i, c := range img.Palette {
+			r, g, b, a := c.RGBA()
+			palette[i] = [4]uint32{r, g, b, a}
+		}
+
+		return func(x, y int) (uint32, uint32, uint32, uint32) {
+			i := int(img.ColorIndexAt(x, y))
+			if i >= len(palette) {
+				return 0, 0, 0, 0
+			}
+
+			return palette[i][0], palette[i][1], palette[i][2], palette[i][3]
+		}
+	}
+
+	return func(x, y int) (uint32, uint32, uint32, uint32) { return src.At(x, y).RGBA() }
+}
+
+// span returns the range of source coordinates covered by the i-th of n destination pixels.
+func span(origin, size, n, i int) (int, int) {
+	start := origin + i*size/n
+	end := origin + (i+1)*size/n
+
+	if end <= start {
+		end = start + 1
+	}
+
+	return start, end
+}
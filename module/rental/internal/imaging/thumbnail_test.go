@@ -0,0 +1,127 @@
+package imaging_test
+
+import (
+	"bytes"
+	"errors"
+	"image"
+	"image/color"
+	"image/color/palette"
+	"testing"
+
+	"github.com/dragonator/rental-service/module/rental/internal/imaging"
+)
+
+func TestThumbnail(t *testing.T) {
+	testCases := []struct {
+		name           string
+		srcWidth       int
+		srcHeight      int
+		width          int
+		expectedBounds image.Rectangle
+		expectedColor  color.RGBA64
+	}{
+		{
+			name:           "Wide image",
+			srcWidth:       100,
+			srcHeight:      50,
+			width:          40,
+			expectedBounds: image.Rect(0, 0, 40, 20),
+			expectedColor:  color.RGBA64{R: 0x7fff, G: 0x7fff, B: 0x7fff, A: 0xffff},
+		},
+		{
+			name:           "Narrow image",
+			srcWidth:       20,
+			srcHeight:      30,
+			width:          40,
+			expectedBounds: image.Rect(0, 0, 20, 30),
+			expectedColor:  color.RGBA64{A: 0xffff},
+		},
+		{
+			name:           "Empty image",
+			srcWidth:       0,
+			srcHeight:      0,
+			width:          40,
+			expectedBounds: image.Rectangle{},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			bounds := image.Rect(0, 0, tc.srcWidth, tc.srcHeight)
+			sources := map[string]interface {
+				image.Image
+				Set(x, y int, c color.Color)
+			}{
+				"rgba":     image.NewRGBA(bounds),
+				"nrgba":    image.NewNRGBA(bounds),
+				"gray":     image.NewGray(bounds),
+				"paletted": image.NewPaletted(bounds, palette.Plan9),
+				"rgba64":   image.NewRGBA64(bounds),
+			}
+
+			for kind, src := range sources {
+				// Alternating black and white columns average out to grey when scaled down.
+				for y := 0; y < tc.srcHeight; y++ {
+					for x := 0; x < tc.srcWidth; x++ {
+						if x%2 == 1 {
+							src.Set(x, y, color.White)
+						} else {
+							src.Set(x, y, color.Black)
+						}
+					}
+				}
+
+				thumbnail := imaging.Thumbnail(src, tc.width)
+
+				if thumbnail.Bounds() != tc.expectedBounds {
+					t.Fatalf("Unexpected bounds of %s:\nexpected: %v\ngot:      %v", kind, tc.expectedBounds, thumbnail.Bounds())
+				}
+
+				if got := color.RGBA64Model.Convert(thumbnail.At(0, 0)); got != tc.expectedColor {
+					t.Fatalf("Unexpected color of %s:\nexpected: %v\ngot:      %v", kind, tc.expectedColor, got)
+				}
+			}
+		})
+	}
+}
+
+func TestEncodeDecode(t *testing.T) {
+	for _, format := range []string{"jpeg", "png"} {
+		var buf bytes.Buffer
+
+		if err := imaging.Encode(&buf, image.NewRGBA(image.Rect(0, 0, 4, 2)), format); err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+
+		img, decodedFormat, err := imaging.Decode(&buf)
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+
+		if decodedFormat != format || img.Bounds() != image.Rect(0, 0, 4, 2) {
+			t.Fatalf("Unexpected image: %s %v", decodedFormat, img.Bounds())
+		}
+
+		if thumbnail := imaging.Thumbnail(img, 2); thumbnail.Bounds() != image.Rect(0, 0, 2, 1) {
+			t.Fatalf("Unexpected thumbnail of %s image: %v", format, thumbnail.Bounds())
+		}
+	}
+}
+
+func TestDecodeTooManyPixels(t *testing.T) {
+	// A GIF header declaring a 65535x65535 image without any pixel data.
+	header := []byte("GIF89a\xff\xff\xff\xff\x00\x00\x00")
+
+	if _, _, err := imaging.Decode(bytes.NewReader(header)); !errors.Is(err, imaging.ErrTooManyPixels) {
+		t.Fatalf("Unexpected error:\nexpected: %v\ngot:      %v", imaging.ErrTooManyPixels, err)
+	}
+}
+
+func TestDecodeEmptyImage(t *testing.T) {
+	// A GIF header declaring a 0x0 image.
+	header := []byte("GIF89a\x00\x00\x00\x00\x00\x00\x00;")
+
+	if _, _, err := imaging.Decode(bytes.NewReader(header)); !errors.Is(err, imaging.ErrEmptyImage) {
+		t.Fatalf("Unexpected error:\nexpected: %v\ngot:      %v", imaging.ErrEmptyImage, err)
+	}
+}
@@ -0,0 +1,12 @@
+package model
+
+// Amenity is a model for an entry of the amenities catalog, e.g. a shower or solar panels.
+// Rentals and filters refer to amenities by Slug.
+type Amenity struct {
+	ID   int32
+	Slug string
+	Name string
+}
+
+// Amenities is a slice of Amenity objects.
+type Amenities []*Amenity
@@ -0,0 +1,65 @@
+package model
+
+import (
+	"errors"
+	"time"
+)
+
+// BookingStatus is the state of a booking.
+type BookingStatus string
+
+// Supported booking statuses.
+const (
+	BookingStatusConfirmed BookingStatus = "confirmed"
+	BookingStatusCancelled BookingStatus = "cancelled"
+)
+
+// Booking is a model for the booking entity. A booking reserves a rental for the
+// nights from StartDate up to, but not including, EndDate. CancellationPolicy is the
+// policy of the rental at the time of booking.
+type Booking struct {
+	ID                 int32
+	RentalID           int32
+	UserID             int32
+	StartDate          time.Time
+	EndDate            time.Time
+	Status             BookingStatus
+	CancellationPolicy CancellationPolicy
+	Created            time.Time
+	Updated            time.Time
+	CancelledAt        *time.Time
+}
+
+// Validate checks whether the booking holds all required fields with sensible values.
+// Bookings cannot start before the current day in UTC.
+func (b *Booking) Validate() error {
+	switch {
+	case b.RentalID <= 0:
+		return errors.New("rental id is required")
+	case b.UserID <= 0:
+		return errors.New("user id is required")
+	case b.StartDate.IsZero():
+		return errors.New("start date is required")
+	case b.EndDate.IsZero():
+		return errors.New("end date is required")
+	case !b.EndDate.After(b.StartDate):
+		return errors.New("end date must be after start date")
+	case b.StartDate.Before(time.Now().UTC().Truncate(24 * time.Hour)):
+		return errors.New("start date must not be in the past")
+	}
+
+	return nil
+}
+
+// Nights returns the number of nights covered by the booking.
+func (b *Booking) Nights() int {
+	return int(b.EndDate.Sub(b.StartDate).Hours() / 24)
+}
+
+// Completed reports whether the booking was not cancelled and its rental was returned by the given time.
+func (b *Booking) Completed(now time.Time) bool {
+	return b.Status == BookingStatusConfirmed && !now.Before(b.EndDate)
+}
+
+// Bookings is a slice of Booking objects.
+type Bookings []*Booking
@@ -0,0 +1,97 @@
+package model
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// DayStatus is the availability of a rental on a single day.
+type DayStatus string
+
+// Supported day statuses.
+const (
+	DayStatusAvailable DayStatus = "available"
+	DayStatusBlocked   DayStatus = "blocked"
+	DayStatusBooked    DayStatus = "booked"
+)
+
+// StayRules limits the number of nights a rental can be booked for. A nil MaxNights means no upper limit.
+type StayRules struct {
+	MinNights int
+	MaxNights *int
+}
+
+// DefaultStayRules returns the rules of a rental whose owner has not set any.
+func DefaultStayRules() *StayRules {
+	return &StayRules{MinNights: 1}
+}
+
+// Validate checks whether the rules are consistent.
+func (sr *StayRules) Validate() error {
+	switch {
+	case sr.MinNights < 1:
+		return errors.New("min nights must be at least 1")
+	case sr.MaxNights != nil && *sr.MaxNights < sr.MinNights:
+		return errors.New("max nights must not be less than min nights")
+	}
+
+	return nil
+}
+
+// Allows checks whether a stay of the given number of nights follows the rules.
+func (sr *StayRules) Allows(nights int) error {
+	switch {
+	case nights < sr.MinNights:
+		return fmt.Errorf("the rental can be booked for at least %d nights", sr.MinNights)
+	case sr.MaxNights != nil && nights > *sr.MaxNights:
+		return fmt.Errorf("the rental can be booked for at most %d nights", *sr.MaxNights)
+	}
+
+	return nil
+}
+
+// CalendarDay is the status of a rental on a single day.
+type CalendarDay struct {
+	Date   time.Time
+	Status DayStatus
+}
+
+// Calendar is a model for the availability of a rental over a period of days.
+type Calendar struct {
+	RentalID  int32
+	StayRules StayRules
+	Days      []CalendarDay
+}
+
+// NewCalendar builds the calendar of a rental for the days from start up to, but not including, end.
+// A day is booked when a booking covers its night, which takes precedence over the day being blocked.
+func NewCalendar(rentalID int32, rules *StayRules, start, end time.Time, blockedDays []time.Time, bookings Bookings) *Calendar {
+	blocked := make(map[string]bool, len(blockedDays))
+	for _, day := range blockedDays {
+		blocked[day.Format(time.DateOnly)] = true
+	}
+
+	calendar := &Calendar{
+		RentalID:  rentalID,
+		StayRules: *rules,
+	}
+
+	for day := start; day.Before(end); day = day.AddDate(0, 0, 1) {
+		status := DayStatusAvailable
+		if blocked[day.Format(time.DateOnly)] {
+			status = DayStatusBlocked
+		}
+
+		for _, b := range bookings {
+			if !day.Before(b.StartDate) && day.Before(b.EndDate) {
+				status = DayStatusBooked
+				break
+			}
+		}
+
+		calendar.Days = append(calendar.Days, CalendarDay{Date: day, Status: status})
+	}
+
+	return calendar
+}
@@ -0,0 +1,24 @@
+package model
+
+// CancellationPolicy decides the refund of a booking of a rental when it is cancelled.
+type CancellationPolicy string
+
+// Supported cancellation policies.
+const (
+	CancellationPolicyFlexible CancellationPolicy = "flexible"
+	CancellationPolicyModerate CancellationPolicy = "moderate"
+	CancellationPolicyStrict   CancellationPolicy = "strict"
+)
+
+// DefaultCancellationPolicy is the cancellation policy of rentals which do not declare one.
+const DefaultCancellationPolicy = CancellationPolicyModerate
+
+// Valid reports whether the cancellation policy is one of the supported policies.
+func (p CancellationPolicy) Valid() bool {
+	switch p {
+	case CancellationPolicyFlexible, CancellationPolicyModerate, CancellationPolicyStrict:
+		return true
+	}
+
+	return false
+}
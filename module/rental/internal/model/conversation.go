@@ -0,0 +1,59 @@
+package model
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// MessageBodyMaxLength is the maximum number of characters in the body of a message.
+const MessageBodyMaxLength = 5000
+
+// Conversation is a model for a message thread between a renter and the owner of a rental.
+// A renter has a single conversation per rental. UnreadCount is the number of messages
+// not yet read by the user the conversation was loaded for.
+type Conversation struct {
+	ID            int32
+	RentalID      int32
+	RenterID      int32
+	OwnerID       int32
+	UnreadCount   int32
+	LastMessageAt time.Time
+	Created       time.Time
+}
+
+// HasParticipant reports whether the user takes part in the conversation.
+func (c *Conversation) HasParticipant(userID int32) bool {
+	return userID == c.RenterID || userID == c.OwnerID
+}
+
+// Conversations is a slice of Conversation objects.
+type Conversations []*Conversation
+
+// Message is a model for a message posted to a conversation.
+type Message struct {
+	ID             int32
+	ConversationID int32
+	SenderID       int32
+	Body           string
+	Created        time.Time
+}
+
+// Validate checks whether the message holds all required fields with sensible values.
+func (m *Message) Validate() error {
+	switch {
+	case m.SenderID <= 0:
+		return errors.New("sender id is required")
+	case strings.TrimSpace(m.Body) == "":
+		return errors.New("body is required")
+	case utf8.RuneCountInString(m.Body) > MessageBodyMaxLength:
+		return fmt.Errorf("body must not be longer than %d characters", MessageBodyMaxLength)
+	}
+
+	return nil
+}
+
+// Messages is a slice of Message objects.
+type Messages []*Message
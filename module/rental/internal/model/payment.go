@@ -0,0 +1,64 @@
+package model
+
+import "time"
+
+// PaymentStatus is the state of a payment intent.
+type PaymentStatus string
+
+// Supported payment statuses.
+const (
+	PaymentStatusPending    PaymentStatus = "pending"
+	PaymentStatusAuthorized PaymentStatus = "authorized"
+	PaymentStatusCaptured   PaymentStatus = "captured"
+	PaymentStatusRefunding  PaymentStatus = "refunding"
+	PaymentStatusRefunded   PaymentStatus = "refunded"
+	PaymentStatusFailed     PaymentStatus = "failed"
+)
+
+// paymentTransitions lists the statuses a payment intent can move to from each status.
+var paymentTransitions = map[PaymentStatus][]PaymentStatus{
+	PaymentStatusPending:    {PaymentStatusAuthorized, PaymentStatusFailed},
+	PaymentStatusAuthorized: {PaymentStatusCaptured, PaymentStatusFailed},
+	PaymentStatusCaptured:   {PaymentStatusRefunding},
+	PaymentStatusRefunding:  {PaymentStatusRefunded},
+}
+
+// CanTransitionTo reports whether a payment intent in the status can move to the next status.
+func (s PaymentStatus) CanTransitionTo(next PaymentStatus) bool {
+	for _, status := range paymentTransitions[s] {
+		if status == next {
+			return true
+		}
+	}
+
+	return false
+}
+
+// PaymentIntent is a model for the payment intent entity. A payment intent collects the Amount
+// of a booking through a payment provider, which identifies it by ProviderRef once authorized.
+// Amounts are in cents.
+type PaymentIntent struct {
+	ID             int32
+	BookingID      int32
+	Amount         int64
+	RefundedAmount int64
+	Status         PaymentStatus
+	ProviderRef    string
+	Transitions    PaymentTransitions
+	Created        time.Time
+	Updated        time.Time
+}
+
+// PaymentTransition is a model for a change of the status of a payment intent. Amount is the amount
+// authorized, captured or refunded by the change. From is empty for the creation of the intent.
+type PaymentTransition struct {
+	ID              int32
+	PaymentIntentID int32
+	From            PaymentStatus
+	To              PaymentStatus
+	Amount          int64
+	Created         time.Time
+}
+
+// PaymentTransitions is a slice of PaymentTransition objects.
+type PaymentTransitions []*PaymentTransition
@@ -0,0 +1,89 @@
+package model
+
+import (
+	"errors"
+	"time"
+)
+
+// PricingRuleType is the kind of adjustment a pricing rule makes to the price of a stay.
+type PricingRuleType string
+
+// Supported pricing rule types.
+const (
+	// PricingRuleSeason overrides the price per day for the nights from StartDate up to, but not including, EndDate.
+	PricingRuleSeason PricingRuleType = "season"
+	// PricingRuleWeekendSurcharge raises the price of Friday and Saturday nights by Percent.
+	PricingRuleWeekendSurcharge PricingRuleType = "weekend_surcharge"
+	// PricingRuleWeeklyDiscount lowers the price of stays of at least a week by Percent.
+	PricingRuleWeeklyDiscount PricingRuleType = "weekly_discount"
+	// PricingRuleMonthlyDiscount lowers the price of stays of at least 28 nights by Percent.
+	PricingRuleMonthlyDiscount PricingRuleType = "monthly_discount"
+)
+
+// Minimum number of nights for the length of stay discounts.
+const (
+	WeeklyDiscountNights  = 7
+	MonthlyDiscountNights = 28
+)
+
+// PricingRule is a model for the pricing rule entity. Which of the optional fields are set depends on Type.
+type PricingRule struct {
+	ID          int32
+	RentalID    int32
+	Type        PricingRuleType
+	StartDate   *time.Time
+	EndDate     *time.Time
+	PricePerDay *int64
+	Percent     *float64
+}
+
+// Validate checks whether the rule holds the fields required by its type with sensible values.
+func (pr *PricingRule) Validate() error {
+	if pr.RentalID <= 0 {
+		return errors.New("rental id is required")
+	}
+
+	switch pr.Type {
+	case PricingRuleSeason:
+		switch {
+		case pr.StartDate == nil || pr.EndDate == nil:
+			return errors.New("start and end dates are required for a season")
+		case !pr.EndDate.After(*pr.StartDate):
+			return errors.New("end date must be after start date")
+		case pr.PricePerDay == nil || *pr.PricePerDay <= 0:
+			return errors.New("price per day must be positive")
+		case pr.Percent != nil:
+			return errors.New("percent is not supported for a season")
+		}
+	case PricingRuleWeekendSurcharge, PricingRuleWeeklyDiscount, PricingRuleMonthlyDiscount:
+		switch {
+		case pr.Percent == nil || *pr.Percent <= 0:
+			return errors.New("percent must be positive")
+		case pr.Type != PricingRuleWeekendSurcharge && *pr.Percent >= 100:
+			return errors.New("percent of a discount must be less than 100")
+		case pr.StartDate != nil || pr.EndDate != nil || pr.PricePerDay != nil:
+			return errors.New("only percent is supported for surcharges and discounts")
+		}
+	default:
+		return errors.New("type must be one of season, weekend_surcharge, weekly_discount and monthly_discount")
+	}
+
+	return nil
+}
+
+// Overlaps reports whether both rules are seasons covering a common night or both are
+// surcharges or discounts of the same type, which cannot be applied together.
+func (pr *PricingRule) Overlaps(other *PricingRule) bool {
+	if pr.Type != other.Type {
+		return false
+	}
+
+	if pr.Type != PricingRuleSeason {
+		return true
+	}
+
+	return pr.StartDate.Before(*other.EndDate) && other.StartDate.Before(*pr.EndDate)
+}
+
+// PricingRules is a slice of PricingRule objects.
+type PricingRules []*PricingRule
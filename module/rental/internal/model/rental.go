@@ -1,27 +1,79 @@
 package model
 
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
 // Rental is a model for the rental entity.
 type Rental struct {
-	ID              int32
-	UserID          int32
-	Name            string
-	Type            string
-	Description     string
-	Sleeps          int32
-	PricePerDay     int64
-	HomeCity        string
-	HomeState       string
-	HomeZip         string
-	HomeCountry     string
-	VehicleMake     string
-	VehicleModel    string
-	VehicleYear     int32
-	VehicleLength   float32
-	Latitude        float32
-	Longitude       float32
-	PrimaryImageURL string
-
-	User *User
+	ID                 int32
+	UserID             int32
+	Name               string
+	Type               string
+	Description        string
+	Sleeps             int32
+	PricePerDay        int64
+	HomeCity           string
+	HomeState          string
+	HomeZip            string
+	HomeCountry        string
+	VehicleMake        string
+	VehicleModel       string
+	VehicleYear        int32
+	VehicleLength      float32
+	Latitude           float32
+	Longitude          float32
+	PrimaryImageURL    string
+	CancellationPolicy CancellationPolicy
+	RatingAverage      *float64
+	RatingCount        int32
+	Created            time.Time
+	Updated            time.Time
+
+	// Distance is the distance in miles of a rental listed near a location.
+	Distance *float64
+
+	User      *User
+	Images    RentalImages
+	Amenities []string
+}
+
+// Validate checks whether the rental holds all required fields with sensible values.
+func (r *Rental) Validate() error {
+	switch {
+	case r.Name == "":
+		return errors.New("name is required")
+	case r.Type == "":
+		return errors.New("type is required")
+	case r.PricePerDay <= 0:
+		return errors.New("price per day must be positive")
+	case r.Sleeps < 0:
+		return errors.New("sleeps must not be negative")
+	case r.UserID <= 0:
+		return errors.New("user id is required")
+	case r.Latitude == 0 && r.Longitude == 0:
+		return errors.New("coordinates are required")
+	case r.Latitude < -90 || r.Latitude > 90:
+		return fmt.Errorf("latitude %.2f is out of range", r.Latitude)
+	case r.Longitude < -180 || r.Longitude > 180:
+		return fmt.Errorf("longitude %.2f is out of range", r.Longitude)
+	case !r.CancellationPolicy.Valid():
+		return fmt.Errorf("unsupported cancellation policy %q", r.CancellationPolicy)
+	}
+
+	return nil
+}
+
+// PrimaryImage returns the url of the first image of the rental, falling back to PrimaryImageURL
+// for rentals without images.
+func (r *Rental) PrimaryImage() string {
+	if len(r.Images) > 0 {
+		return r.Images[0].URL
+	}
+
+	return r.PrimaryImageURL
 }
 
 // Rentals is a slice of Rental objects.
@@ -0,0 +1,47 @@
+package model
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"time"
+	"unicode/utf8"
+)
+
+// RentalImageCaptionMaxLength is the maximum number of characters in the caption of a rental image.
+const RentalImageCaptionMaxLength = 500
+
+// RentalImage is a model for an image of a rental. Images of a rental are shown in the order of
+// their Position, the first of them being the primary image of the rental. Uploaded images keep
+// their files under StorageKey and carry the URLs of their thumbnails by variant name.
+type RentalImage struct {
+	ID         int32
+	RentalID   int32
+	URL        string
+	Caption    string
+	Position   int
+	StorageKey string
+	Thumbnails map[string]string
+	Created    time.Time
+}
+
+// Validate checks whether the image holds all required fields with sensible values.
+func (ri *RentalImage) Validate() error {
+	if ri.RentalID <= 0 {
+		return errors.New("rental id is required")
+	}
+
+	u, err := url.Parse(ri.URL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return errors.New("url must be an absolute http or https url")
+	}
+
+	if utf8.RuneCountInString(ri.Caption) > RentalImageCaptionMaxLength {
+		return fmt.Errorf("caption must not be longer than %d characters", RentalImageCaptionMaxLength)
+	}
+
+	return nil
+}
+
+// RentalImages is a slice of RentalImage objects.
+type RentalImages []*RentalImage
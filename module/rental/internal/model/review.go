@@ -0,0 +1,46 @@
+package model
+
+import (
+	"errors"
+	"fmt"
+	"time"
+	"unicode/utf8"
+)
+
+// Bounds of the values of a review.
+const (
+	ReviewRatingMin        = 1
+	ReviewRatingMax        = 5
+	ReviewCommentMaxLength = 2000
+)
+
+// Review is a model for the review entity. A review rates a rental after a completed booking
+// and is written by the user who made the booking.
+type Review struct {
+	ID        int32
+	RentalID  int32
+	BookingID int32
+	UserID    int32
+	Rating    int
+	Comment   string
+	Created   time.Time
+}
+
+// Validate checks whether the review holds all required fields with sensible values.
+func (r *Review) Validate() error {
+	switch {
+	case r.RentalID <= 0:
+		return errors.New("rental id is required")
+	case r.BookingID <= 0:
+		return errors.New("booking id is required")
+	case r.Rating < ReviewRatingMin || r.Rating > ReviewRatingMax:
+		return fmt.Errorf("rating must be between %d and %d", ReviewRatingMin, ReviewRatingMax)
+	case utf8.RuneCountInString(r.Comment) > ReviewCommentMaxLength:
+		return fmt.Errorf("comment must not be longer than %d characters", ReviewCommentMaxLength)
+	}
+
+	return nil
+}
+
+// Reviews is a slice of Review objects.
+type Reviews []*Review
@@ -1,8 +1,25 @@
 package model
 
+import "errors"
+
 // User is a model for the user entity.
 type User struct {
 	ID        int32
 	FirstName string
 	LastName  string
 }
+
+// Validate checks whether the user holds all required fields.
+func (u *User) Validate() error {
+	switch {
+	case u.FirstName == "":
+		return errors.New("first name is required")
+	case u.LastName == "":
+		return errors.New("last name is required")
+	}
+
+	return nil
+}
+
+// Users is a slice of User objects.
+type Users []*User
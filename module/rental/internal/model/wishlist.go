@@ -0,0 +1,38 @@
+package model
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// WishlistNameMaxLength is the maximum number of characters in the name of a wishlist.
+const WishlistNameMaxLength = 100
+
+// Wishlist is a model for a named list of rentals saved by a user.
+type Wishlist struct {
+	ID          int32
+	UserID      int32
+	Name        string
+	RentalCount int32
+	Created     time.Time
+}
+
+// Validate checks whether the wishlist holds all required fields with sensible values.
+func (w *Wishlist) Validate() error {
+	switch {
+	case w.UserID <= 0:
+		return errors.New("user id is required")
+	case strings.TrimSpace(w.Name) == "":
+		return errors.New("name is required")
+	case utf8.RuneCountInString(w.Name) > WishlistNameMaxLength:
+		return fmt.Errorf("name must not be longer than %d characters", WishlistNameMaxLength)
+	}
+
+	return nil
+}
+
+// Wishlists is a slice of Wishlist objects.
+type Wishlists []*Wishlist
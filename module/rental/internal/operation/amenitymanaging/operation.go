@@ -0,0 +1,76 @@
+package amenitymanaging
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/dragonator/rental-service/module/rental/internal/http/service/svc"
+	"github.com/dragonator/rental-service/module/rental/internal/model"
+	"github.com/dragonator/rental-service/module/rental/internal/storage"
+)
+
+// Operation provides an API for the amenities catalog and the amenities of rentals.
+type Operation struct {
+	amenityStore AmenityStore
+	rentalStore  RentalStore
+}
+
+// NewOperation is a contruction function for Operation.
+func NewOperation(amenityStore AmenityStore, rentalStore RentalStore) *Operation {
+	return &Operation{
+		amenityStore: amenityStore,
+		rentalStore:  rentalStore,
+	}
+}
+
+// ListAmenities returns the amenities catalog.
+func (o *Operation) ListAmenities(ctx context.Context) (model.Amenities, error) {
+	amenities, err := o.amenityStore.List(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("operation ListAmenities: %w", err)
+	}
+
+	return amenities, nil
+}
+
+// SetRentalAmenities replaces the amenities of a rental with the amenities of the given slugs,
+// which have to be in the catalog. It returns the rental with its new amenities.
+func (o *Operation) SetRentalAmenities(ctx context.Context, rentalID int, slugs []string) (*model.Rental, error) {
+	if _, err := o.getRental(ctx, rentalID); err != nil {
+		return nil, err
+	}
+
+	unique := make([]string, 0, len(slugs))
+	seen := make(map[string]bool, len(slugs))
+
+	for _, slug := range slugs {
+		if !seen[slug] {
+			seen[slug] = true
+			unique = append(unique, slug)
+		}
+	}
+
+	err := o.amenityStore.SetForRental(ctx, rentalID, unique)
+	if errors.Is(err, storage.ErrUnknownAmenity) {
+		return nil, fmt.Errorf("%w: %w", svc.ErrInvalidRequestBody, err)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("operation SetRentalAmenities: %w", err)
+	}
+
+	return o.getRental(ctx, rentalID)
+}
+
+func (o *Operation) getRental(ctx context.Context, rentalID int) (*model.Rental, error) {
+	rental, err := o.rentalStore.GetByID(ctx, rentalID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("%w: rental with id %d", svc.ErrNotFound, rentalID)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("operation SetRentalAmenities: %w", err)
+	}
+
+	return rental, nil
+}
@@ -0,0 +1,93 @@
+package amenitymanaging_test
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/dragonator/rental-service/module/rental/internal/http/service/svc"
+	"github.com/dragonator/rental-service/module/rental/internal/model"
+	"github.com/dragonator/rental-service/module/rental/internal/operation/amenitymanaging"
+	"github.com/dragonator/rental-service/module/rental/internal/storage"
+)
+
+func TestOperation_SetRentalAmenities(t *testing.T) {
+	rental := &model.Rental{ID: 1, Amenities: []string{"shower", "solar"}}
+
+	testCases := []struct {
+		name          string
+		slugs         []string
+		rentalErr     error
+		setErr        error
+		expectedSlugs []string
+		expectedErr   error
+	}{
+		{
+			name:          "Known amenities",
+			slugs:         []string{"solar", "shower", "solar"},
+			expectedSlugs: []string{"solar", "shower"},
+		},
+		{
+			name:          "No amenities",
+			slugs:         []string{},
+			expectedSlugs: []string{},
+		},
+		{
+			name:        "Missing rental",
+			slugs:       []string{"shower"},
+			rentalErr:   sql.ErrNoRows,
+			expectedErr: svc.ErrNotFound,
+		},
+		{
+			name:          "Unknown amenity",
+			slugs:         []string{"sauna"},
+			setErr:        fmt.Errorf("adding rental amenity: %w: sauna", storage.ErrUnknownAmenity),
+			expectedSlugs: []string{"sauna"},
+			expectedErr:   svc.ErrInvalidRequestBody,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockAmenityStore := &AmenityStoreMock{
+				SetForRentalFunc: func(ctx context.Context, rentalID int, slugs []string) error {
+					return tc.setErr
+				},
+			}
+			mockRentalStore := &RentalStoreMock{
+				GetByIDFunc: func(ctx context.Context, rentalID int) (*model.Rental, error) {
+					if tc.rentalErr != nil {
+						return nil, tc.rentalErr
+					}
+
+					return rental, nil
+				},
+			}
+
+			operation := amenitymanaging.NewOperation(mockAmenityStore, mockRentalStore)
+
+			_, err := operation.SetRentalAmenities(context.Background(), 1, tc.slugs)
+
+			if err != tc.expectedErr && !errors.Is(err, tc.expectedErr) {
+				t.Fatalf("Unexpected error:\nexpected: %v\ngot:      %v", tc.expectedErr, err)
+			}
+
+			calls := mockAmenityStore.SetForRentalCalls()
+			if tc.expectedSlugs == nil {
+				if len(calls) != 0 {
+					t.Fatalf("Unexpected calls to SetForRental: %v", calls)
+				}
+
+				return
+			}
+
+			if len(calls) != 1 || !cmp.Equal(calls[0].Slugs, tc.expectedSlugs) {
+				t.Fatalf("Unexpected calls to SetForRental:\nexpected: %v\ngot:      %v", tc.expectedSlugs, calls)
+			}
+		})
+	}
+}
@@ -0,0 +1,22 @@
+package amenitymanaging
+
+import (
+	"context"
+
+	"github.com/dragonator/rental-service/module/rental/internal/model"
+)
+
+// AmenityStore is a contract to an amenity storage.
+//
+//go:generate moq -rm -pkg amenitymanaging_test -out amenity_store_mock_test.go . AmenityStore
+type AmenityStore interface {
+	List(ctx context.Context) (model.Amenities, error)
+	SetForRental(ctx context.Context, rentalID int, slugs []string) error
+}
+
+// RentalStore is a contract to a rental storage.
+//
+//go:generate moq -rm -pkg amenitymanaging_test -out rental_store_mock_test.go . RentalStore
+type RentalStore interface {
+	GetByID(ctx context.Context, rentalID int) (*model.Rental, error)
+}
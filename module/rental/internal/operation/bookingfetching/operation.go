@@ -0,0 +1,55 @@
+package bookingfetching
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/dragonator/rental-service/module/rental/internal/http/service/svc"
+	"github.com/dragonator/rental-service/module/rental/internal/model"
+)
+
+// Operation provides an API for fetching bookings.
+type Operation struct {
+	bookingStore BookingStore
+	paymentStore PaymentStore
+}
+
+// NewOperation is a contruction function for Operation.
+func NewOperation(bookingStore BookingStore, paymentStore PaymentStore) *Operation {
+	return &Operation{
+		bookingStore: bookingStore,
+		paymentStore: paymentStore,
+	}
+}
+
+// GetBookingByID returns a booking for the given id.
+func (o *Operation) GetBookingByID(ctx context.Context, bookingID int) (*model.Booking, error) {
+	booking, err := o.bookingStore.GetByID(ctx, bookingID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("%w: booking with id %d", svc.ErrNotFound, bookingID)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("operation GetBookingByID: %w", err)
+	}
+
+	return booking, nil
+}
+
+// GetBookingPayment returns the payment intent of a booking along with its transitions.
+func (o *Operation) GetBookingPayment(ctx context.Context, bookingID int) (*model.PaymentIntent, error) {
+	if _, err := o.GetBookingByID(ctx, bookingID); err != nil {
+		return nil, err
+	}
+
+	intent, err := o.paymentStore.GetByBooking(ctx, bookingID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("%w: payment of booking with id %d", svc.ErrNotFound, bookingID)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("operation GetBookingPayment: %w", err)
+	}
+
+	return intent, nil
+}
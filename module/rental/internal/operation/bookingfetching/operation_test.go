@@ -0,0 +1,126 @@
+package bookingfetching_test
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/dragonator/rental-service/module/rental/internal/http/service/svc"
+	"github.com/dragonator/rental-service/module/rental/internal/model"
+	"github.com/dragonator/rental-service/module/rental/internal/operation/bookingfetching"
+)
+
+var _booking = &model.Booking{
+	ID:        1,
+	RentalID:  2,
+	UserID:    3,
+	StartDate: time.Date(2023, 7, 1, 0, 0, 0, 0, time.UTC),
+	EndDate:   time.Date(2023, 7, 5, 0, 0, 0, 0, time.UTC),
+	Status:    model.BookingStatusConfirmed,
+}
+
+func TestOperation_GetBookingByID(t *testing.T) {
+	testCases := []struct {
+		name             string
+		mockBookingStore *BookingStoreMock
+		expectedResult   *model.Booking
+		expectedErr      error
+	}{
+		{
+			name: "Existing booking",
+			mockBookingStore: &BookingStoreMock{
+				GetByIDFunc: func(ctx context.Context, bookingID int) (*model.Booking, error) {
+					return _booking, nil
+				},
+			},
+			expectedResult: _booking,
+		},
+		{
+			name: "Missing booking",
+			mockBookingStore: &BookingStoreMock{
+				GetByIDFunc: func(ctx context.Context, bookingID int) (*model.Booking, error) {
+					return nil, sql.ErrNoRows
+				},
+			},
+			expectedErr: svc.ErrNotFound,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			operation := bookingfetching.NewOperation(tc.mockBookingStore, &PaymentStoreMock{})
+
+			booking, err := operation.GetBookingByID(context.Background(), 1)
+
+			if err != tc.expectedErr && !errors.Is(err, tc.expectedErr) {
+				t.Fatalf("Unexpected error:\nexpected: %v\ngot:      %v", tc.expectedErr, err)
+			}
+
+			if !cmp.Equal(booking, tc.expectedResult) {
+				t.Fatalf("Unxpected booking:\nexpected: %v\ngot:      %v", tc.expectedResult, booking)
+			}
+		})
+	}
+}
+
+func TestOperation_GetBookingPayment(t *testing.T) {
+	intent := &model.PaymentIntent{ID: 4, BookingID: 1, Amount: 50000, Status: model.PaymentStatusCaptured}
+
+	testCases := []struct {
+		name           string
+		bookingErr     error
+		paymentErr     error
+		expectedResult *model.PaymentIntent
+		expectedErr    error
+	}{
+		{
+			name:           "Paid booking",
+			expectedResult: intent,
+		},
+		{
+			name:        "Missing booking",
+			bookingErr:  sql.ErrNoRows,
+			expectedErr: svc.ErrNotFound,
+		},
+		{
+			name:        "Booking without payment",
+			paymentErr:  sql.ErrNoRows,
+			expectedErr: svc.ErrNotFound,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockBookingStore := &BookingStoreMock{
+				GetByIDFunc: func(ctx context.Context, bookingID int) (*model.Booking, error) {
+					return _booking, tc.bookingErr
+				},
+			}
+			mockPaymentStore := &PaymentStoreMock{
+				GetByBookingFunc: func(ctx context.Context, bookingID int) (*model.PaymentIntent, error) {
+					if tc.paymentErr != nil {
+						return nil, tc.paymentErr
+					}
+
+					return intent, nil
+				},
+			}
+
+			operation := bookingfetching.NewOperation(mockBookingStore, mockPaymentStore)
+
+			result, err := operation.GetBookingPayment(context.Background(), 1)
+
+			if err != tc.expectedErr && !errors.Is(err, tc.expectedErr) {
+				t.Fatalf("Unexpected error:\nexpected: %v\ngot:      %v", tc.expectedErr, err)
+			}
+
+			if !cmp.Equal(result, tc.expectedResult) {
+				t.Fatalf("Unxpected payment:\nexpected: %v\ngot:      %v", tc.expectedResult, result)
+			}
+		})
+	}
+}
@@ -0,0 +1,21 @@
+package bookingfetching
+
+import (
+	"context"
+
+	"github.com/dragonator/rental-service/module/rental/internal/model"
+)
+
+// BookingStore is a contract to a booking storage.
+//
+//go:generate moq -rm -pkg bookingfetching_test -out booking_store_mock_test.go . BookingStore
+type BookingStore interface {
+	GetByID(ctx context.Context, bookingID int) (*model.Booking, error)
+}
+
+// PaymentStore is a contract to a payment storage.
+//
+//go:generate moq -rm -pkg bookingfetching_test -out payment_store_mock_test.go . PaymentStore
+type PaymentStore interface {
+	GetByBooking(ctx context.Context, bookingID int) (*model.PaymentIntent, error)
+}
@@ -0,0 +1,328 @@
+package bookingmanaging
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/dragonator/rental-service/module/rental/internal/cancellation"
+	"github.com/dragonator/rental-service/module/rental/internal/http/service/svc"
+	"github.com/dragonator/rental-service/module/rental/internal/model"
+	"github.com/dragonator/rental-service/module/rental/internal/payment"
+	"github.com/dragonator/rental-service/module/rental/internal/pricing"
+	"github.com/dragonator/rental-service/module/rental/internal/storage"
+)
+
+// Operation provides an API for creating and cancelling bookings.
+type Operation struct {
+	bookingStore     BookingStore
+	rentalStore      RentalStore
+	userStore        UserStore
+	calendarStore    CalendarStore
+	pricingRuleStore PricingRuleStore
+	calculator       Calculator
+	paymentStore     PaymentStore
+	paymentProvider  PaymentProvider
+}
+
+// NewOperation is a contruction function for Operation.
+func NewOperation(
+	bookingStore BookingStore,
+	rentalStore RentalStore,
+	userStore UserStore,
+	calendarStore CalendarStore,
+	pricingRuleStore PricingRuleStore,
+	calculator Calculator,
+	paymentStore PaymentStore,
+	paymentProvider PaymentProvider,
+) *Operation {
+	return &Operation{
+		bookingStore:     bookingStore,
+		rentalStore:      rentalStore,
+		userStore:        userStore,
+		calendarStore:    calendarStore,
+		pricingRuleStore: pricingRuleStore,
+		calculator:       calculator,
+		paymentStore:     paymentStore,
+		paymentProvider:  paymentProvider,
+	}
+}
+
+// CreateBooking validates and stores the given booking as confirmed. The booking has to follow
+// the stay rules of the rental and cannot cover blocked days or overlap other bookings.
+// The total of the quote for the stay is collected from the payment provider and the booking
+// is cancelled when the payment cannot be collected. It returns the booking as stored.
+func (o *Operation) CreateBooking(ctx context.Context, booking *model.Booking) (*model.Booking, error) {
+	if err := booking.Validate(); err != nil {
+		return nil, fmt.Errorf("%w: %w", svc.ErrInvalidRequestBody, err)
+	}
+
+	rental, err := o.rentalStore.GetByID(ctx, int(booking.RentalID))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("%w: rental with id %d", svc.ErrNotFound, booking.RentalID)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("operation CreateBooking: %w", err)
+	}
+
+	_, err = o.userStore.GetByID(ctx, int(booking.UserID))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("%w: user with id %d does not exist", svc.ErrInvalidRequestBody, booking.UserID)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("operation CreateBooking: %w", err)
+	}
+
+	rules, err := o.calendarStore.GetStayRules(ctx, int(booking.RentalID))
+	if err != nil {
+		return nil, fmt.Errorf("operation CreateBooking: %w", err)
+	}
+
+	if err := rules.Allows(booking.Nights()); err != nil {
+		return nil, fmt.Errorf("%w: %w", svc.ErrInvalidRequestBody, err)
+	}
+
+	blocked, err := o.calendarStore.HasBlockedDays(ctx, int(booking.RentalID), booking.StartDate, booking.EndDate)
+	if err != nil {
+		return nil, fmt.Errorf("operation CreateBooking: %w", err)
+	}
+
+	if blocked {
+		return nil, fmt.Errorf("%w: rental with id %d is blocked for some of the requested dates", svc.ErrConflict, booking.RentalID)
+	}
+
+	pricingRules, err := o.pricingRuleStore.ListByRental(ctx, int(booking.RentalID))
+	if err != nil {
+		return nil, fmt.Errorf("operation CreateBooking: %w", err)
+	}
+
+	quote, err := o.calculator.Quote(rental, pricingRules, booking.StartDate, booking.EndDate)
+	if errors.Is(err, pricing.ErrInvalidStay) {
+		return nil, fmt.Errorf("%w: %w", svc.ErrInvalidRequestBody, err)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("operation CreateBooking: %w", err)
+	}
+
+	booking.CancellationPolicy = rental.CancellationPolicy
+
+	bookingID, err := o.bookingStore.Create(ctx, booking)
+	if errors.Is(err, storage.ErrBookingOverlap) {
+		return nil, fmt.Errorf("%w: rental with id %d is already booked for the requested dates", svc.ErrConflict, booking.RentalID)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("operation CreateBooking: %w", err)
+	}
+
+	if err := o.collectPayment(ctx, bookingID, quote.Total); err != nil {
+		if cancelErr := o.bookingStore.Cancel(ctx, bookingID); cancelErr != nil {
+			err = errors.Join(err, fmt.Errorf("cancelling unpaid booking: %w", cancelErr))
+		}
+
+		if errors.Is(err, payment.ErrDeclined) {
+			return nil, fmt.Errorf("%w: %w", svc.ErrPaymentRequired, err)
+		}
+
+		return nil, fmt.Errorf("operation CreateBooking: %w", err)
+	}
+
+	created, err := o.bookingStore.GetByID(ctx, bookingID)
+	if err != nil {
+		return nil, fmt.Errorf("operation CreateBooking: %w", err)
+	}
+
+	return created, nil
+}
+
+// CancelBooking cancels a confirmed booking and refunds its payment as decided by the cancellation
+// policy of the booking. It returns the cancelled booking along with the refund. Cancelled bookings
+// cannot be cancelled again, unless the refund due to them was not issued, which is then retried.
+func (o *Operation) CancelBooking(ctx context.Context, bookingID int) (*model.Booking, *cancellation.Refund, error) {
+	err := o.bookingStore.Cancel(ctx, bookingID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return o.retryRefund(ctx, bookingID)
+	}
+	if err != nil {
+		return nil, nil, fmt.Errorf("operation CancelBooking: %w", err)
+	}
+
+	cancelled, err := o.bookingStore.GetByID(ctx, bookingID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("operation CancelBooking: %w", err)
+	}
+
+	intent, refund, err := o.pendingRefund(ctx, cancelled)
+	if err != nil {
+		return nil, nil, fmt.Errorf("operation CancelBooking: %w", err)
+	}
+
+	if refund.Amount > 0 {
+		err := o.issueRefund(ctx, intent, refund)
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil, fmt.Errorf("%w: booking with id %d is already being refunded", svc.ErrConflict, bookingID)
+		}
+		if err != nil {
+			return nil, nil, fmt.Errorf("operation CancelBooking: %w", err)
+		}
+	}
+
+	return cancelled, refund, nil
+}
+
+// retryRefund issues the refund due to a booking which was cancelled without it, as when the payment
+// provider failed to refund the booking. It tells apart a missing booking from one that is no longer
+// confirmed and has nothing left to refund.
+func (o *Operation) retryRefund(ctx context.Context, bookingID int) (*model.Booking, *cancellation.Refund, error) {
+	booking, err := o.bookingStore.GetByID(ctx, bookingID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil, fmt.Errorf("%w: booking with id %d", svc.ErrNotFound, bookingID)
+	}
+	if err != nil {
+		return nil, nil, fmt.Errorf("operation CancelBooking: %w", err)
+	}
+
+	if booking.Status != model.BookingStatusCancelled || booking.CancelledAt == nil {
+		return nil, nil, fmt.Errorf("%w: booking with id %d is already cancelled", svc.ErrConflict, bookingID)
+	}
+
+	intent, refund, err := o.pendingRefund(ctx, booking)
+	if err != nil {
+		return nil, nil, fmt.Errorf("operation CancelBooking: %w", err)
+	}
+
+	if refund.Amount == 0 {
+		return nil, nil, fmt.Errorf("%w: booking with id %d is already cancelled", svc.ErrConflict, bookingID)
+	}
+
+	err = o.issueRefund(ctx, intent, refund)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil, fmt.Errorf("%w: booking with id %d is already being refunded", svc.ErrConflict, bookingID)
+	}
+	if err != nil {
+		return nil, nil, fmt.Errorf("operation CancelBooking: %w", err)
+	}
+
+	return booking, refund, nil
+}
+
+// collectPayment authorizes and captures the amount for the booking through a new payment intent.
+// If the payment cannot be collected the intent is marked as failed, an authorization which was
+// not captured is voided and an amount which was captured but could not be recorded is refunded.
+func (o *Operation) collectPayment(ctx context.Context, bookingID int, amount int64) error {
+	intent := &model.PaymentIntent{
+		BookingID: int32(bookingID),
+		Amount:    amount,
+		Status:    model.PaymentStatusPending,
+	}
+
+	intentID, err := o.paymentStore.Create(ctx, intent)
+	if err != nil {
+		return err
+	}
+
+	intent.ID = int32(intentID)
+
+	authorizationID, err := o.paymentProvider.Authorize(ctx, amount, fmt.Sprintf("booking-%d", bookingID))
+	if err != nil {
+		return o.failPayment(ctx, intent, err)
+	}
+
+	intent.ProviderRef = authorizationID
+
+	if err := o.transition(ctx, intent, model.PaymentStatusAuthorized, amount); err != nil {
+		return o.failPayment(ctx, intent, o.voidAuthorization(ctx, authorizationID, err))
+	}
+
+	if err := o.paymentProvider.Capture(ctx, authorizationID, amount); err != nil {
+		return o.failPayment(ctx, intent, o.voidAuthorization(ctx, authorizationID, err))
+	}
+
+	if err := o.transition(ctx, intent, model.PaymentStatusCaptured, amount); err != nil {
+		if refundErr := o.paymentProvider.Refund(ctx, authorizationID, amount, refundKey(intent)); refundErr != nil {
+			err = errors.Join(err, fmt.Errorf("refunding unrecorded capture: %w", refundErr))
+		}
+
+		return o.failPayment(ctx, intent, err)
+	}
+
+	return nil
+}
+
+// failPayment marks the intent as failed by cause and returns cause, joined with the error of
+// marking the intent if that fails too.
+func (o *Operation) failPayment(ctx context.Context, intent *model.PaymentIntent, cause error) error {
+	if err := o.transition(ctx, intent, model.PaymentStatusFailed, 0); err != nil {
+		return errors.Join(cause, fmt.Errorf("marking payment as failed: %w", err))
+	}
+
+	return cause
+}
+
+// voidAuthorization releases the authorization which was not captured because of cause and returns
+// cause, joined with the error of voiding the authorization if that fails too.
+func (o *Operation) voidAuthorization(ctx context.Context, authorizationID string, cause error) error {
+	if err := o.paymentProvider.Void(ctx, authorizationID); err != nil {
+		return errors.Join(cause, fmt.Errorf("voiding authorization: %w", err))
+	}
+
+	return cause
+}
+
+// pendingRefund returns the payment intent of the cancelled booking along with the refund due to it
+// as decided by its cancellation policy. The refund of an intent still refunding is due again, as the
+// cancellation time and so the refund do not change. Nothing is due once the captured payment was
+// refunded, and bookings made before payments were collected have no payment intent and nothing to refund.
+func (o *Operation) pendingRefund(ctx context.Context, booking *model.Booking) (*model.PaymentIntent, *cancellation.Refund, error) {
+	intent, err := o.paymentStore.GetByBooking(ctx, int(booking.ID))
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return nil, nil, err
+	}
+
+	var paid int64
+	if intent != nil && (intent.Status == model.PaymentStatusCaptured || intent.Status == model.PaymentStatusRefunding) {
+		paid = intent.Amount - intent.RefundedAmount
+	}
+
+	return intent, cancellation.ComputeRefund(booking.CancellationPolicy, booking, paid, *booking.CancelledAt), nil
+}
+
+// issueRefund refunds the amount of the refund through the payment provider and records it on the intent.
+// A captured intent is claimed for the refund by moving it to refunding first, so that of concurrent
+// cancellations only the one which claimed it calls the provider; the others get sql.ErrNoRows.
+// An intent left refunding by a failure is refunded again under the same idempotency key.
+func (o *Operation) issueRefund(ctx context.Context, intent *model.PaymentIntent, refund *cancellation.Refund) error {
+	if intent.Status == model.PaymentStatusCaptured {
+		if err := o.transition(ctx, intent, model.PaymentStatusRefunding, refund.Amount); err != nil {
+			return err
+		}
+	}
+
+	if err := o.paymentProvider.Refund(ctx, intent.ProviderRef, refund.Amount, refundKey(intent)); err != nil {
+		return err
+	}
+
+	intent.RefundedAmount += refund.Amount
+
+	return o.transition(ctx, intent, model.PaymentStatusRefunded, refund.Amount)
+}
+
+// transition moves the intent to the next status, recording the amount moved by the change.
+func (o *Operation) transition(ctx context.Context, intent *model.PaymentIntent, next model.PaymentStatus, amount int64) error {
+	if !intent.Status.CanTransitionTo(next) {
+		return fmt.Errorf("payment intent with id %d cannot move from %s to %s", intent.ID, intent.Status, next)
+	}
+
+	if err := o.paymentStore.Transition(ctx, intent, next, amount); err != nil {
+		return err
+	}
+
+	intent.Status = next
+
+	return nil
+}
+
+// refundKey returns the idempotency key of the refund of the intent, which is refunded at most once.
+func refundKey(intent *model.PaymentIntent) string {
+	return fmt.Sprintf("payment-intent-%d", intent.ID)
+}
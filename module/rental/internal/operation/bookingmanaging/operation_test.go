@@ -0,0 +1,612 @@
+package bookingmanaging_test
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/dragonator/rental-service/module/rental/internal/cancellation"
+	"github.com/dragonator/rental-service/module/rental/internal/http/service/svc"
+	"github.com/dragonator/rental-service/module/rental/internal/model"
+	"github.com/dragonator/rental-service/module/rental/internal/operation/bookingmanaging"
+	"github.com/dragonator/rental-service/module/rental/internal/payment"
+	"github.com/dragonator/rental-service/module/rental/internal/pricing"
+	"github.com/dragonator/rental-service/module/rental/internal/storage"
+)
+
+var _booking = &model.Booking{
+	ID:        1,
+	RentalID:  2,
+	UserID:    3,
+	StartDate: time.Date(2099, 7, 1, 0, 0, 0, 0, time.UTC),
+	EndDate:   time.Date(2099, 7, 5, 0, 0, 0, 0, time.UTC),
+	Status:    model.BookingStatusConfirmed,
+}
+
+func TestOperation_CreateBooking(t *testing.T) {
+	errStore := errors.New("store failure")
+	errProvider := errors.New("provider failure")
+
+	testCases := []struct {
+		name                string
+		booking             *model.Booking
+		rentalErr           error
+		userErr             error
+		rules               *model.StayRules
+		blocked             bool
+		quoteErr            error
+		createErr           error
+		intentErr           error
+		authorizeErr        error
+		captureErr          error
+		failedTransition    model.PaymentStatus
+		expectedCreateCalls int
+		expectedTransitions []model.PaymentStatus
+		expectedCancelled   bool
+		expectedVoided      bool
+		expectedRefunded    bool
+		expectedResult      *model.Booking
+		expectedErr         error
+	}{
+		{
+			name:                "Valid booking",
+			booking:             &model.Booking{RentalID: 2, UserID: 3, StartDate: _booking.StartDate, EndDate: _booking.EndDate},
+			expectedCreateCalls: 1,
+			expectedTransitions: []model.PaymentStatus{model.PaymentStatusAuthorized, model.PaymentStatusCaptured},
+			expectedResult:      _booking,
+		},
+		{
+			name:                "Declined payment",
+			booking:             &model.Booking{RentalID: 2, UserID: 3, StartDate: _booking.StartDate, EndDate: _booking.EndDate},
+			authorizeErr:        fmt.Errorf("authorizing booking-1: %w", payment.ErrDeclined),
+			expectedCreateCalls: 1,
+			expectedTransitions: []model.PaymentStatus{model.PaymentStatusFailed},
+			expectedCancelled:   true,
+			expectedErr:         svc.ErrPaymentRequired,
+		},
+		{
+			name:                "Declined payment not marked as failed",
+			booking:             &model.Booking{RentalID: 2, UserID: 3, StartDate: _booking.StartDate, EndDate: _booking.EndDate},
+			authorizeErr:        fmt.Errorf("authorizing booking-1: %w", payment.ErrDeclined),
+			failedTransition:    model.PaymentStatusFailed,
+			expectedCreateCalls: 1,
+			expectedTransitions: []model.PaymentStatus{model.PaymentStatusFailed},
+			expectedCancelled:   true,
+			expectedErr:         svc.ErrPaymentRequired,
+		},
+		{
+			name:                "Payment intent not stored",
+			booking:             &model.Booking{RentalID: 2, UserID: 3, StartDate: _booking.StartDate, EndDate: _booking.EndDate},
+			intentErr:           errStore,
+			expectedCreateCalls: 1,
+			expectedCancelled:   true,
+			expectedErr:         errStore,
+		},
+		{
+			name:                "Authorization not recorded",
+			booking:             &model.Booking{RentalID: 2, UserID: 3, StartDate: _booking.StartDate, EndDate: _booking.EndDate},
+			failedTransition:    model.PaymentStatusAuthorized,
+			expectedCreateCalls: 1,
+			expectedTransitions: []model.PaymentStatus{model.PaymentStatusAuthorized, model.PaymentStatusFailed},
+			expectedCancelled:   true,
+			expectedVoided:      true,
+			expectedErr:         errStore,
+		},
+		{
+			name:                "Failed capture",
+			booking:             &model.Booking{RentalID: 2, UserID: 3, StartDate: _booking.StartDate, EndDate: _booking.EndDate},
+			captureErr:          errProvider,
+			expectedCreateCalls: 1,
+			expectedTransitions: []model.PaymentStatus{model.PaymentStatusAuthorized, model.PaymentStatusFailed},
+			expectedCancelled:   true,
+			expectedVoided:      true,
+			expectedErr:         errProvider,
+		},
+		{
+			name:                "Capture not recorded",
+			booking:             &model.Booking{RentalID: 2, UserID: 3, StartDate: _booking.StartDate, EndDate: _booking.EndDate},
+			failedTransition:    model.PaymentStatusCaptured,
+			expectedCreateCalls: 1,
+			expectedTransitions: []model.PaymentStatus{
+				model.PaymentStatusAuthorized,
+				model.PaymentStatusCaptured,
+				model.PaymentStatusFailed,
+			},
+			expectedCancelled: true,
+			expectedRefunded:  true,
+			expectedErr:       errStore,
+		},
+		{
+			name:                "End date before start date",
+			booking:             &model.Booking{RentalID: 2, UserID: 3, StartDate: _booking.EndDate, EndDate: _booking.StartDate},
+			expectedCreateCalls: 0,
+			expectedErr:         svc.ErrInvalidRequestBody,
+		},
+		{
+			name: "Start date in the past",
+			booking: &model.Booking{
+				RentalID:  2,
+				UserID:    3,
+				StartDate: time.Date(2023, 7, 1, 0, 0, 0, 0, time.UTC),
+				EndDate:   time.Date(2023, 7, 5, 0, 0, 0, 0, time.UTC),
+			},
+			expectedCreateCalls: 0,
+			expectedErr:         svc.ErrInvalidRequestBody,
+		},
+		{
+			name:                "Missing rental",
+			booking:             &model.Booking{RentalID: 2, UserID: 3, StartDate: _booking.StartDate, EndDate: _booking.EndDate},
+			rentalErr:           sql.ErrNoRows,
+			expectedCreateCalls: 0,
+			expectedErr:         svc.ErrNotFound,
+		},
+		{
+			name:                "Missing user",
+			booking:             &model.Booking{RentalID: 2, UserID: 3, StartDate: _booking.StartDate, EndDate: _booking.EndDate},
+			userErr:             sql.ErrNoRows,
+			expectedCreateCalls: 0,
+			expectedErr:         svc.ErrInvalidRequestBody,
+		},
+		{
+			name:                "Too short stay",
+			booking:             &model.Booking{RentalID: 2, UserID: 3, StartDate: _booking.StartDate, EndDate: _booking.EndDate},
+			rules:               &model.StayRules{MinNights: 7},
+			expectedCreateCalls: 0,
+			expectedErr:         svc.ErrInvalidRequestBody,
+		},
+		{
+			name:                "Too long stay",
+			booking:             &model.Booking{RentalID: 2, UserID: 3, StartDate: _booking.StartDate, EndDate: _booking.EndDate},
+			rules:               &model.StayRules{MinNights: 1, MaxNights: toPtr(3)},
+			expectedCreateCalls: 0,
+			expectedErr:         svc.ErrInvalidRequestBody,
+		},
+		{
+			name:                "Blocked days",
+			booking:             &model.Booking{RentalID: 2, UserID: 3, StartDate: _booking.StartDate, EndDate: _booking.EndDate},
+			blocked:             true,
+			expectedCreateCalls: 0,
+			expectedErr:         svc.ErrConflict,
+		},
+		{
+			name:                "Too long stay to price",
+			booking:             &model.Booking{RentalID: 2, UserID: 3, StartDate: _booking.StartDate, EndDate: _booking.EndDate},
+			quoteErr:            fmt.Errorf("%w: at most 365 nights can be priced", pricing.ErrInvalidStay),
+			expectedCreateCalls: 0,
+			expectedErr:         svc.ErrInvalidRequestBody,
+		},
+		{
+			name:                "Overlapping booking",
+			booking:             &model.Booking{RentalID: 2, UserID: 3, StartDate: _booking.StartDate, EndDate: _booking.EndDate},
+			createErr:           fmt.Errorf("creating booking: %w", storage.ErrBookingOverlap),
+			expectedCreateCalls: 1,
+			expectedErr:         svc.ErrConflict,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockBookingStore := &BookingStoreMock{
+				CreateFunc: func(ctx context.Context, booking *model.Booking) (int, error) {
+					return 1, tc.createErr
+				},
+				GetByIDFunc: func(ctx context.Context, bookingID int) (*model.Booking, error) {
+					return _booking, nil
+				},
+				CancelFunc: func(ctx context.Context, bookingID int) error {
+					return nil
+				},
+			}
+			mockRentalStore := &RentalStoreMock{
+				GetByIDFunc: func(ctx context.Context, rentalID int) (*model.Rental, error) {
+					return &model.Rental{ID: int32(rentalID), CancellationPolicy: model.CancellationPolicyStrict}, tc.rentalErr
+				},
+			}
+			mockUserStore := &UserStoreMock{
+				GetByIDFunc: func(ctx context.Context, userID int) (*model.User, error) {
+					return &model.User{ID: int32(userID)}, tc.userErr
+				},
+			}
+
+			mockCalendarStore := &CalendarStoreMock{
+				GetStayRulesFunc: func(ctx context.Context, rentalID int) (*model.StayRules, error) {
+					if tc.rules != nil {
+						return tc.rules, nil
+					}
+
+					return model.DefaultStayRules(), nil
+				},
+				HasBlockedDaysFunc: func(ctx context.Context, rentalID int, start, end time.Time) (bool, error) {
+					return tc.blocked, nil
+				},
+			}
+
+			mockPricingRuleStore := &PricingRuleStoreMock{
+				ListByRentalFunc: func(ctx context.Context, rentalID int) (model.PricingRules, error) {
+					return nil, nil
+				},
+			}
+			mockCalculator := &CalculatorMock{
+				QuoteFunc: func(rental *model.Rental, rules model.PricingRules, start, end time.Time) (*pricing.Quote, error) {
+					if tc.quoteErr != nil {
+						return nil, tc.quoteErr
+					}
+
+					return &pricing.Quote{Total: 50000}, nil
+				},
+			}
+			mockPaymentStore := &PaymentStoreMock{
+				CreateFunc: func(ctx context.Context, intent *model.PaymentIntent) (int, error) {
+					return 4, tc.intentErr
+				},
+				TransitionFunc: func(ctx context.Context, intent *model.PaymentIntent, next model.PaymentStatus, amount int64) error {
+					if next == tc.failedTransition {
+						return errStore
+					}
+
+					return nil
+				},
+			}
+			mockPaymentProvider := &PaymentProviderMock{
+				AuthorizeFunc: func(ctx context.Context, amount int64, reference string) (string, error) {
+					return "fake_auth_1", tc.authorizeErr
+				},
+				CaptureFunc: func(ctx context.Context, authorizationID string, amount int64) error {
+					return tc.captureErr
+				},
+				VoidFunc: func(ctx context.Context, authorizationID string) error {
+					return nil
+				},
+				RefundFunc: func(ctx context.Context, authorizationID string, amount int64, idempotencyKey string) error {
+					return nil
+				},
+			}
+
+			operation := bookingmanaging.NewOperation(
+				mockBookingStore,
+				mockRentalStore,
+				mockUserStore,
+				mockCalendarStore,
+				mockPricingRuleStore,
+				mockCalculator,
+				mockPaymentStore,
+				mockPaymentProvider,
+			)
+
+			booking, err := operation.CreateBooking(context.Background(), tc.booking)
+
+			if calls := mockBookingStore.CreateCalls(); len(calls) != tc.expectedCreateCalls {
+				t.Fatalf("Unexpected number of calls to Create:\nexpected: %d\ngot      %d", tc.expectedCreateCalls, len(calls))
+			}
+
+			for _, call := range mockBookingStore.CreateCalls() {
+				if call.Booking.CancellationPolicy != model.CancellationPolicyStrict {
+					t.Fatalf("Unexpected cancellation policy of the booking: %s", call.Booking.CancellationPolicy)
+				}
+			}
+
+			var transitions []model.PaymentStatus
+			for _, call := range mockPaymentStore.TransitionCalls() {
+				transitions = append(transitions, call.Next)
+			}
+
+			if !cmp.Equal(transitions, tc.expectedTransitions) {
+				t.Fatalf("Unexpected payment transitions:\nexpected: %v\ngot:      %v", tc.expectedTransitions, transitions)
+			}
+
+			if cancelled := len(mockBookingStore.CancelCalls()) == 1; cancelled != tc.expectedCancelled {
+				t.Fatalf("Unexpected cancellation of the booking:\nexpected: %t\ngot:      %t", tc.expectedCancelled, cancelled)
+			}
+
+			if voided := len(mockPaymentProvider.VoidCalls()) == 1; voided != tc.expectedVoided {
+				t.Fatalf("Unexpected void of the authorization:\nexpected: %t\ngot:      %t", tc.expectedVoided, voided)
+			}
+
+			if refunded := len(mockPaymentProvider.RefundCalls()) == 1; refunded != tc.expectedRefunded {
+				t.Fatalf("Unexpected refund of the payment:\nexpected: %t\ngot:      %t", tc.expectedRefunded, refunded)
+			}
+
+			if err != tc.expectedErr && !errors.Is(err, tc.expectedErr) {
+				t.Fatalf("Unexpected error:\nexpected: %v\ngot:      %v", tc.expectedErr, err)
+			}
+
+			if !cmp.Equal(booking, tc.expectedResult) {
+				t.Fatalf("Unxpected booking:\nexpected: %v\ngot:      %v", tc.expectedResult, booking)
+			}
+		})
+	}
+}
+
+func TestOperation_CancelBooking(t *testing.T) {
+	cancelled := *_booking
+	cancelled.Status = model.BookingStatusCancelled
+	cancelled.CancellationPolicy = model.CancellationPolicyModerate
+	cancelled.CancelledAt = toPtr(time.Date(2099, 6, 28, 0, 0, 0, 0, time.UTC))
+
+	captured := &model.PaymentIntent{ID: 4, Amount: 50000, Status: model.PaymentStatusCaptured, ProviderRef: "fake_auth_1"}
+	errProvider := errors.New("provider failure")
+
+	refunding := &model.PaymentIntent{ID: 4, Amount: 50000, Status: model.PaymentStatusRefunding, ProviderRef: "fake_auth_1"}
+	refunded := &model.PaymentIntent{
+		ID:             4,
+		Amount:         50000,
+		RefundedAmount: 25000,
+		Status:         model.PaymentStatusRefunded,
+		ProviderRef:    "fake_auth_1",
+	}
+
+	testCases := []struct {
+		name                string
+		cancelErr           error
+		getErr              error
+		intent              *model.PaymentIntent
+		claimErr            error
+		refundErr           error
+		expectedRefunds     []int64
+		expectedTransitions []model.PaymentStatus
+		expectedRefund      *cancellation.Refund
+		expectedResult      *model.Booking
+		expectedErr         error
+	}{
+		{
+			name:                "Paid booking",
+			intent:              captured,
+			expectedRefunds:     []int64{25000},
+			expectedTransitions: []model.PaymentStatus{model.PaymentStatusRefunding, model.PaymentStatusRefunded},
+			expectedRefund:      &cancellation.Refund{Policy: model.CancellationPolicyModerate, Percent: 50, Amount: 25000},
+			expectedResult:      &cancelled,
+		},
+		{
+			name:           "Booking without payment",
+			expectedRefund: &cancellation.Refund{Policy: model.CancellationPolicyModerate, Percent: 50},
+			expectedResult: &cancelled,
+		},
+		{
+			name:        "Missing booking",
+			cancelErr:   sql.ErrNoRows,
+			getErr:      sql.ErrNoRows,
+			expectedErr: svc.ErrNotFound,
+		},
+		{
+			name:                "Refund claimed by another cancellation",
+			intent:              captured,
+			claimErr:            fmt.Errorf("transitioning payment intent: %w", sql.ErrNoRows),
+			expectedTransitions: []model.PaymentStatus{model.PaymentStatusRefunding},
+			expectedErr:         svc.ErrConflict,
+		},
+		{
+			name:                "Refund fails",
+			intent:              captured,
+			refundErr:           errProvider,
+			expectedRefunds:     []int64{25000},
+			expectedTransitions: []model.PaymentStatus{model.PaymentStatusRefunding},
+			expectedErr:         errProvider,
+		},
+		{
+			name:        "Cancelled booking",
+			cancelErr:   sql.ErrNoRows,
+			expectedErr: svc.ErrConflict,
+		},
+		{
+			name:                "Cancelled booking with pending refund",
+			cancelErr:           sql.ErrNoRows,
+			intent:              captured,
+			expectedRefunds:     []int64{25000},
+			expectedTransitions: []model.PaymentStatus{model.PaymentStatusRefunding, model.PaymentStatusRefunded},
+			expectedRefund:      &cancellation.Refund{Policy: model.CancellationPolicyModerate, Percent: 50, Amount: 25000},
+			expectedResult:      &cancelled,
+		},
+		{
+			name:                "Cancelled booking with refunding payment",
+			cancelErr:           sql.ErrNoRows,
+			intent:              refunding,
+			expectedRefunds:     []int64{25000},
+			expectedTransitions: []model.PaymentStatus{model.PaymentStatusRefunded},
+			expectedRefund:      &cancellation.Refund{Policy: model.CancellationPolicyModerate, Percent: 50, Amount: 25000},
+			expectedResult:      &cancelled,
+		},
+		{
+			name:        "Cancelled booking with refunded payment",
+			cancelErr:   sql.ErrNoRows,
+			intent:      refunded,
+			expectedErr: svc.ErrConflict,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockBookingStore := &BookingStoreMock{
+				CancelFunc: func(ctx context.Context, bookingID int) error {
+					return tc.cancelErr
+				},
+				GetByIDFunc: func(ctx context.Context, bookingID int) (*model.Booking, error) {
+					if tc.getErr != nil {
+						return nil, tc.getErr
+					}
+
+					return &cancelled, nil
+				},
+			}
+
+			mockPaymentStore := &PaymentStoreMock{
+				GetByBookingFunc: func(ctx context.Context, bookingID int) (*model.PaymentIntent, error) {
+					if tc.intent == nil {
+						return nil, sql.ErrNoRows
+					}
+
+					intent := *tc.intent
+
+					return &intent, nil
+				},
+				TransitionFunc: func(ctx context.Context, intent *model.PaymentIntent, next model.PaymentStatus, amount int64) error {
+					if next == model.PaymentStatusRefunding {
+						return tc.claimErr
+					}
+
+					return nil
+				},
+			}
+			mockPaymentProvider := &PaymentProviderMock{
+				RefundFunc: func(ctx context.Context, authorizationID string, amount int64, idempotencyKey string) error {
+					return tc.refundErr
+				},
+			}
+
+			operation := bookingmanaging.NewOperation(
+				mockBookingStore,
+				&RentalStoreMock{},
+				&UserStoreMock{},
+				&CalendarStoreMock{},
+				&PricingRuleStoreMock{},
+				&CalculatorMock{},
+				mockPaymentStore,
+				mockPaymentProvider,
+			)
+
+			booking, refund, err := operation.CancelBooking(context.Background(), 1)
+
+			var refunds []int64
+			for _, call := range mockPaymentProvider.RefundCalls() {
+				if call.IdempotencyKey != "payment-intent-4" {
+					t.Fatalf("Unexpected idempotency key: %s", call.IdempotencyKey)
+				}
+
+				refunds = append(refunds, call.Amount)
+			}
+
+			if !cmp.Equal(refunds, tc.expectedRefunds) {
+				t.Fatalf("Unexpected refunds:\nexpected: %v\ngot:      %v", tc.expectedRefunds, refunds)
+			}
+
+			var transitions []model.PaymentStatus
+			for _, call := range mockPaymentStore.TransitionCalls() {
+				transitions = append(transitions, call.Next)
+			}
+
+			if !cmp.Equal(transitions, tc.expectedTransitions) {
+				t.Fatalf("Unexpected transitions:\nexpected: %v\ngot:      %v", tc.expectedTransitions, transitions)
+			}
+
+			if err != tc.expectedErr && !errors.Is(err, tc.expectedErr) {
+				t.Fatalf("Unexpected error:\nexpected: %v\ngot:      %v", tc.expectedErr, err)
+			}
+
+			if !cmp.Equal(booking, tc.expectedResult) {
+				t.Fatalf("Unxpected booking:\nexpected: %v\ngot:      %v", tc.expectedResult, booking)
+			}
+
+			if !cmp.Equal(refund, tc.expectedRefund) {
+				t.Fatalf("Unxpected refund:\nexpected: %v\ngot:      %v", tc.expectedRefund, refund)
+			}
+		})
+	}
+}
+
+func TestOperation_CancelBookingConcurrently(t *testing.T) {
+	cancelled := *_booking
+	cancelled.Status = model.BookingStatusCancelled
+	cancelled.CancellationPolicy = model.CancellationPolicyModerate
+	cancelled.CancelledAt = toPtr(time.Date(2099, 6, 28, 0, 0, 0, 0, time.UTC))
+
+	var (
+		mu        sync.Mutex
+		confirmed = true
+		status    = model.PaymentStatusCaptured
+	)
+
+	mockBookingStore := &BookingStoreMock{
+		CancelFunc: func(ctx context.Context, bookingID int) error {
+			mu.Lock()
+			defer mu.Unlock()
+
+			if !confirmed {
+				return sql.ErrNoRows
+			}
+
+			confirmed = false
+
+			return nil
+		},
+		GetByIDFunc: func(ctx context.Context, bookingID int) (*model.Booking, error) {
+			booking := cancelled
+			return &booking, nil
+		},
+	}
+
+	// Both cancellations read the intent as captured, before either of them claims it.
+	mockPaymentStore := &PaymentStoreMock{
+		GetByBookingFunc: func(ctx context.Context, bookingID int) (*model.PaymentIntent, error) {
+			return &model.PaymentIntent{ID: 4, Amount: 50000, Status: model.PaymentStatusCaptured, ProviderRef: "fake_auth_1"}, nil
+		},
+		TransitionFunc: func(ctx context.Context, intent *model.PaymentIntent, next model.PaymentStatus, amount int64) error {
+			mu.Lock()
+			defer mu.Unlock()
+
+			if intent.Status != status {
+				return fmt.Errorf("transitioning payment intent: %w", sql.ErrNoRows)
+			}
+
+			status = next
+
+			return nil
+		},
+	}
+	mockPaymentProvider := &PaymentProviderMock{
+		RefundFunc: func(ctx context.Context, authorizationID string, amount int64, idempotencyKey string) error {
+			return nil
+		},
+	}
+
+	operation := bookingmanaging.NewOperation(
+		mockBookingStore,
+		&RentalStoreMock{},
+		&UserStoreMock{},
+		&CalendarStoreMock{},
+		&PricingRuleStoreMock{},
+		&CalculatorMock{},
+		mockPaymentStore,
+		mockPaymentProvider,
+	)
+
+	var wg sync.WaitGroup
+	errs := make([]error, 2)
+
+	for i := range errs {
+		wg.Add(1)
+
+		go func(i int) {
+			defer wg.Done()
+			_, _, errs[i] = operation.CancelBooking(context.Background(), 1)
+		}(i)
+	}
+
+	wg.Wait()
+
+	if calls := len(mockPaymentProvider.RefundCalls()); calls != 1 {
+		t.Fatalf("Unexpected refund calls:\nexpected: 1\ngot:      %d", calls)
+	}
+
+	if (errs[0] == nil) == (errs[1] == nil) {
+		t.Fatalf("Expected exactly one cancellation to succeed, got: %v", errs)
+	}
+
+	for _, err := range errs {
+		if err != nil && !errors.Is(err, svc.ErrConflict) {
+			t.Fatalf("Unexpected error:\nexpected: %v\ngot:      %v", svc.ErrConflict, err)
+		}
+	}
+
+	if status != model.PaymentStatusRefunded {
+		t.Fatalf("Unexpected payment status: %s", status)
+	}
+}
+
+func toPtr[T any](v T) *T {
+	return &v
+}
@@ -0,0 +1,74 @@
+package bookingmanaging
+
+import (
+	"context"
+	"time"
+
+	"github.com/dragonator/rental-service/module/rental/internal/model"
+	"github.com/dragonator/rental-service/module/rental/internal/pricing"
+)
+
+// BookingStore is a contract to a booking storage.
+//
+//go:generate moq -rm -pkg bookingmanaging_test -out booking_store_mock_test.go . BookingStore
+type BookingStore interface {
+	GetByID(ctx context.Context, bookingID int) (*model.Booking, error)
+	Create(ctx context.Context, booking *model.Booking) (int, error)
+	Cancel(ctx context.Context, bookingID int) error
+}
+
+// RentalStore is a contract to a rental storage.
+//
+//go:generate moq -rm -pkg bookingmanaging_test -out rental_store_mock_test.go . RentalStore
+type RentalStore interface {
+	GetByID(ctx context.Context, rentalID int) (*model.Rental, error)
+}
+
+// UserStore is a contract to a user storage.
+//
+//go:generate moq -rm -pkg bookingmanaging_test -out user_store_mock_test.go . UserStore
+type UserStore interface {
+	GetByID(ctx context.Context, userID int) (*model.User, error)
+}
+
+// CalendarStore is a contract to a calendar storage.
+//
+//go:generate moq -rm -pkg bookingmanaging_test -out calendar_store_mock_test.go . CalendarStore
+type CalendarStore interface {
+	GetStayRules(ctx context.Context, rentalID int) (*model.StayRules, error)
+	HasBlockedDays(ctx context.Context, rentalID int, start, end time.Time) (bool, error)
+}
+
+// PricingRuleStore is a contract to a pricing rule storage.
+//
+//go:generate moq -rm -pkg bookingmanaging_test -out pricing_rule_store_mock_test.go . PricingRuleStore
+type PricingRuleStore interface {
+	ListByRental(ctx context.Context, rentalID int) (model.PricingRules, error)
+}
+
+// Calculator is a contract to a price calculator.
+//
+//go:generate moq -rm -pkg bookingmanaging_test -out calculator_mock_test.go . Calculator
+type Calculator interface {
+	Quote(rental *model.Rental, rules model.PricingRules, start, end time.Time) (*pricing.Quote, error)
+}
+
+// PaymentStore is a contract to a payment storage.
+//
+//go:generate moq -rm -pkg bookingmanaging_test -out payment_store_mock_test.go . PaymentStore
+type PaymentStore interface {
+	GetByBooking(ctx context.Context, bookingID int) (*model.PaymentIntent, error)
+	Create(ctx context.Context, intent *model.PaymentIntent) (int, error)
+	Transition(ctx context.Context, intent *model.PaymentIntent, next model.PaymentStatus, amount int64) error
+}
+
+// PaymentProvider is a contract to a payment provider. Amounts are in cents. A refund repeating
+// the idempotency key of an earlier refund is not issued again.
+//
+//go:generate moq -rm -pkg bookingmanaging_test -out payment_provider_mock_test.go . PaymentProvider
+type PaymentProvider interface {
+	Authorize(ctx context.Context, amount int64, reference string) (string, error)
+	Capture(ctx context.Context, authorizationID string, amount int64) error
+	Void(ctx context.Context, authorizationID string) error
+	Refund(ctx context.Context, authorizationID string, amount int64, idempotencyKey string) error
+}
@@ -0,0 +1,123 @@
+package calendarmanaging
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/dragonator/rental-service/module/rental/internal/http/service/svc"
+	"github.com/dragonator/rental-service/module/rental/internal/model"
+)
+
+// Operation provides an API for reading and updating the availability calendar of rentals.
+type Operation struct {
+	calendarStore CalendarStore
+	bookingStore  BookingStore
+	rentalStore   RentalStore
+}
+
+// NewOperation is a contruction function for Operation.
+func NewOperation(calendarStore CalendarStore, bookingStore BookingStore, rentalStore RentalStore) *Operation {
+	return &Operation{
+		calendarStore: calendarStore,
+		bookingStore:  bookingStore,
+		rentalStore:   rentalStore,
+	}
+}
+
+// GetCalendar returns the calendar of a rental for the days from start up to, but not including, end.
+func (o *Operation) GetCalendar(ctx context.Context, rentalID int, start, end time.Time) (*model.Calendar, error) {
+	if err := o.checkRentalExists(ctx, rentalID); err != nil {
+		return nil, err
+	}
+
+	calendar, err := o.buildCalendar(ctx, rentalID, start, end)
+	if err != nil {
+		return nil, fmt.Errorf("operation GetCalendar: %w", err)
+	}
+
+	return calendar, nil
+}
+
+// UpdateCalendar stores the stay rules of a rental and replaces its blocked days from start up to,
+// but not including, end. Days which are already booked cannot be blocked.
+// It returns the updated calendar for the same days.
+func (o *Operation) UpdateCalendar(
+	ctx context.Context,
+	rentalID int,
+	rules *model.StayRules,
+	start, end time.Time,
+	blockedDays []time.Time,
+) (*model.Calendar, error) {
+	if err := rules.Validate(); err != nil {
+		return nil, fmt.Errorf("%w: %w", svc.ErrInvalidRequestBody, err)
+	}
+
+	for _, day := range blockedDays {
+		if day.Before(start) || !day.Before(end) {
+			return nil, fmt.Errorf("%w: blocked day %s is outside of the requested months",
+				svc.ErrInvalidRequestBody, day.Format(time.DateOnly))
+		}
+	}
+
+	if err := o.checkRentalExists(ctx, rentalID); err != nil {
+		return nil, err
+	}
+
+	bookings, err := o.bookingStore.ListByRental(ctx, rentalID, start, end)
+	if err != nil {
+		return nil, fmt.Errorf("operation UpdateCalendar: %w", err)
+	}
+
+	for _, day := range blockedDays {
+		for _, b := range bookings {
+			if !day.Before(b.StartDate) && day.Before(b.EndDate) {
+				return nil, fmt.Errorf("%w: day %s is booked", svc.ErrConflict, day.Format(time.DateOnly))
+			}
+		}
+	}
+
+	if err := o.calendarStore.Update(ctx, rentalID, rules, start, end, blockedDays); err != nil {
+		return nil, fmt.Errorf("operation UpdateCalendar: %w", err)
+	}
+
+	calendar, err := o.buildCalendar(ctx, rentalID, start, end)
+	if err != nil {
+		return nil, fmt.Errorf("operation UpdateCalendar: %w", err)
+	}
+
+	return calendar, nil
+}
+
+func (o *Operation) checkRentalExists(ctx context.Context, rentalID int) error {
+	_, err := o.rentalStore.GetByID(ctx, rentalID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return fmt.Errorf("%w: rental with id %d", svc.ErrNotFound, rentalID)
+	}
+	if err != nil {
+		return fmt.Errorf("checking rental: %w", err)
+	}
+
+	return nil
+}
+
+func (o *Operation) buildCalendar(ctx context.Context, rentalID int, start, end time.Time) (*model.Calendar, error) {
+	rules, err := o.calendarStore.GetStayRules(ctx, rentalID)
+	if err != nil {
+		return nil, err
+	}
+
+	blockedDays, err := o.calendarStore.ListBlockedDays(ctx, rentalID, start, end)
+	if err != nil {
+		return nil, err
+	}
+
+	bookings, err := o.bookingStore.ListByRental(ctx, rentalID, start, end)
+	if err != nil {
+		return nil, err
+	}
+
+	return model.NewCalendar(int32(rentalID), rules, start, end, blockedDays, bookings), nil
+}
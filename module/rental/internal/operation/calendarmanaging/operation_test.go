@@ -0,0 +1,169 @@
+package calendarmanaging_test
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/dragonator/rental-service/module/rental/internal/http/service/svc"
+	"github.com/dragonator/rental-service/module/rental/internal/model"
+	"github.com/dragonator/rental-service/module/rental/internal/operation/calendarmanaging"
+)
+
+var (
+	_start   = time.Date(2023, 7, 1, 0, 0, 0, 0, time.UTC)
+	_end     = time.Date(2023, 7, 5, 0, 0, 0, 0, time.UTC)
+	_booking = &model.Booking{
+		ID:        1,
+		RentalID:  2,
+		StartDate: time.Date(2023, 7, 3, 0, 0, 0, 0, time.UTC),
+		EndDate:   time.Date(2023, 7, 5, 0, 0, 0, 0, time.UTC),
+		Status:    model.BookingStatusConfirmed,
+	}
+)
+
+func TestOperation_GetCalendar(t *testing.T) {
+	testCases := []struct {
+		name           string
+		rentalErr      error
+		expectedResult *model.Calendar
+		expectedErr    error
+	}{
+		{
+			name: "Existing rental",
+			expectedResult: &model.Calendar{
+				RentalID:  2,
+				StayRules: model.StayRules{MinNights: 2},
+				Days: []model.CalendarDay{
+					{Date: time.Date(2023, 7, 1, 0, 0, 0, 0, time.UTC), Status: model.DayStatusAvailable},
+					{Date: time.Date(2023, 7, 2, 0, 0, 0, 0, time.UTC), Status: model.DayStatusBlocked},
+					{Date: time.Date(2023, 7, 3, 0, 0, 0, 0, time.UTC), Status: model.DayStatusBooked},
+					{Date: time.Date(2023, 7, 4, 0, 0, 0, 0, time.UTC), Status: model.DayStatusBooked},
+				},
+			},
+		},
+		{
+			name:        "Missing rental",
+			rentalErr:   sql.ErrNoRows,
+			expectedErr: svc.ErrNotFound,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockCalendarStore := &CalendarStoreMock{
+				GetStayRulesFunc: func(ctx context.Context, rentalID int) (*model.StayRules, error) {
+					return &model.StayRules{MinNights: 2}, nil
+				},
+				ListBlockedDaysFunc: func(ctx context.Context, rentalID int, start, end time.Time) ([]time.Time, error) {
+					return []time.Time{time.Date(2023, 7, 2, 0, 0, 0, 0, time.UTC)}, nil
+				},
+			}
+			mockBookingStore := &BookingStoreMock{
+				ListByRentalFunc: func(ctx context.Context, rentalID int, start, end time.Time) (model.Bookings, error) {
+					return model.Bookings{_booking}, nil
+				},
+			}
+			mockRentalStore := &RentalStoreMock{
+				GetByIDFunc: func(ctx context.Context, rentalID int) (*model.Rental, error) {
+					return &model.Rental{ID: int32(rentalID)}, tc.rentalErr
+				},
+			}
+
+			operation := calendarmanaging.NewOperation(mockCalendarStore, mockBookingStore, mockRentalStore)
+
+			calendar, err := operation.GetCalendar(context.Background(), 2, _start, _end)
+
+			if err != tc.expectedErr && !errors.Is(err, tc.expectedErr) {
+				t.Fatalf("Unexpected error:\nexpected: %v\ngot:      %v", tc.expectedErr, err)
+			}
+
+			if !cmp.Equal(calendar, tc.expectedResult) {
+				t.Fatalf("Unxpected calendar:\nexpected: %v\ngot:      %v", tc.expectedResult, calendar)
+			}
+		})
+	}
+}
+
+func TestOperation_UpdateCalendar(t *testing.T) {
+	testCases := []struct {
+		name                string
+		rules               *model.StayRules
+		blockedDays         []time.Time
+		expectedUpdateCalls int
+		expectedErr         error
+	}{
+		{
+			name:                "Free days",
+			rules:               &model.StayRules{MinNights: 2},
+			blockedDays:         []time.Time{time.Date(2023, 7, 2, 0, 0, 0, 0, time.UTC)},
+			expectedUpdateCalls: 1,
+		},
+		{
+			name:                "Inconsistent rules",
+			rules:               &model.StayRules{MinNights: 5, MaxNights: toPtr(2)},
+			expectedUpdateCalls: 0,
+			expectedErr:         svc.ErrInvalidRequestBody,
+		},
+		{
+			name:                "Day outside of the range",
+			rules:               &model.StayRules{MinNights: 1},
+			blockedDays:         []time.Time{time.Date(2023, 8, 2, 0, 0, 0, 0, time.UTC)},
+			expectedUpdateCalls: 0,
+			expectedErr:         svc.ErrInvalidRequestBody,
+		},
+		{
+			name:                "Booked day",
+			rules:               &model.StayRules{MinNights: 1},
+			blockedDays:         []time.Time{time.Date(2023, 7, 3, 0, 0, 0, 0, time.UTC)},
+			expectedUpdateCalls: 0,
+			expectedErr:         svc.ErrConflict,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockCalendarStore := &CalendarStoreMock{
+				GetStayRulesFunc: func(ctx context.Context, rentalID int) (*model.StayRules, error) {
+					return tc.rules, nil
+				},
+				ListBlockedDaysFunc: func(ctx context.Context, rentalID int, start, end time.Time) ([]time.Time, error) {
+					return tc.blockedDays, nil
+				},
+				UpdateFunc: func(ctx context.Context, rentalID int, rules *model.StayRules, start, end time.Time, blockedDays []time.Time) error {
+					return nil
+				},
+			}
+			mockBookingStore := &BookingStoreMock{
+				ListByRentalFunc: func(ctx context.Context, rentalID int, start, end time.Time) (model.Bookings, error) {
+					return model.Bookings{_booking}, nil
+				},
+			}
+			mockRentalStore := &RentalStoreMock{
+				GetByIDFunc: func(ctx context.Context, rentalID int) (*model.Rental, error) {
+					return &model.Rental{ID: int32(rentalID)}, nil
+				},
+			}
+
+			operation := calendarmanaging.NewOperation(mockCalendarStore, mockBookingStore, mockRentalStore)
+
+			_, err := operation.UpdateCalendar(context.Background(), 2, tc.rules, _start, _end, tc.blockedDays)
+
+			if calls := mockCalendarStore.UpdateCalls(); len(calls) != tc.expectedUpdateCalls {
+				t.Fatalf("Unexpected number of calls to Update:\nexpected: %d\ngot      %d", tc.expectedUpdateCalls, len(calls))
+			}
+
+			if err != tc.expectedErr && !errors.Is(err, tc.expectedErr) {
+				t.Fatalf("Unexpected error:\nexpected: %v\ngot:      %v", tc.expectedErr, err)
+			}
+		})
+	}
+}
+
+func toPtr[T any](v T) *T {
+	return &v
+}
@@ -0,0 +1,31 @@
+package calendarmanaging
+
+import (
+	"context"
+	"time"
+
+	"github.com/dragonator/rental-service/module/rental/internal/model"
+)
+
+// CalendarStore is a contract to a calendar storage.
+//
+//go:generate moq -rm -pkg calendarmanaging_test -out calendar_store_mock_test.go . CalendarStore
+type CalendarStore interface {
+	GetStayRules(ctx context.Context, rentalID int) (*model.StayRules, error)
+	ListBlockedDays(ctx context.Context, rentalID int, start, end time.Time) ([]time.Time, error)
+	Update(ctx context.Context, rentalID int, rules *model.StayRules, start, end time.Time, blockedDays []time.Time) error
+}
+
+// BookingStore is a contract to a booking storage.
+//
+//go:generate moq -rm -pkg calendarmanaging_test -out booking_store_mock_test.go . BookingStore
+type BookingStore interface {
+	ListByRental(ctx context.Context, rentalID int, start, end time.Time) (model.Bookings, error)
+}
+
+// RentalStore is a contract to a rental storage.
+//
+//go:generate moq -rm -pkg calendarmanaging_test -out rental_store_mock_test.go . RentalStore
+type RentalStore interface {
+	GetByID(ctx context.Context, rentalID int) (*model.Rental, error)
+}
@@ -0,0 +1,229 @@
+package conversationmanaging
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/dragonator/rental-service/module/rental/internal/http/service/svc"
+	"github.com/dragonator/rental-service/module/rental/internal/model"
+	"github.com/dragonator/rental-service/module/rental/internal/storage"
+)
+
+const (
+	// DefaultMessagePageSize is the number of messages listed when no limit is requested.
+	DefaultMessagePageSize = 20
+	// MaxMessagePageSize is the largest number of messages which can be listed at once.
+	MaxMessagePageSize = 100
+)
+
+// Operation provides an API for messaging between renters and owners of rentals.
+type Operation struct {
+	conversationStore ConversationStore
+	rentalStore       RentalStore
+	userStore         UserStore
+}
+
+// NewOperation is a contruction function for Operation.
+func NewOperation(conversationStore ConversationStore, rentalStore RentalStore, userStore UserStore) *Operation {
+	return &Operation{
+		conversationStore: conversationStore,
+		rentalStore:       rentalStore,
+		userStore:         userStore,
+	}
+}
+
+// GetConversationByID returns a conversation by id.
+func (o *Operation) GetConversationByID(ctx context.Context, conversationID int) (*model.Conversation, error) {
+	conversation, err := o.conversationStore.GetByID(ctx, conversationID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("%w: conversation with id %d", svc.ErrNotFound, conversationID)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("operation GetConversationByID: %w", err)
+	}
+
+	return conversation, nil
+}
+
+// ListConversations returns the conversations of a user with the number of messages unread by the user.
+func (o *Operation) ListConversations(ctx context.Context, userID int) (model.Conversations, error) {
+	if err := o.checkUserExists(ctx, userID); err != nil {
+		return nil, err
+	}
+
+	conversations, err := o.conversationStore.ListByUser(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("operation ListConversations: %w", err)
+	}
+
+	return conversations, nil
+}
+
+// CountUnread returns the number of messages unread by a user across all of their conversations.
+func (o *Operation) CountUnread(ctx context.Context, userID int) (int, error) {
+	if err := o.checkUserExists(ctx, userID); err != nil {
+		return 0, err
+	}
+
+	count, err := o.conversationStore.CountUnread(ctx, userID)
+	if err != nil {
+		return 0, fmt.Errorf("operation CountUnread: %w", err)
+	}
+
+	return count, nil
+}
+
+// StartConversation starts a conversation of a renter with the owner of a rental with the given first message.
+// A renter can start a single conversation per rental and owners cannot start one about their own rentals.
+func (o *Operation) StartConversation(ctx context.Context, rentalID int, message *model.Message) (*model.Conversation, error) {
+	if err := message.Validate(); err != nil {
+		return nil, fmt.Errorf("%w: %w", svc.ErrInvalidRequestBody, err)
+	}
+
+	rental, err := o.rentalStore.GetByID(ctx, rentalID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("%w: rental with id %d", svc.ErrNotFound, rentalID)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("operation StartConversation: %w", err)
+	}
+
+	if message.SenderID == rental.UserID {
+		return nil, fmt.Errorf("%w: owners cannot start a conversation about their own rental", svc.ErrInvalidRequestBody)
+	}
+
+	_, err = o.userStore.GetByID(ctx, int(message.SenderID))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("%w: user with id %d does not exist", svc.ErrInvalidRequestBody, message.SenderID)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("operation StartConversation: %w", err)
+	}
+
+	conversation := &model.Conversation{
+		RentalID: int32(rentalID),
+		RenterID: message.SenderID,
+		OwnerID:  rental.UserID,
+	}
+
+	conversationID, err := o.conversationStore.Create(ctx, conversation, message)
+	if errors.Is(err, storage.ErrConversationExists) {
+		return nil, fmt.Errorf("%w: user with id %d already has a conversation about rental with id %d",
+			svc.ErrConflict, message.SenderID, rentalID)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("operation StartConversation: %w", err)
+	}
+
+	return o.GetConversationByID(ctx, conversationID)
+}
+
+// ListMessages returns a page of the messages of a conversation, latest first, along with the
+// cursor of the following page, which is nil on the last page.
+func (o *Operation) ListMessages(ctx context.Context, conversationID int, page *storage.CursorPagination) (model.Messages, *int32, error) {
+	if _, err := o.GetConversationByID(ctx, conversationID); err != nil {
+		return nil, nil, err
+	}
+
+	limit := page.Limit
+	switch {
+	case limit == 0:
+		limit = DefaultMessagePageSize
+	case limit < 0 || limit > MaxMessagePageSize:
+		return nil, nil, fmt.Errorf("%w: limit must be between 1 and %d", svc.ErrInvalidQueryParameters, MaxMessagePageSize)
+	}
+
+	// One more message than requested tells whether a following page exists.
+	messages, err := o.conversationStore.ListMessages(ctx, conversationID, &storage.CursorPagination{
+		Limit: limit + 1,
+		After: page.After,
+	})
+	if err != nil {
+		return nil, nil, fmt.Errorf("operation ListMessages: %w", err)
+	}
+
+	if len(messages) <= limit {
+		return messages, nil, nil
+	}
+
+	messages = messages[:limit]
+
+	return messages, &messages[limit-1].ID, nil
+}
+
+// GetMessage returns a message of a conversation by id.
+func (o *Operation) GetMessage(ctx context.Context, conversationID, messageID int) (*model.Message, error) {
+	message, err := o.conversationStore.GetMessageByID(ctx, messageID)
+	if errors.Is(err, sql.ErrNoRows) || (err == nil && int(message.ConversationID) != conversationID) {
+		return nil, fmt.Errorf("%w: message with id %d in conversation with id %d", svc.ErrNotFound, messageID, conversationID)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("operation GetMessage: %w", err)
+	}
+
+	return message, nil
+}
+
+// PostMessage posts a message to a conversation on behalf of one of its participants and returns it.
+func (o *Operation) PostMessage(ctx context.Context, message *model.Message) (*model.Message, error) {
+	if err := message.Validate(); err != nil {
+		return nil, fmt.Errorf("%w: %w", svc.ErrInvalidRequestBody, err)
+	}
+
+	if err := o.checkParticipant(ctx, int(message.ConversationID), message.SenderID); err != nil {
+		return nil, err
+	}
+
+	messageID, err := o.conversationStore.CreateMessage(ctx, message)
+	if err != nil {
+		return nil, fmt.Errorf("operation PostMessage: %w", err)
+	}
+
+	created, err := o.conversationStore.GetMessageByID(ctx, messageID)
+	if err != nil {
+		return nil, fmt.Errorf("operation PostMessage: %w", err)
+	}
+
+	return created, nil
+}
+
+// MarkRead marks every message of a conversation as read by one of its participants.
+func (o *Operation) MarkRead(ctx context.Context, conversationID, userID int) error {
+	if err := o.checkParticipant(ctx, conversationID, int32(userID)); err != nil {
+		return err
+	}
+
+	if err := o.conversationStore.MarkRead(ctx, conversationID, userID); err != nil {
+		return fmt.Errorf("operation MarkRead: %w", err)
+	}
+
+	return nil
+}
+
+func (o *Operation) checkParticipant(ctx context.Context, conversationID int, userID int32) error {
+	conversation, err := o.GetConversationByID(ctx, conversationID)
+	if err != nil {
+		return err
+	}
+
+	if !conversation.HasParticipant(userID) {
+		return fmt.Errorf("%w: user with id %d does not take part in conversation with id %d",
+			svc.ErrForbidden, userID, conversationID)
+	}
+
+	return nil
+}
+
+func (o *Operation) checkUserExists(ctx context.Context, userID int) error {
+	_, err := o.userStore.GetByID(ctx, userID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return fmt.Errorf("%w: user with id %d", svc.ErrNotFound, userID)
+	}
+	if err != nil {
+		return fmt.Errorf("checking user: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,235 @@
+package conversationmanaging_test
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/dragonator/rental-service/module/rental/internal/http/service/svc"
+	"github.com/dragonator/rental-service/module/rental/internal/model"
+	"github.com/dragonator/rental-service/module/rental/internal/operation/conversationmanaging"
+	"github.com/dragonator/rental-service/module/rental/internal/storage"
+)
+
+func TestOperation_StartConversation(t *testing.T) {
+	testCases := []struct {
+		name                string
+		message             *model.Message
+		rentalErr           error
+		createErr           error
+		expectedCreateCalls int
+		expectedErr         error
+	}{
+		{
+			name:                "Renter question",
+			message:             &model.Message{SenderID: 2, Body: "Is the van pet friendly?"},
+			expectedCreateCalls: 1,
+		},
+		{
+			name:                "Empty body",
+			message:             &model.Message{SenderID: 2, Body: " "},
+			expectedCreateCalls: 0,
+			expectedErr:         svc.ErrInvalidRequestBody,
+		},
+		{
+			name:                "Owner of the rental",
+			message:             &model.Message{SenderID: 3, Body: "Is the van pet friendly?"},
+			expectedCreateCalls: 0,
+			expectedErr:         svc.ErrInvalidRequestBody,
+		},
+		{
+			name:                "Missing rental",
+			message:             &model.Message{SenderID: 2, Body: "Is the van pet friendly?"},
+			rentalErr:           sql.ErrNoRows,
+			expectedCreateCalls: 0,
+			expectedErr:         svc.ErrNotFound,
+		},
+		{
+			name:                "Existing conversation",
+			message:             &model.Message{SenderID: 2, Body: "Is the van pet friendly?"},
+			createErr:           storage.ErrConversationExists,
+			expectedCreateCalls: 1,
+			expectedErr:         svc.ErrConflict,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockConversationStore := &ConversationStoreMock{
+				CreateFunc: func(ctx context.Context, conversation *model.Conversation, message *model.Message) (int, error) {
+					return 4, tc.createErr
+				},
+				GetByIDFunc: func(ctx context.Context, conversationID int) (*model.Conversation, error) {
+					return &model.Conversation{ID: int32(conversationID)}, nil
+				},
+			}
+			mockRentalStore := &RentalStoreMock{
+				GetByIDFunc: func(ctx context.Context, rentalID int) (*model.Rental, error) {
+					return &model.Rental{ID: int32(rentalID), UserID: 3}, tc.rentalErr
+				},
+			}
+			mockUserStore := &UserStoreMock{
+				GetByIDFunc: func(ctx context.Context, userID int) (*model.User, error) {
+					return &model.User{ID: int32(userID)}, nil
+				},
+			}
+
+			operation := conversationmanaging.NewOperation(mockConversationStore, mockRentalStore, mockUserStore)
+
+			_, err := operation.StartConversation(context.Background(), 1, tc.message)
+
+			calls := mockConversationStore.CreateCalls()
+			if len(calls) != tc.expectedCreateCalls {
+				t.Fatalf("Unexpected number of calls to Create:\nexpected: %d\ngot      %d", tc.expectedCreateCalls, len(calls))
+			}
+
+			expectedConversation := &model.Conversation{RentalID: 1, RenterID: 2, OwnerID: 3}
+			if len(calls) == 1 && !cmp.Equal(calls[0].Conversation, expectedConversation) {
+				t.Fatalf("Unexpected conversation:\nexpected: %v\ngot:      %v", expectedConversation, calls[0].Conversation)
+			}
+
+			if err != tc.expectedErr && !errors.Is(err, tc.expectedErr) {
+				t.Fatalf("Unexpected error:\nexpected: %v\ngot:      %v", tc.expectedErr, err)
+			}
+		})
+	}
+}
+
+func TestOperation_ListMessages(t *testing.T) {
+	messages := model.Messages{{ID: 9}, {ID: 8}, {ID: 7}}
+
+	testCases := []struct {
+		name               string
+		page               *storage.CursorPagination
+		stored             model.Messages
+		expectedLimit      int
+		expectedMessages   model.Messages
+		expectedNextCursor *int32
+		expectedErr        error
+	}{
+		{
+			name:               "Following page exists",
+			page:               &storage.CursorPagination{Limit: 2},
+			stored:             messages,
+			expectedLimit:      3,
+			expectedMessages:   messages[:2],
+			expectedNextCursor: toPtr(int32(8)),
+		},
+		{
+			name:             "Last page",
+			page:             &storage.CursorPagination{Limit: 5, After: toPtr(int32(10))},
+			stored:           messages,
+			expectedLimit:    6,
+			expectedMessages: messages,
+		},
+		{
+			name:             "Default limit",
+			page:             &storage.CursorPagination{},
+			stored:           messages,
+			expectedLimit:    conversationmanaging.DefaultMessagePageSize + 1,
+			expectedMessages: messages,
+		},
+		{
+			name:        "Limit too large",
+			page:        &storage.CursorPagination{Limit: conversationmanaging.MaxMessagePageSize + 1},
+			expectedErr: svc.ErrInvalidQueryParameters,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockConversationStore := &ConversationStoreMock{
+				GetByIDFunc: func(ctx context.Context, conversationID int) (*model.Conversation, error) {
+					return &model.Conversation{ID: int32(conversationID)}, nil
+				},
+				ListMessagesFunc: func(ctx context.Context, conversationID int, page *storage.CursorPagination) (model.Messages, error) {
+					if len(tc.stored) > page.Limit {
+						return tc.stored[:page.Limit], nil
+					}
+
+					return tc.stored, nil
+				},
+			}
+
+			operation := conversationmanaging.NewOperation(mockConversationStore, &RentalStoreMock{}, &UserStoreMock{})
+
+			result, nextCursor, err := operation.ListMessages(context.Background(), 4, tc.page)
+
+			if err != tc.expectedErr && !errors.Is(err, tc.expectedErr) {
+				t.Fatalf("Unexpected error:\nexpected: %v\ngot:      %v", tc.expectedErr, err)
+			}
+
+			if tc.expectedErr != nil {
+				return
+			}
+
+			if calls := mockConversationStore.ListMessagesCalls(); calls[0].Page.Limit != tc.expectedLimit || calls[0].Page.After != tc.page.After {
+				t.Fatalf("Unexpected page:\nexpected: %d %v\ngot:      %d %v", tc.expectedLimit, tc.page.After, calls[0].Page.Limit, calls[0].Page.After)
+			}
+
+			if !cmp.Equal(result, tc.expectedMessages) {
+				t.Fatalf("Unexpected messages:\nexpected: %v\ngot:      %v", tc.expectedMessages, result)
+			}
+
+			if !cmp.Equal(nextCursor, tc.expectedNextCursor) {
+				t.Fatalf("Unexpected next cursor:\nexpected: %v\ngot:      %v", tc.expectedNextCursor, nextCursor)
+			}
+		})
+	}
+}
+
+func TestOperation_PostMessage(t *testing.T) {
+	testCases := []struct {
+		name                string
+		senderID            int32
+		expectedCreateCalls int
+		expectedErr         error
+	}{
+		{
+			name:                "Owner reply",
+			senderID:            3,
+			expectedCreateCalls: 1,
+		},
+		{
+			name:                "Outsider",
+			senderID:            5,
+			expectedCreateCalls: 0,
+			expectedErr:         svc.ErrForbidden,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockConversationStore := &ConversationStoreMock{
+				GetByIDFunc: func(ctx context.Context, conversationID int) (*model.Conversation, error) {
+					return &model.Conversation{ID: int32(conversationID), RenterID: 2, OwnerID: 3}, nil
+				},
+				CreateMessageFunc: func(ctx context.Context, message *model.Message) (int, error) {
+					return 7, nil
+				},
+				GetMessageByIDFunc: func(ctx context.Context, messageID int) (*model.Message, error) {
+					return &model.Message{ID: int32(messageID)}, nil
+				},
+			}
+
+			operation := conversationmanaging.NewOperation(mockConversationStore, &RentalStoreMock{}, &UserStoreMock{})
+
+			_, err := operation.PostMessage(context.Background(), &model.Message{ConversationID: 4, SenderID: tc.senderID, Body: "Yes"})
+
+			if calls := mockConversationStore.CreateMessageCalls(); len(calls) != tc.expectedCreateCalls {
+				t.Fatalf("Unexpected number of calls to CreateMessage:\nexpected: %d\ngot      %d", tc.expectedCreateCalls, len(calls))
+			}
+
+			if err != tc.expectedErr && !errors.Is(err, tc.expectedErr) {
+				t.Fatalf("Unexpected error:\nexpected: %v\ngot:      %v", tc.expectedErr, err)
+			}
+		})
+	}
+}
+
+func toPtr[T any](v T) *T {
+	return &v
+}
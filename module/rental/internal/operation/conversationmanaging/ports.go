@@ -0,0 +1,36 @@
+package conversationmanaging
+
+import (
+	"context"
+
+	"github.com/dragonator/rental-service/module/rental/internal/model"
+	"github.com/dragonator/rental-service/module/rental/internal/storage"
+)
+
+// ConversationStore is a contract to a conversation storage.
+//
+//go:generate moq -rm -pkg conversationmanaging_test -out conversation_store_mock_test.go . ConversationStore
+type ConversationStore interface {
+	GetByID(ctx context.Context, conversationID int) (*model.Conversation, error)
+	ListByUser(ctx context.Context, userID int) (model.Conversations, error)
+	CountUnread(ctx context.Context, userID int) (int, error)
+	Create(ctx context.Context, conversation *model.Conversation, message *model.Message) (int, error)
+	GetMessageByID(ctx context.Context, messageID int) (*model.Message, error)
+	ListMessages(ctx context.Context, conversationID int, page *storage.CursorPagination) (model.Messages, error)
+	CreateMessage(ctx context.Context, message *model.Message) (int, error)
+	MarkRead(ctx context.Context, conversationID, userID int) error
+}
+
+// RentalStore is a contract to a rental storage.
+//
+//go:generate moq -rm -pkg conversationmanaging_test -out rental_store_mock_test.go . RentalStore
+type RentalStore interface {
+	GetByID(ctx context.Context, rentalID int) (*model.Rental, error)
+}
+
+// UserStore is a contract to a user storage.
+//
+//go:generate moq -rm -pkg conversationmanaging_test -out user_store_mock_test.go . UserStore
+type UserStore interface {
+	GetByID(ctx context.Context, userID int) (*model.User, error)
+}
@@ -0,0 +1,277 @@
+package imagemanaging
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"database/sql"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	goimage "image"
+	"io"
+	"net/http"
+
+	"github.com/dragonator/rental-service/module/rental/internal/http/service/svc"
+	"github.com/dragonator/rental-service/module/rental/internal/imaging"
+	"github.com/dragonator/rental-service/module/rental/internal/model"
+)
+
+// uploadExtensions maps the content types accepted for uploaded images to the extension of their files.
+var uploadExtensions = map[string]string{
+	"image/jpeg": ".jpg",
+	"image/png":  ".png",
+	"image/gif":  ".gif",
+}
+
+// Operation provides an API for managing the images of rentals.
+type Operation struct {
+	imageStore    ImageStore
+	rentalStore   RentalStore
+	blobStore     BlobStore
+	maxUploadSize int64
+}
+
+// NewOperation is a contruction function for Operation.
+func NewOperation(imageStore ImageStore, rentalStore RentalStore, blobStore BlobStore, maxUploadSize int64) *Operation {
+	return &Operation{
+		imageStore:    imageStore,
+		rentalStore:   rentalStore,
+		blobStore:     blobStore,
+		maxUploadSize: maxUploadSize,
+	}
+}
+
+// ListImages returns the images of a rental in their order.
+func (o *Operation) ListImages(ctx context.Context, rentalID int) (model.RentalImages, error) {
+	if err := o.checkRentalExists(ctx, rentalID); err != nil {
+		return nil, err
+	}
+
+	images, err := o.imageStore.ListByRental(ctx, rentalID)
+	if err != nil {
+		return nil, fmt.Errorf("operation ListImages: %w", err)
+	}
+
+	return images, nil
+}
+
+// GetImage returns an image of a rental by id.
+func (o *Operation) GetImage(ctx context.Context, rentalID, imageID int) (*model.RentalImage, error) {
+	image, err := o.imageStore.GetByID(ctx, imageID)
+	if errors.Is(err, sql.ErrNoRows) || (err == nil && int(image.RentalID) != rentalID) {
+		return nil, fmt.Errorf("%w: image with id %d of rental with id %d", svc.ErrNotFound, imageID, rentalID)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("operation GetImage: %w", err)
+	}
+
+	return image, nil
+}
+
+// AddImage validates and appends a new image to the images of a rental and returns it with its assigned id.
+func (o *Operation) AddImage(ctx context.Context, image *model.RentalImage) (*model.RentalImage, error) {
+	if err := image.Validate(); err != nil {
+		return nil, fmt.Errorf("%w: %w", svc.ErrInvalidRequestBody, err)
+	}
+
+	if err := o.checkRentalExists(ctx, int(image.RentalID)); err != nil {
+		return nil, err
+	}
+
+	imageID, err := o.imageStore.Create(ctx, image)
+	if err != nil {
+		return nil, fmt.Errorf("operation AddImage: %w", err)
+	}
+
+	created, err := o.imageStore.GetByID(ctx, imageID)
+	if err != nil {
+		return nil, fmt.Errorf("operation AddImage: %w", err)
+	}
+
+	return created, nil
+}
+
+// UploadImage stores an uploaded image file along with its thumbnails and appends it to the images of a rental.
+// The file has to be a JPEG, PNG or GIF image of at most the configured upload size.
+func (o *Operation) UploadImage(ctx context.Context, rentalID int, caption string, file io.Reader) (*model.RentalImage, error) {
+	if err := o.checkRentalExists(ctx, rentalID); err != nil {
+		return nil, err
+	}
+
+	content, err := io.ReadAll(io.LimitReader(file, o.maxUploadSize+1))
+	if err != nil {
+		return nil, fmt.Errorf("%w: reading file: %w", svc.ErrInvalidRequestBody, err)
+	}
+
+	if int64(len(content)) > o.maxUploadSize {
+		return nil, fmt.Errorf("%w: file is larger than %d bytes", svc.ErrPayloadTooLarge, o.maxUploadSize)
+	}
+
+	contentType := http.DetectContentType(content)
+
+	extension, ok := uploadExtensions[contentType]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s, expected a jpeg, png or gif image", svc.ErrUnsupportedMediaType, contentType)
+	}
+
+	img, format, err := imaging.Decode(bytes.NewReader(content))
+	if errors.Is(err, imaging.ErrTooManyPixels) {
+		return nil, fmt.Errorf("%w: %w", svc.ErrPayloadTooLarge, err)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", svc.ErrInvalidRequestBody, err)
+	}
+
+	storageKey, err := newStorageKey(rentalID)
+	if err != nil {
+		return nil, fmt.Errorf("operation UploadImage: %w", err)
+	}
+
+	originalKey := storageKey + "/original" + extension
+
+	image := &model.RentalImage{
+		RentalID:   int32(rentalID),
+		URL:        o.blobStore.URL(originalKey),
+		Caption:    caption,
+		StorageKey: storageKey,
+		Thumbnails: make(map[string]string, len(imaging.Variants)),
+	}
+
+	if err := image.Validate(); err != nil {
+		return nil, fmt.Errorf("%w: %w", svc.ErrInvalidRequestBody, err)
+	}
+
+	if err := o.storeFiles(ctx, image, originalKey, content, img, format); err != nil {
+		_ = o.blobStore.DeleteAll(ctx, storageKey)
+		return nil, fmt.Errorf("operation UploadImage: %w", err)
+	}
+
+	imageID, err := o.imageStore.Create(ctx, image)
+	if err != nil {
+		_ = o.blobStore.DeleteAll(ctx, storageKey)
+		return nil, fmt.Errorf("operation UploadImage: %w", err)
+	}
+
+	created, err := o.imageStore.GetByID(ctx, imageID)
+	if err != nil {
+		return nil, fmt.Errorf("operation UploadImage: %w", err)
+	}
+
+	return created, nil
+}
+
+// ReorderImages moves the images of a rental into the order of the given ids, which must list
+// every image of the rental exactly once. It returns the images in their new order.
+func (o *Operation) ReorderImages(ctx context.Context, rentalID int, imageIDs []int32) (model.RentalImages, error) {
+	images, err := o.ListImages(ctx, rentalID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := checkPermutation(images, imageIDs); err != nil {
+		return nil, fmt.Errorf("%w: %w", svc.ErrInvalidRequestBody, err)
+	}
+
+	if err := o.imageStore.Reorder(ctx, rentalID, imageIDs); err != nil {
+		return nil, fmt.Errorf("operation ReorderImages: %w", err)
+	}
+
+	reordered, err := o.imageStore.ListByRental(ctx, rentalID)
+	if err != nil {
+		return nil, fmt.Errorf("operation ReorderImages: %w", err)
+	}
+
+	return reordered, nil
+}
+
+// RemoveImage removes an image from the images of a rental along with the files of uploaded images.
+func (o *Operation) RemoveImage(ctx context.Context, rentalID, imageID int) error {
+	image, err := o.GetImage(ctx, rentalID, imageID)
+	if err != nil {
+		return err
+	}
+
+	err = o.imageStore.Delete(ctx, rentalID, imageID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return fmt.Errorf("%w: image with id %d of rental with id %d", svc.ErrNotFound, imageID, rentalID)
+	}
+	if err != nil {
+		return fmt.Errorf("operation RemoveImage: %w", err)
+	}
+
+	if image.StorageKey != "" {
+		// The image is already gone from the rental, so files failing to be deleted are only left unused.
+		_ = o.blobStore.DeleteAll(ctx, image.StorageKey)
+	}
+
+	return nil
+}
+
+// storeFiles writes the original content of an uploaded image and its thumbnails to the blob store
+// and records the urls of the thumbnails on the image.
+func (o *Operation) storeFiles(ctx context.Context, image *model.RentalImage, originalKey string, content []byte, img goimage.Image, format string) error {
+	if err := o.blobStore.Put(ctx, originalKey, bytes.NewReader(content)); err != nil {
+		return fmt.Errorf("storing image: %w", err)
+	}
+
+	for _, variant := range imaging.Variants {
+		var buf bytes.Buffer
+		if err := imaging.Encode(&buf, imaging.Thumbnail(img, variant.Width), format); err != nil {
+			return fmt.Errorf("creating %s thumbnail: %w", variant.Name, err)
+		}
+
+		key := image.StorageKey + "/" + variant.Name + imaging.Extension(format)
+		if err := o.blobStore.Put(ctx, key, &buf); err != nil {
+			return fmt.Errorf("storing %s thumbnail: %w", variant.Name, err)
+		}
+
+		image.Thumbnails[variant.Name] = o.blobStore.URL(key)
+	}
+
+	return nil
+}
+
+func (o *Operation) checkRentalExists(ctx context.Context, rentalID int) error {
+	_, err := o.rentalStore.GetByID(ctx, rentalID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return fmt.Errorf("%w: rental with id %d", svc.ErrNotFound, rentalID)
+	}
+	if err != nil {
+		return fmt.Errorf("checking rental: %w", err)
+	}
+
+	return nil
+}
+
+// checkPermutation returns an error unless the ids list every one of the images exactly once.
+func checkPermutation(images model.RentalImages, imageIDs []int32) error {
+	if len(imageIDs) != len(images) {
+		return fmt.Errorf("expected the ids of all %d images of the rental", len(images))
+	}
+
+	pending := make(map[int32]bool, len(images))
+	for _, image := range images {
+		pending[image.ID] = true
+	}
+
+	for _, id := range imageIDs {
+		if !pending[id] {
+			return fmt.Errorf("image id %d is unknown or repeated", id)
+		}
+
+		delete(pending, id)
+	}
+
+	return nil
+}
+
+// newStorageKey returns a random key under which the files of an uploaded image of the rental are stored.
+func newStorageKey(rentalID int) (string, error) {
+	id := make([]byte, 16)
+	if _, err := rand.Read(id); err != nil {
+		return "", fmt.Errorf("generating storage key: %w", err)
+	}
+
+	return fmt.Sprintf("rentals/%d/images/%s", rentalID, hex.EncodeToString(id)), nil
+}
@@ -0,0 +1,315 @@
+package imagemanaging_test
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"errors"
+	"image"
+	"image/color/palette"
+	"image/gif"
+	"io"
+	"path"
+	"strings"
+	"testing"
+
+	"github.com/dragonator/rental-service/module/rental/internal/http/service/svc"
+	"github.com/dragonator/rental-service/module/rental/internal/imaging"
+	"github.com/dragonator/rental-service/module/rental/internal/model"
+	"github.com/dragonator/rental-service/module/rental/internal/operation/imagemanaging"
+)
+
+var _images = model.RentalImages{
+	{ID: 1, RentalID: 2, URL: "https://example.com/1.jpg"},
+	{ID: 2, RentalID: 2, URL: "https://example.com/2.jpg", Position: 1},
+	{ID: 3, RentalID: 2, URL: "https://example.com/3.jpg", Position: 2},
+}
+
+func TestOperation_AddImage(t *testing.T) {
+	testCases := []struct {
+		name                string
+		image               *model.RentalImage
+		rentalErr           error
+		expectedCreateCalls int
+		expectedErr         error
+	}{
+		{
+			name:                "Valid image",
+			image:               &model.RentalImage{RentalID: 2, URL: "https://example.com/4.jpg", Caption: "Kitchen"},
+			expectedCreateCalls: 1,
+		},
+		{
+			name:                "Relative url",
+			image:               &model.RentalImage{RentalID: 2, URL: "/images/4.jpg"},
+			expectedCreateCalls: 0,
+			expectedErr:         svc.ErrInvalidRequestBody,
+		},
+		{
+			name:                "Missing rental",
+			image:               &model.RentalImage{RentalID: 2, URL: "https://example.com/4.jpg"},
+			rentalErr:           sql.ErrNoRows,
+			expectedCreateCalls: 0,
+			expectedErr:         svc.ErrNotFound,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockImageStore := &ImageStoreMock{
+				CreateFunc: func(ctx context.Context, image *model.RentalImage) (int, error) {
+					return 4, nil
+				},
+				GetByIDFunc: func(ctx context.Context, imageID int) (*model.RentalImage, error) {
+					return &model.RentalImage{ID: int32(imageID)}, nil
+				},
+			}
+			mockRentalStore := &RentalStoreMock{
+				GetByIDFunc: func(ctx context.Context, rentalID int) (*model.Rental, error) {
+					return &model.Rental{ID: int32(rentalID)}, tc.rentalErr
+				},
+			}
+
+			operation := imagemanaging.NewOperation(mockImageStore, mockRentalStore, &BlobStoreMock{}, 1024)
+
+			_, err := operation.AddImage(context.Background(), tc.image)
+
+			if calls := mockImageStore.CreateCalls(); len(calls) != tc.expectedCreateCalls {
+				t.Fatalf("Unexpected number of calls to Create:\nexpected: %d\ngot      %d", tc.expectedCreateCalls, len(calls))
+			}
+
+			if err != tc.expectedErr && !errors.Is(err, tc.expectedErr) {
+				t.Fatalf("Unexpected error:\nexpected: %v\ngot:      %v", tc.expectedErr, err)
+			}
+		})
+	}
+}
+
+func TestOperation_UploadImage(t *testing.T) {
+	var png bytes.Buffer
+	if err := imaging.Encode(&png, image.NewRGBA(image.Rect(0, 0, 8, 4)), "png"); err != nil {
+		t.Fatalf("failed to encode image: %s", err)
+	}
+
+	// A GIF header declaring a 65535x65535 image without any pixel data.
+	hugeGIF := []byte("GIF89a\xff\xff\xff\xff\x00\x00\x00")
+	// A 0x0 image, which content sniffing accepts as a GIF.
+	var emptyGIF bytes.Buffer
+	if err := gif.Encode(&emptyGIF, image.NewPaletted(image.Rectangle{}, palette.Plan9), nil); err != nil {
+		t.Fatalf("failed to encode image: %s", err)
+	}
+
+	testCases := []struct {
+		name                string
+		content             []byte
+		expectedPutKeys     []string
+		expectedCreateCalls int
+		expectedErr         error
+	}{
+		{
+			name:                "PNG image",
+			content:             png.Bytes(),
+			expectedPutKeys:     []string{"original.png", "small.png", "medium.png"},
+			expectedCreateCalls: 1,
+		},
+		{
+			name:                "Too large",
+			content:             bytes.Repeat(png.Bytes(), 1024/png.Len()+1),
+			expectedCreateCalls: 0,
+			expectedErr:         svc.ErrPayloadTooLarge,
+		},
+		{
+			name:                "Too many pixels",
+			content:             hugeGIF,
+			expectedCreateCalls: 0,
+			expectedErr:         svc.ErrPayloadTooLarge,
+		},
+		{
+			name:                "Zero size image",
+			content:             emptyGIF.Bytes(),
+			expectedCreateCalls: 0,
+			expectedErr:         svc.ErrInvalidRequestBody,
+		},
+		{
+			name:                "Plain text",
+			content:             []byte("not an image"),
+			expectedCreateCalls: 0,
+			expectedErr:         svc.ErrUnsupportedMediaType,
+		},
+		{
+			name:                "Truncated image",
+			content:             png.Bytes()[:png.Len()/2],
+			expectedCreateCalls: 0,
+			expectedErr:         svc.ErrInvalidRequestBody,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockImageStore := &ImageStoreMock{
+				CreateFunc: func(ctx context.Context, image *model.RentalImage) (int, error) {
+					return 4, nil
+				},
+				GetByIDFunc: func(ctx context.Context, imageID int) (*model.RentalImage, error) {
+					return &model.RentalImage{ID: int32(imageID)}, nil
+				},
+			}
+			mockRentalStore := &RentalStoreMock{
+				GetByIDFunc: func(ctx context.Context, rentalID int) (*model.Rental, error) {
+					return &model.Rental{ID: int32(rentalID)}, nil
+				},
+			}
+			mockBlobStore := &BlobStoreMock{
+				PutFunc: func(ctx context.Context, key string, r io.Reader) error {
+					return nil
+				},
+				URLFunc: func(key string) string {
+					return "http://localhost:9090/uploads/" + key
+				},
+			}
+
+			operation := imagemanaging.NewOperation(mockImageStore, mockRentalStore, mockBlobStore, 1024)
+
+			_, err := operation.UploadImage(context.Background(), 2, "Kitchen", bytes.NewReader(tc.content))
+
+			if err != tc.expectedErr && !errors.Is(err, tc.expectedErr) {
+				t.Fatalf("Unexpected error:\nexpected: %v\ngot:      %v", tc.expectedErr, err)
+			}
+
+			putCalls := mockBlobStore.PutCalls()
+			if len(putCalls) != len(tc.expectedPutKeys) {
+				t.Fatalf("Unexpected number of calls to Put:\nexpected: %d\ngot      %d", len(tc.expectedPutKeys), len(putCalls))
+			}
+
+			for i, call := range putCalls {
+				if !strings.HasPrefix(call.Key, "rentals/2/images/") || path.Base(call.Key) != tc.expectedPutKeys[i] {
+					t.Fatalf("Unexpected key:\nexpected: rentals/2/images/.../%s\ngot:      %s", tc.expectedPutKeys[i], call.Key)
+				}
+			}
+
+			createCalls := mockImageStore.CreateCalls()
+			if len(createCalls) != tc.expectedCreateCalls {
+				t.Fatalf("Unexpected number of calls to Create:\nexpected: %d\ngot      %d", tc.expectedCreateCalls, len(createCalls))
+			}
+
+			if tc.expectedCreateCalls == 1 {
+				created := createCalls[0].Image
+				if created.URL != "http://localhost:9090/uploads/"+putCalls[0].Key || len(created.Thumbnails) != 2 || created.Caption != "Kitchen" {
+					t.Fatalf("Unexpected image: %+v", created)
+				}
+			}
+		})
+	}
+}
+
+func TestOperation_ReorderImages(t *testing.T) {
+	testCases := []struct {
+		name                 string
+		imageIDs             []int32
+		expectedReorderCalls int
+		expectedErr          error
+	}{
+		{
+			name:                 "All images",
+			imageIDs:             []int32{3, 1, 2},
+			expectedReorderCalls: 1,
+		},
+		{
+			name:                 "Missing image",
+			imageIDs:             []int32{3, 1},
+			expectedReorderCalls: 0,
+			expectedErr:          svc.ErrInvalidRequestBody,
+		},
+		{
+			name:                 "Repeated image",
+			imageIDs:             []int32{3, 1, 3},
+			expectedReorderCalls: 0,
+			expectedErr:          svc.ErrInvalidRequestBody,
+		},
+		{
+			name:                 "Image of another rental",
+			imageIDs:             []int32{3, 1, 7},
+			expectedReorderCalls: 0,
+			expectedErr:          svc.ErrInvalidRequestBody,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockImageStore := &ImageStoreMock{
+				ListByRentalFunc: func(ctx context.Context, rentalID int) (model.RentalImages, error) {
+					return _images, nil
+				},
+				ReorderFunc: func(ctx context.Context, rentalID int, imageIDs []int32) error {
+					return nil
+				},
+			}
+			mockRentalStore := &RentalStoreMock{
+				GetByIDFunc: func(ctx context.Context, rentalID int) (*model.Rental, error) {
+					return &model.Rental{ID: int32(rentalID)}, nil
+				},
+			}
+
+			operation := imagemanaging.NewOperation(mockImageStore, mockRentalStore, &BlobStoreMock{}, 1024)
+
+			_, err := operation.ReorderImages(context.Background(), 2, tc.imageIDs)
+
+			if calls := mockImageStore.ReorderCalls(); len(calls) != tc.expectedReorderCalls {
+				t.Fatalf("Unexpected number of calls to Reorder:\nexpected: %d\ngot      %d", tc.expectedReorderCalls, len(calls))
+			}
+
+			if err != tc.expectedErr && !errors.Is(err, tc.expectedErr) {
+				t.Fatalf("Unexpected error:\nexpected: %v\ngot:      %v", tc.expectedErr, err)
+			}
+		})
+	}
+}
+
+func TestOperation_RemoveImage(t *testing.T) {
+	testCases := []struct {
+		name                   string
+		deleteErr              error
+		expectedDeleteAllCalls int
+		expectedErr            error
+	}{
+		{
+			name:                   "Uploaded image",
+			expectedDeleteAllCalls: 1,
+		},
+		{
+			name:                   "Missing image",
+			deleteErr:              sql.ErrNoRows,
+			expectedDeleteAllCalls: 0,
+			expectedErr:            svc.ErrNotFound,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockImageStore := &ImageStoreMock{
+				GetByIDFunc: func(ctx context.Context, imageID int) (*model.RentalImage, error) {
+					return &model.RentalImage{ID: int32(imageID), RentalID: 2, StorageKey: "rentals/2/images/abc"}, nil
+				},
+				DeleteFunc: func(ctx context.Context, rentalID, imageID int) error {
+					return tc.deleteErr
+				},
+			}
+			mockBlobStore := &BlobStoreMock{
+				DeleteAllFunc: func(ctx context.Context, key string) error {
+					return nil
+				},
+			}
+
+			operation := imagemanaging.NewOperation(mockImageStore, &RentalStoreMock{}, mockBlobStore, 1024)
+
+			err := operation.RemoveImage(context.Background(), 2, 1)
+
+			if calls := mockBlobStore.DeleteAllCalls(); len(calls) != tc.expectedDeleteAllCalls {
+				t.Fatalf("Unexpected number of calls to DeleteAll:\nexpected: %d\ngot      %d", tc.expectedDeleteAllCalls, len(calls))
+			}
+
+			if err != tc.expectedErr && !errors.Is(err, tc.expectedErr) {
+				t.Fatalf("Unexpected error:\nexpected: %v\ngot:      %v", tc.expectedErr, err)
+			}
+		})
+	}
+}
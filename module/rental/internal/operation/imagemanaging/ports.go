@@ -0,0 +1,35 @@
+package imagemanaging
+
+import (
+	"context"
+	"io"
+
+	"github.com/dragonator/rental-service/module/rental/internal/model"
+)
+
+// ImageStore is a contract to a rental image storage.
+//
+//go:generate moq -rm -pkg imagemanaging_test -out image_store_mock_test.go . ImageStore
+type ImageStore interface {
+	GetByID(ctx context.Context, imageID int) (*model.RentalImage, error)
+	ListByRental(ctx context.Context, rentalID int) (model.RentalImages, error)
+	Create(ctx context.Context, image *model.RentalImage) (int, error)
+	Reorder(ctx context.Context, rentalID int, imageIDs []int32) error
+	Delete(ctx context.Context, rentalID, imageID int) error
+}
+
+// RentalStore is a contract to a rental storage.
+//
+//go:generate moq -rm -pkg imagemanaging_test -out rental_store_mock_test.go . RentalStore
+type RentalStore interface {
+	GetByID(ctx context.Context, rentalID int) (*model.Rental, error)
+}
+
+// BlobStore is a contract to a storage of uploaded files.
+//
+//go:generate moq -rm -pkg imagemanaging_test -out blob_store_mock_test.go . BlobStore
+type BlobStore interface {
+	Put(ctx context.Context, key string, r io.Reader) error
+	DeleteAll(ctx context.Context, key string) error
+	URL(key string) string
+}
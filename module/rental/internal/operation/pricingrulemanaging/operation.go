@@ -0,0 +1,155 @@
+package pricingrulemanaging
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/dragonator/rental-service/module/rental/internal/http/service/svc"
+	"github.com/dragonator/rental-service/module/rental/internal/model"
+)
+
+// Operation provides an API for managing the pricing rules of rentals.
+type Operation struct {
+	pricingRuleStore PricingRuleStore
+	rentalStore      RentalStore
+}
+
+// NewOperation is a contruction function for Operation.
+func NewOperation(pricingRuleStore PricingRuleStore, rentalStore RentalStore) *Operation {
+	return &Operation{
+		pricingRuleStore: pricingRuleStore,
+		rentalStore:      rentalStore,
+	}
+}
+
+// ListPricingRules returns the pricing rules of a rental.
+func (o *Operation) ListPricingRules(ctx context.Context, rentalID int) (model.PricingRules, error) {
+	if err := o.checkRentalExists(ctx, rentalID); err != nil {
+		return nil, err
+	}
+
+	rules, err := o.pricingRuleStore.ListByRental(ctx, rentalID)
+	if err != nil {
+		return nil, fmt.Errorf("operation ListPricingRules: %w", err)
+	}
+
+	return rules, nil
+}
+
+// CreatePricingRule validates and stores a new pricing rule of a rental and returns it with its assigned id.
+// A rule which overlaps with another rule of the rental is rejected.
+func (o *Operation) CreatePricingRule(ctx context.Context, rule *model.PricingRule) (*model.PricingRule, error) {
+	if err := rule.Validate(); err != nil {
+		return nil, fmt.Errorf("%w: %w", svc.ErrInvalidRequestBody, err)
+	}
+
+	if err := o.checkRentalExists(ctx, int(rule.RentalID)); err != nil {
+		return nil, err
+	}
+
+	if err := o.checkOverlaps(ctx, rule); err != nil {
+		return nil, err
+	}
+
+	ruleID, err := o.pricingRuleStore.Create(ctx, rule)
+	if err != nil {
+		return nil, fmt.Errorf("operation CreatePricingRule: %w", err)
+	}
+
+	created, err := o.pricingRuleStore.GetByID(ctx, ruleID)
+	if err != nil {
+		return nil, fmt.Errorf("operation CreatePricingRule: %w", err)
+	}
+
+	return created, nil
+}
+
+// UpdatePricingRule validates and overwrites an existing pricing rule of a rental and returns the stored rule.
+// A rule which overlaps with another rule of the rental is rejected.
+func (o *Operation) UpdatePricingRule(ctx context.Context, rule *model.PricingRule) (*model.PricingRule, error) {
+	if err := rule.Validate(); err != nil {
+		return nil, fmt.Errorf("%w: %w", svc.ErrInvalidRequestBody, err)
+	}
+
+	if err := o.checkRuleExists(ctx, int(rule.RentalID), int(rule.ID)); err != nil {
+		return nil, err
+	}
+
+	if err := o.checkOverlaps(ctx, rule); err != nil {
+		return nil, err
+	}
+
+	err := o.pricingRuleStore.Update(ctx, rule)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("%w: pricing rule with id %d", svc.ErrNotFound, rule.ID)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("operation UpdatePricingRule: %w", err)
+	}
+
+	updated, err := o.pricingRuleStore.GetByID(ctx, int(rule.ID))
+	if err != nil {
+		return nil, fmt.Errorf("operation UpdatePricingRule: %w", err)
+	}
+
+	return updated, nil
+}
+
+// DeletePricingRule removes a pricing rule of a rental.
+func (o *Operation) DeletePricingRule(ctx context.Context, rentalID, ruleID int) error {
+	if err := o.checkRuleExists(ctx, rentalID, ruleID); err != nil {
+		return err
+	}
+
+	err := o.pricingRuleStore.Delete(ctx, ruleID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return fmt.Errorf("%w: pricing rule with id %d", svc.ErrNotFound, ruleID)
+	}
+	if err != nil {
+		return fmt.Errorf("operation DeletePricingRule: %w", err)
+	}
+
+	return nil
+}
+
+func (o *Operation) checkRentalExists(ctx context.Context, rentalID int) error {
+	_, err := o.rentalStore.GetByID(ctx, rentalID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return fmt.Errorf("%w: rental with id %d", svc.ErrNotFound, rentalID)
+	}
+	if err != nil {
+		return fmt.Errorf("checking rental: %w", err)
+	}
+
+	return nil
+}
+
+// checkRuleExists returns an error when the rule does not exist or belongs to another rental.
+func (o *Operation) checkRuleExists(ctx context.Context, rentalID, ruleID int) error {
+	rule, err := o.pricingRuleStore.GetByID(ctx, ruleID)
+	if errors.Is(err, sql.ErrNoRows) || (err == nil && int(rule.RentalID) != rentalID) {
+		return fmt.Errorf("%w: pricing rule with id %d of rental with id %d", svc.ErrNotFound, ruleID, rentalID)
+	}
+	if err != nil {
+		return fmt.Errorf("checking pricing rule: %w", err)
+	}
+
+	return nil
+}
+
+func (o *Operation) checkOverlaps(ctx context.Context, rule *model.PricingRule) error {
+	rules, err := o.pricingRuleStore.ListByRental(ctx, int(rule.RentalID))
+	if err != nil {
+		return fmt.Errorf("checking pricing rules: %w", err)
+	}
+
+	for _, other := range rules {
+		if other.ID != rule.ID && rule.Overlaps(other) {
+			return fmt.Errorf("%w: rule overlaps with pricing rule with id %d", svc.ErrConflict, other.ID)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,224 @@
+package pricingrulemanaging_test
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/dragonator/rental-service/module/rental/internal/http/service/svc"
+	"github.com/dragonator/rental-service/module/rental/internal/model"
+	"github.com/dragonator/rental-service/module/rental/internal/operation/pricingrulemanaging"
+)
+
+var (
+	_seasonStart = time.Date(2023, 7, 1, 0, 0, 0, 0, time.UTC)
+	_seasonEnd   = time.Date(2023, 7, 10, 0, 0, 0, 0, time.UTC)
+	_rules       = model.PricingRules{
+		{ID: 1, RentalID: 2, Type: model.PricingRuleSeason, StartDate: &_seasonStart, EndDate: &_seasonEnd, PricePerDay: toPtr[int64](20000)},
+		{ID: 2, RentalID: 2, Type: model.PricingRuleWeeklyDiscount, Percent: toPtr(10.0)},
+	}
+)
+
+func TestOperation_CreatePricingRule(t *testing.T) {
+	laterStart := time.Date(2023, 7, 10, 0, 0, 0, 0, time.UTC)
+	laterEnd := time.Date(2023, 7, 20, 0, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		name                string
+		rule                *model.PricingRule
+		rentalErr           error
+		expectedCreateCalls int
+		expectedErr         error
+	}{
+		{
+			name:                "Adjacent season",
+			rule:                &model.PricingRule{RentalID: 2, Type: model.PricingRuleSeason, StartDate: &laterStart, EndDate: &laterEnd, PricePerDay: toPtr[int64](18000)},
+			expectedCreateCalls: 1,
+		},
+		{
+			name:                "New rule type",
+			rule:                &model.PricingRule{RentalID: 2, Type: model.PricingRuleWeekendSurcharge, Percent: toPtr(15.0)},
+			expectedCreateCalls: 1,
+		},
+		{
+			name:                "Invalid rule",
+			rule:                &model.PricingRule{RentalID: 2, Type: model.PricingRuleMonthlyDiscount, Percent: toPtr(100.0)},
+			expectedCreateCalls: 0,
+			expectedErr:         svc.ErrInvalidRequestBody,
+		},
+		{
+			name:                "Missing rental",
+			rule:                &model.PricingRule{RentalID: 2, Type: model.PricingRuleWeekendSurcharge, Percent: toPtr(15.0)},
+			rentalErr:           sql.ErrNoRows,
+			expectedCreateCalls: 0,
+			expectedErr:         svc.ErrNotFound,
+		},
+		{
+			name:                "Overlapping season",
+			rule:                &model.PricingRule{RentalID: 2, Type: model.PricingRuleSeason, StartDate: &_seasonStart, EndDate: &laterEnd, PricePerDay: toPtr[int64](18000)},
+			expectedCreateCalls: 0,
+			expectedErr:         svc.ErrConflict,
+		},
+		{
+			name:                "Duplicate discount",
+			rule:                &model.PricingRule{RentalID: 2, Type: model.PricingRuleWeeklyDiscount, Percent: toPtr(5.0)},
+			expectedCreateCalls: 0,
+			expectedErr:         svc.ErrConflict,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockPricingRuleStore := newPricingRuleStoreMock()
+			mockRentalStore := &RentalStoreMock{
+				GetByIDFunc: func(ctx context.Context, rentalID int) (*model.Rental, error) {
+					return &model.Rental{ID: int32(rentalID)}, tc.rentalErr
+				},
+			}
+
+			operation := pricingrulemanaging.NewOperation(mockPricingRuleStore, mockRentalStore)
+
+			_, err := operation.CreatePricingRule(context.Background(), tc.rule)
+
+			if calls := mockPricingRuleStore.CreateCalls(); len(calls) != tc.expectedCreateCalls {
+				t.Fatalf("Unexpected number of calls to Create:\nexpected: %d\ngot      %d", tc.expectedCreateCalls, len(calls))
+			}
+
+			if err != tc.expectedErr && !errors.Is(err, tc.expectedErr) {
+				t.Fatalf("Unexpected error:\nexpected: %v\ngot:      %v", tc.expectedErr, err)
+			}
+		})
+	}
+}
+
+func TestOperation_UpdatePricingRule(t *testing.T) {
+	testCases := []struct {
+		name                string
+		rule                *model.PricingRule
+		expectedUpdateCalls int
+		expectedErr         error
+	}{
+		{
+			name:                "Changed season",
+			rule:                &model.PricingRule{ID: 1, RentalID: 2, Type: model.PricingRuleSeason, StartDate: &_seasonStart, EndDate: &_seasonEnd, PricePerDay: toPtr[int64](22000)},
+			expectedUpdateCalls: 1,
+		},
+		{
+			name:                "Rule of another rental",
+			rule:                &model.PricingRule{ID: 1, RentalID: 3, Type: model.PricingRuleSeason, StartDate: &_seasonStart, EndDate: &_seasonEnd, PricePerDay: toPtr[int64](22000)},
+			expectedUpdateCalls: 0,
+			expectedErr:         svc.ErrNotFound,
+		},
+		{
+			name:                "Missing rule",
+			rule:                &model.PricingRule{ID: 5, RentalID: 2, Type: model.PricingRuleWeekendSurcharge, Percent: toPtr(15.0)},
+			expectedUpdateCalls: 0,
+			expectedErr:         svc.ErrNotFound,
+		},
+		{
+			name:                "Overlapping rule",
+			rule:                &model.PricingRule{ID: 1, RentalID: 2, Type: model.PricingRuleWeeklyDiscount, Percent: toPtr(15.0)},
+			expectedUpdateCalls: 0,
+			expectedErr:         svc.ErrConflict,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockPricingRuleStore := newPricingRuleStoreMock()
+
+			operation := pricingrulemanaging.NewOperation(mockPricingRuleStore, &RentalStoreMock{})
+
+			_, err := operation.UpdatePricingRule(context.Background(), tc.rule)
+
+			if calls := mockPricingRuleStore.UpdateCalls(); len(calls) != tc.expectedUpdateCalls {
+				t.Fatalf("Unexpected number of calls to Update:\nexpected: %d\ngot      %d", tc.expectedUpdateCalls, len(calls))
+			}
+
+			if err != tc.expectedErr && !errors.Is(err, tc.expectedErr) {
+				t.Fatalf("Unexpected error:\nexpected: %v\ngot:      %v", tc.expectedErr, err)
+			}
+		})
+	}
+}
+
+func TestOperation_DeletePricingRule(t *testing.T) {
+	testCases := []struct {
+		name                string
+		rentalID            int
+		ruleID              int
+		expectedDeleteCalls int
+		expectedErr         error
+	}{
+		{
+			name:                "Existing rule",
+			rentalID:            2,
+			ruleID:              2,
+			expectedDeleteCalls: 1,
+		},
+		{
+			name:                "Rule of another rental",
+			rentalID:            3,
+			ruleID:              2,
+			expectedDeleteCalls: 0,
+			expectedErr:         svc.ErrNotFound,
+		},
+		{
+			name:                "Missing rule",
+			rentalID:            2,
+			ruleID:              5,
+			expectedDeleteCalls: 0,
+			expectedErr:         svc.ErrNotFound,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockPricingRuleStore := newPricingRuleStoreMock()
+
+			operation := pricingrulemanaging.NewOperation(mockPricingRuleStore, &RentalStoreMock{})
+
+			err := operation.DeletePricingRule(context.Background(), tc.rentalID, tc.ruleID)
+
+			if calls := mockPricingRuleStore.DeleteCalls(); len(calls) != tc.expectedDeleteCalls {
+				t.Fatalf("Unexpected number of calls to Delete:\nexpected: %d\ngot      %d", tc.expectedDeleteCalls, len(calls))
+			}
+
+			if err != tc.expectedErr && !errors.Is(err, tc.expectedErr) {
+				t.Fatalf("Unexpected error:\nexpected: %v\ngot:      %v", tc.expectedErr, err)
+			}
+		})
+	}
+}
+
+func newPricingRuleStoreMock() *PricingRuleStoreMock {
+	return &PricingRuleStoreMock{
+		GetByIDFunc: func(ctx context.Context, ruleID int) (*model.PricingRule, error) {
+			for _, rule := range _rules {
+				if int(rule.ID) == ruleID {
+					return rule, nil
+				}
+			}
+
+			return nil, sql.ErrNoRows
+		},
+		ListByRentalFunc: func(ctx context.Context, rentalID int) (model.PricingRules, error) {
+			return _rules, nil
+		},
+		CreateFunc: func(ctx context.Context, rule *model.PricingRule) (int, error) {
+			return 1, nil
+		},
+		UpdateFunc: func(ctx context.Context, rule *model.PricingRule) error {
+			return nil
+		},
+		DeleteFunc: func(ctx context.Context, ruleID int) error {
+			return nil
+		},
+	}
+}
+
+func toPtr[T any](v T) *T {
+	return &v
+}
@@ -0,0 +1,25 @@
+package pricingrulemanaging
+
+import (
+	"context"
+
+	"github.com/dragonator/rental-service/module/rental/internal/model"
+)
+
+// PricingRuleStore is a contract to a pricing rule storage.
+//
+//go:generate moq -rm -pkg pricingrulemanaging_test -out pricing_rule_store_mock_test.go . PricingRuleStore
+type PricingRuleStore interface {
+	GetByID(ctx context.Context, ruleID int) (*model.PricingRule, error)
+	ListByRental(ctx context.Context, rentalID int) (model.PricingRules, error)
+	Create(ctx context.Context, rule *model.PricingRule) (int, error)
+	Update(ctx context.Context, rule *model.PricingRule) error
+	Delete(ctx context.Context, ruleID int) error
+}
+
+// RentalStore is a contract to a rental storage.
+//
+//go:generate moq -rm -pkg pricingrulemanaging_test -out rental_store_mock_test.go . RentalStore
+type RentalStore interface {
+	GetByID(ctx context.Context, rentalID int) (*model.Rental, error)
+}
@@ -0,0 +1,55 @@
+package rentalarchiving
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/dragonator/rental-service/module/rental/internal/http/service/svc"
+	"github.com/dragonator/rental-service/module/rental/internal/model"
+)
+
+// Operation provides an API for archiving and restoring rentals.
+type Operation struct {
+	rentalStore RentalStore
+}
+
+// NewOperation is a contruction function for Operation.
+func NewOperation(rentalStore RentalStore) *Operation {
+	return &Operation{
+		rentalStore: rentalStore,
+	}
+}
+
+// ArchiveRental archives the rental with the given id instead of deleting it.
+func (o *Operation) ArchiveRental(ctx context.Context, rentalID int) error {
+	err := o.rentalStore.Archive(ctx, rentalID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return fmt.Errorf("%w: rental with id %d", svc.ErrNotFound, rentalID)
+	}
+	if err != nil {
+		return fmt.Errorf("operation ArchiveRental: %w", err)
+	}
+
+	return nil
+}
+
+// RestoreRental makes an archived rental with the given id active again and returns it.
+// Restoring an active rental has no effect.
+func (o *Operation) RestoreRental(ctx context.Context, rentalID int) (*model.Rental, error) {
+	err := o.rentalStore.Restore(ctx, rentalID)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("operation RestoreRental: %w", err)
+	}
+
+	rental, err := o.rentalStore.GetByID(ctx, rentalID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("%w: rental with id %d", svc.ErrNotFound, rentalID)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("operation RestoreRental: %w", err)
+	}
+
+	return rental, nil
+}
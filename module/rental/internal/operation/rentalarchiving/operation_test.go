@@ -0,0 +1,150 @@
+package rentalarchiving_test
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/dragonator/rental-service/module/rental/internal/http/service/svc"
+	"github.com/dragonator/rental-service/module/rental/internal/model"
+	"github.com/dragonator/rental-service/module/rental/internal/operation/rentalarchiving"
+)
+
+var _rental = &model.Rental{
+	ID:          1,
+	UserID:      2,
+	Name:        "Rental 1",
+	Type:        "Type 1",
+	PricePerDay: 1000,
+	User: &model.User{
+		ID:        2,
+		FirstName: "FirstName 1",
+		LastName:  "LastName 1",
+	},
+}
+
+func TestOperation_ArchiveRental(t *testing.T) {
+	testCases := []struct {
+		name            string
+		mockRentalStore *RentalStoreMock
+		expectedErr     error
+	}{
+		{
+			name: "Active rental",
+			mockRentalStore: &RentalStoreMock{
+				ArchiveFunc: func(ctx context.Context, rentalID int) error {
+					return nil
+				},
+			},
+		},
+		{
+			name: "Missing rental",
+			mockRentalStore: &RentalStoreMock{
+				ArchiveFunc: func(ctx context.Context, rentalID int) error {
+					return sql.ErrNoRows
+				},
+			},
+			expectedErr: svc.ErrNotFound,
+		},
+		{
+			name: "Other error",
+			mockRentalStore: &RentalStoreMock{
+				ArchiveFunc: func(ctx context.Context, rentalID int) error {
+					return sql.ErrConnDone
+				},
+			},
+			expectedErr: sql.ErrConnDone,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			operation := rentalarchiving.NewOperation(tc.mockRentalStore)
+
+			err := operation.ArchiveRental(context.Background(), 1)
+
+			calls := tc.mockRentalStore.ArchiveCalls()
+			if len(calls) != 1 {
+				t.Fatalf("Unexpected number of calls to Archive:\nexpected: 1\ngot      %d", len(calls))
+			}
+
+			if calls[0].RentalID != 1 {
+				t.Fatalf("Unexpected rental id:\nexpected: 1\ngot:      %v", calls[0].RentalID)
+			}
+
+			if err != tc.expectedErr && !errors.Is(err, tc.expectedErr) {
+				t.Fatalf("Unexpected error:\nexpected: %v\ngot:      %v", tc.expectedErr, err)
+			}
+		})
+	}
+}
+
+func TestOperation_RestoreRental(t *testing.T) {
+	testCases := []struct {
+		name            string
+		mockRentalStore *RentalStoreMock
+		expectedResult  *model.Rental
+		expectedErr     error
+	}{
+		{
+			name: "Archived rental",
+			mockRentalStore: &RentalStoreMock{
+				RestoreFunc: func(ctx context.Context, rentalID int) error {
+					return nil
+				},
+				GetByIDFunc: func(ctx context.Context, rentalID int) (*model.Rental, error) {
+					return _rental, nil
+				},
+			},
+			expectedResult: _rental,
+		},
+		{
+			name: "Active rental",
+			mockRentalStore: &RentalStoreMock{
+				RestoreFunc: func(ctx context.Context, rentalID int) error {
+					return sql.ErrNoRows
+				},
+				GetByIDFunc: func(ctx context.Context, rentalID int) (*model.Rental, error) {
+					return _rental, nil
+				},
+			},
+			expectedResult: _rental,
+		},
+		{
+			name: "Missing rental",
+			mockRentalStore: &RentalStoreMock{
+				RestoreFunc: func(ctx context.Context, rentalID int) error {
+					return sql.ErrNoRows
+				},
+				GetByIDFunc: func(ctx context.Context, rentalID int) (*model.Rental, error) {
+					return nil, sql.ErrNoRows
+				},
+			},
+			expectedErr: svc.ErrNotFound,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			operation := rentalarchiving.NewOperation(tc.mockRentalStore)
+
+			rental, err := operation.RestoreRental(context.Background(), 1)
+
+			calls := tc.mockRentalStore.RestoreCalls()
+			if len(calls) != 1 {
+				t.Fatalf("Unexpected number of calls to Restore:\nexpected: 1\ngot      %d", len(calls))
+			}
+
+			if err != tc.expectedErr && !errors.Is(err, tc.expectedErr) {
+				t.Fatalf("Unexpected error:\nexpected: %v\ngot:      %v", tc.expectedErr, err)
+			}
+
+			if !cmp.Equal(rental, tc.expectedResult) {
+				t.Fatalf("Unxpected rental:\nexpected: %v\ngot:      %v", tc.expectedResult, rental)
+			}
+		})
+	}
+}
@@ -0,0 +1,16 @@
+package rentalarchiving
+
+import (
+	"context"
+
+	"github.com/dragonator/rental-service/module/rental/internal/model"
+)
+
+// RentalStore is a contract to a rental storage.
+//
+//go:generate moq -rm -pkg rentalarchiving_test -out rental_store_mock_test.go . RentalStore
+type RentalStore interface {
+	Archive(ctx context.Context, rentalID int) error
+	Restore(ctx context.Context, rentalID int) error
+	GetByID(ctx context.Context, rentalID int) (*model.Rental, error)
+}
@@ -0,0 +1,52 @@
+package rentalcreating
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/dragonator/rental-service/module/rental/internal/http/service/svc"
+	"github.com/dragonator/rental-service/module/rental/internal/model"
+)
+
+// Operation provides an API for creating rentals.
+type Operation struct {
+	rentalStore RentalStore
+	userStore   UserStore
+}
+
+// NewOperation is a contruction function for Operation.
+func NewOperation(rentalStore RentalStore, userStore UserStore) *Operation {
+	return &Operation{
+		rentalStore: rentalStore,
+		userStore:   userStore,
+	}
+}
+
+// CreateRental validates and stores the given rental. It returns the rental as stored.
+func (o *Operation) CreateRental(ctx context.Context, rental *model.Rental) (*model.Rental, error) {
+	if err := rental.Validate(); err != nil {
+		return nil, fmt.Errorf("%w: %w", svc.ErrInvalidRequestBody, err)
+	}
+
+	_, err := o.userStore.GetByID(ctx, int(rental.UserID))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("%w: user with id %d does not exist", svc.ErrInvalidRequestBody, rental.UserID)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("operation CreateRental: %w", err)
+	}
+
+	rentalID, err := o.rentalStore.Create(ctx, rental)
+	if err != nil {
+		return nil, fmt.Errorf("operation CreateRental: %w", err)
+	}
+
+	created, err := o.rentalStore.GetByID(ctx, rentalID)
+	if err != nil {
+		return nil, fmt.Errorf("operation CreateRental: %w", err)
+	}
+
+	return created, nil
+}
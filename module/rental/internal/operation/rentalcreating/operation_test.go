@@ -0,0 +1,154 @@
+package rentalcreating_test
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/dragonator/rental-service/module/rental/internal/http/service/svc"
+	"github.com/dragonator/rental-service/module/rental/internal/model"
+	"github.com/dragonator/rental-service/module/rental/internal/operation/rentalcreating"
+)
+
+var (
+	_user = &model.User{
+		ID:        2,
+		FirstName: "FirstName 1",
+		LastName:  "LastName 1",
+	}
+	_rental = &model.Rental{
+		ID:              1,
+		UserID:          2,
+		Name:            "Rental 1",
+		Type:            "Type 1",
+		Description:     "Description 1",
+		Sleeps:          4,
+		PricePerDay:     1000,
+		HomeCity:        "City 1",
+		HomeState:       "State 1",
+		HomeZip:         "Zip 1",
+		HomeCountry:     "Country 1",
+		VehicleMake:     "Make 1",
+		VehicleModel:    "Model 1",
+		VehicleYear:     2022,
+		VehicleLength:   10.5,
+		Latitude:        40.1234,
+		Longitude:       -75.5678,
+		PrimaryImageURL: "ImageURL 1",
+		User:            _user,
+	}
+)
+
+func TestOperation_CreateRental(t *testing.T) {
+	testCases := []struct {
+		name                string
+		rental              *model.Rental
+		mockRentalStore     *RentalStoreMock
+		mockUserStore       *UserStoreMock
+		expectedCreateCalls int
+		expectedResult      *model.Rental
+		expectedErr         error
+	}{
+		{
+			name:   "Valid rental",
+			rental: &model.Rental{UserID: 2, Name: "Rental 1", Type: "Type 1", PricePerDay: 1000, Latitude: 40.1234, Longitude: -75.5678, CancellationPolicy: model.CancellationPolicyModerate},
+			mockRentalStore: &RentalStoreMock{
+				CreateFunc: func(ctx context.Context, rental *model.Rental) (int, error) {
+					return 1, nil
+				},
+				GetByIDFunc: func(ctx context.Context, rentalID int) (*model.Rental, error) {
+					return _rental, nil
+				},
+			},
+			mockUserStore: &UserStoreMock{
+				GetByIDFunc: func(ctx context.Context, userID int) (*model.User, error) {
+					return _user, nil
+				},
+			},
+			expectedCreateCalls: 1,
+			expectedResult:      _rental,
+		},
+		{
+			name:                "Missing name",
+			rental:              &model.Rental{UserID: 2, Type: "Type 1", PricePerDay: 1000, Latitude: 40.1234, Longitude: -75.5678, CancellationPolicy: model.CancellationPolicyModerate},
+			mockRentalStore:     &RentalStoreMock{},
+			mockUserStore:       &UserStoreMock{},
+			expectedCreateCalls: 0,
+			expectedErr:         svc.ErrInvalidRequestBody,
+		},
+		{
+			name:                "Missing coordinates",
+			rental:              &model.Rental{UserID: 2, Name: "Rental 1", Type: "Type 1", PricePerDay: 1000},
+			mockRentalStore:     &RentalStoreMock{},
+			mockUserStore:       &UserStoreMock{},
+			expectedCreateCalls: 0,
+			expectedErr:         svc.ErrInvalidRequestBody,
+		},
+		{
+			name:                "Unsupported cancellation policy",
+			rental:              &model.Rental{UserID: 2, Name: "Rental 1", Type: "Type 1", PricePerDay: 1000, Latitude: 40.1234, Longitude: -75.5678, CancellationPolicy: "lenient"},
+			mockRentalStore:     &RentalStoreMock{},
+			mockUserStore:       &UserStoreMock{},
+			expectedCreateCalls: 0,
+			expectedErr:         svc.ErrInvalidRequestBody,
+		},
+		{
+			name:            "Missing user",
+			rental:          &model.Rental{UserID: 77, Name: "Rental 1", Type: "Type 1", PricePerDay: 1000, Latitude: 40.1234, Longitude: -75.5678, CancellationPolicy: model.CancellationPolicyModerate},
+			mockRentalStore: &RentalStoreMock{},
+			mockUserStore: &UserStoreMock{
+				GetByIDFunc: func(ctx context.Context, userID int) (*model.User, error) {
+					return nil, sql.ErrNoRows
+				},
+			},
+			expectedCreateCalls: 0,
+			expectedErr:         svc.ErrInvalidRequestBody,
+		},
+		{
+			name:   "Store error",
+			rental: &model.Rental{UserID: 2, Name: "Rental 1", Type: "Type 1", PricePerDay: 1000, Latitude: 40.1234, Longitude: -75.5678, CancellationPolicy: model.CancellationPolicyModerate},
+			mockRentalStore: &RentalStoreMock{
+				CreateFunc: func(ctx context.Context, rental *model.Rental) (int, error) {
+					return 0, sql.ErrConnDone
+				},
+			},
+			mockUserStore: &UserStoreMock{
+				GetByIDFunc: func(ctx context.Context, userID int) (*model.User, error) {
+					return _user, nil
+				},
+			},
+			expectedCreateCalls: 1,
+			expectedErr:         sql.ErrConnDone,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.Background()
+
+			operation := rentalcreating.NewOperation(tc.mockRentalStore, tc.mockUserStore)
+
+			rental, err := operation.CreateRental(ctx, tc.rental)
+
+			calls := tc.mockRentalStore.CreateCalls()
+			if len(calls) != tc.expectedCreateCalls {
+				t.Fatalf("Unexpected number of calls to Create:\nexpected: %d\ngot      %d", tc.expectedCreateCalls, len(calls))
+			}
+
+			if tc.expectedCreateCalls > 0 && calls[0].Rental != tc.rental {
+				t.Fatalf("Unexpected rental:\nexpected: %v\ngot:      %v", tc.rental, calls[0].Rental)
+			}
+
+			if err != tc.expectedErr && !errors.Is(err, tc.expectedErr) {
+				t.Fatalf("Unexpected error:\nexpected: %v\ngot:      %v", tc.expectedErr, err)
+			}
+
+			if !cmp.Equal(rental, tc.expectedResult) {
+				t.Fatalf("Unxpected rental:\nexpected: %v\ngot:      %v", tc.expectedResult, rental)
+			}
+		})
+	}
+}
@@ -0,0 +1,22 @@
+package rentalcreating
+
+import (
+	"context"
+
+	"github.com/dragonator/rental-service/module/rental/internal/model"
+)
+
+// RentalStore is a contract to a rental storage.
+//
+//go:generate moq -rm -pkg rentalcreating_test -out rental_store_mock_test.go . RentalStore
+type RentalStore interface {
+	Create(ctx context.Context, rental *model.Rental) (int, error)
+	GetByID(ctx context.Context, rentalID int) (*model.Rental, error)
+}
+
+// UserStore is a contract to a user storage.
+//
+//go:generate moq -rm -pkg rentalcreating_test -out user_store_mock_test.go . UserStore
+type UserStore interface {
+	GetByID(ctx context.Context, userID int) (*model.User, error)
+}
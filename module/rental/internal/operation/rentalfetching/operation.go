@@ -46,3 +46,13 @@ func (o *Operation) ListRentals(ctx context.Context, filters *storage.RentalFilt
 
 	return rentals, nil
 }
+
+// CountRentals returns the number of rentals matching the specified filters, regardless of their pagination.
+func (o *Operation) CountRentals(ctx context.Context, filters *storage.RentalFilters) (int, error) {
+	count, err := o.rentalStore.Count(ctx, filters)
+	if err != nil {
+		return 0, fmt.Errorf("operation CountRentals: %w", err)
+	}
+
+	return count, nil
+}
@@ -166,7 +166,7 @@ func TestOperation_ListRentals(t *testing.T) {
 					Latitude:  34.3,
 					Longitude: -83.23,
 				},
-				OrderBy: toPtr("price_per_day"),
+				OrderBy: []storage.SortTerm{{Field: "price_per_day"}},
 				Pagination: storage.Pagination{
 					Limit:  toPtr(10),
 					Offset: toPtr(8),
@@ -223,3 +223,55 @@ func TestOperation_ListRentals(t *testing.T) {
 func toPtr[T any](v T) *T {
 	return &v
 }
+
+func TestOperation_CountRentals(t *testing.T) {
+	testCases := []struct {
+		name          string
+		countErr      error
+		expectedCount int
+		expectedErr   error
+	}{
+		{
+			name:          "Counted rentals",
+			expectedCount: 12,
+		},
+		{
+			name:        "Storage error",
+			countErr:    sql.ErrConnDone,
+			expectedErr: sql.ErrConnDone,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			filters := &storage.RentalFilters{PriceMin: toPtr[int64](100)}
+
+			mockRentalStore := &RentalStoreMock{
+				CountFunc: func(ctx context.Context, filters *storage.RentalFilters) (int, error) {
+					if tc.countErr != nil {
+						return 0, tc.countErr
+					}
+
+					return 12, nil
+				},
+			}
+
+			operation := rentalfetching.NewOperation(mockRentalStore)
+
+			count, err := operation.CountRentals(context.Background(), filters)
+
+			calls := mockRentalStore.CountCalls()
+			if len(calls) != 1 || calls[0].Filters != filters {
+				t.Fatalf("Unexpected calls to Count: %v", calls)
+			}
+
+			if err != tc.expectedErr && !errors.Is(err, tc.expectedErr) {
+				t.Fatalf("Unexpected error:\nexpected: %v\ngot:      %v", tc.expectedErr, err)
+			}
+
+			if count != tc.expectedCount {
+				t.Fatalf("Unexpected count:\nexpected: %d\ngot:      %d", tc.expectedCount, count)
+			}
+		})
+	}
+}
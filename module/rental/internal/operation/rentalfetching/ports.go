@@ -13,4 +13,5 @@ import (
 type RentalStore interface {
 	GetByID(ctx context.Context, rentalID int) (*model.Rental, error)
 	List(ctx context.Context, filters *storage.RentalFilters) (model.Rentals, error)
+	Count(ctx context.Context, filters *storage.RentalFilters) (int, error)
 }
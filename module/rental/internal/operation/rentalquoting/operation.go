@@ -0,0 +1,55 @@
+package rentalquoting
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/dragonator/rental-service/module/rental/internal/http/service/svc"
+	"github.com/dragonator/rental-service/module/rental/internal/pricing"
+)
+
+// Operation provides an API for pricing stays at rentals.
+type Operation struct {
+	rentalStore      RentalStore
+	pricingRuleStore PricingRuleStore
+	calculator       Calculator
+}
+
+// NewOperation is a contruction function for Operation.
+func NewOperation(rentalStore RentalStore, pricingRuleStore PricingRuleStore, calculator Calculator) *Operation {
+	return &Operation{
+		rentalStore:      rentalStore,
+		pricingRuleStore: pricingRuleStore,
+		calculator:       calculator,
+	}
+}
+
+// QuoteRental returns the price of staying at a rental for the nights from start up to, but not including, end,
+// with the pricing rules of the rental applied.
+func (o *Operation) QuoteRental(ctx context.Context, rentalID int, start, end time.Time) (*pricing.Quote, error) {
+	rental, err := o.rentalStore.GetByID(ctx, rentalID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("%w: rental with id %d", svc.ErrNotFound, rentalID)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("operation QuoteRental: %w", err)
+	}
+
+	rules, err := o.pricingRuleStore.ListByRental(ctx, rentalID)
+	if err != nil {
+		return nil, fmt.Errorf("operation QuoteRental: %w", err)
+	}
+
+	quote, err := o.calculator.Quote(rental, rules, start, end)
+	if errors.Is(err, pricing.ErrInvalidStay) {
+		return nil, fmt.Errorf("%w: %w", svc.ErrInvalidQueryParameters, err)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("operation QuoteRental: %w", err)
+	}
+
+	return quote, nil
+}
@@ -0,0 +1,115 @@
+package rentalquoting_test
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/dragonator/rental-service/module/rental/internal/http/service/svc"
+	"github.com/dragonator/rental-service/module/rental/internal/model"
+	"github.com/dragonator/rental-service/module/rental/internal/operation/rentalquoting"
+	"github.com/dragonator/rental-service/module/rental/internal/pricing"
+)
+
+var (
+	_start = time.Date(2023, 7, 1, 0, 0, 0, 0, time.UTC)
+	_end   = time.Date(2023, 7, 5, 0, 0, 0, 0, time.UTC)
+	_rules = model.PricingRules{{ID: 1, RentalID: 2, Type: model.PricingRuleWeeklyDiscount, Percent: toPtr(10.0)}}
+	_quote = &pricing.Quote{RentalID: 2, StartDate: _start, EndDate: _end, Nights: 4, Subtotal: 67600, Total: 67600}
+)
+
+func TestOperation_QuoteRental(t *testing.T) {
+	testCases := []struct {
+		name           string
+		rentalErr      error
+		rulesErr       error
+		quoteErr       error
+		expectedCalls  int
+		expectedResult *pricing.Quote
+		expectedErr    error
+	}{
+		{
+			name:           "Existing rental",
+			expectedCalls:  1,
+			expectedResult: _quote,
+		},
+		{
+			name:          "Missing rental",
+			rentalErr:     sql.ErrNoRows,
+			expectedCalls: 0,
+			expectedErr:   svc.ErrNotFound,
+		},
+		{
+			name:          "Failing pricing rules",
+			rulesErr:      sql.ErrConnDone,
+			expectedCalls: 0,
+			expectedErr:   sql.ErrConnDone,
+		},
+		{
+			name:          "Invalid stay",
+			quoteErr:      pricing.ErrInvalidStay,
+			expectedCalls: 1,
+			expectedErr:   svc.ErrInvalidQueryParameters,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockRentalStore := &RentalStoreMock{
+				GetByIDFunc: func(ctx context.Context, rentalID int) (*model.Rental, error) {
+					if tc.rentalErr != nil {
+						return nil, tc.rentalErr
+					}
+
+					return &model.Rental{ID: int32(rentalID), PricePerDay: 16900}, nil
+				},
+			}
+			mockPricingRuleStore := &PricingRuleStoreMock{
+				ListByRentalFunc: func(ctx context.Context, rentalID int) (model.PricingRules, error) {
+					if tc.rulesErr != nil {
+						return nil, tc.rulesErr
+					}
+
+					return _rules, nil
+				},
+			}
+			mockCalculator := &CalculatorMock{
+				QuoteFunc: func(rental *model.Rental, rules model.PricingRules, start, end time.Time) (*pricing.Quote, error) {
+					if !cmp.Equal(rules, _rules) {
+						t.Fatalf("Unexpected pricing rules:\nexpected: %v\ngot:      %v", _rules, rules)
+					}
+
+					if tc.quoteErr != nil {
+						return nil, tc.quoteErr
+					}
+
+					return _quote, nil
+				},
+			}
+
+			operation := rentalquoting.NewOperation(mockRentalStore, mockPricingRuleStore, mockCalculator)
+
+			quote, err := operation.QuoteRental(context.Background(), 2, _start, _end)
+
+			if calls := mockCalculator.QuoteCalls(); len(calls) != tc.expectedCalls {
+				t.Fatalf("Unexpected number of calls to Quote:\nexpected: %d\ngot      %d", tc.expectedCalls, len(calls))
+			}
+
+			if err != tc.expectedErr && !errors.Is(err, tc.expectedErr) {
+				t.Fatalf("Unexpected error:\nexpected: %v\ngot:      %v", tc.expectedErr, err)
+			}
+
+			if !cmp.Equal(quote, tc.expectedResult) {
+				t.Fatalf("Unxpected quote:\nexpected: %v\ngot:      %v", tc.expectedResult, quote)
+			}
+		})
+	}
+}
+
+func toPtr[T any](v T) *T {
+	return &v
+}
@@ -0,0 +1,30 @@
+package rentalquoting
+
+import (
+	"context"
+	"time"
+
+	"github.com/dragonator/rental-service/module/rental/internal/model"
+	"github.com/dragonator/rental-service/module/rental/internal/pricing"
+)
+
+// RentalStore is a contract to a rental storage.
+//
+//go:generate moq -rm -pkg rentalquoting_test -out rental_store_mock_test.go . RentalStore
+type RentalStore interface {
+	GetByID(ctx context.Context, rentalID int) (*model.Rental, error)
+}
+
+// PricingRuleStore is a contract to a pricing rule storage.
+//
+//go:generate moq -rm -pkg rentalquoting_test -out pricing_rule_store_mock_test.go . PricingRuleStore
+type PricingRuleStore interface {
+	ListByRental(ctx context.Context, rentalID int) (model.PricingRules, error)
+}
+
+// Calculator is a contract to a price calculator.
+//
+//go:generate moq -rm -pkg rentalquoting_test -out calculator_mock_test.go . Calculator
+type Calculator interface {
+	Quote(rental *model.Rental, rules model.PricingRules, start, end time.Time) (*pricing.Quote, error)
+}
@@ -0,0 +1,71 @@
+package rentalupdating
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/dragonator/rental-service/module/rental/internal/http/service/svc"
+	"github.com/dragonator/rental-service/module/rental/internal/model"
+)
+
+// Operation provides an API for updating rentals.
+type Operation struct {
+	rentalStore RentalStore
+	userStore   UserStore
+}
+
+// NewOperation is a contruction function for Operation.
+func NewOperation(rentalStore RentalStore, userStore UserStore) *Operation {
+	return &Operation{
+		rentalStore: rentalStore,
+		userStore:   userStore,
+	}
+}
+
+// UpdateRental validates and stores the given rental over the existing one with the same id.
+// When version is given, the update is rejected with svc.ErrPreconditionFailed unless the
+// stored rental was last updated at version. It returns the rental as stored.
+func (o *Operation) UpdateRental(ctx context.Context, rental *model.Rental, version *time.Time) (*model.Rental, error) {
+	if err := rental.Validate(); err != nil {
+		return nil, fmt.Errorf("%w: %w", svc.ErrInvalidRequestBody, err)
+	}
+
+	_, err := o.userStore.GetByID(ctx, int(rental.UserID))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("%w: user with id %d does not exist", svc.ErrInvalidRequestBody, rental.UserID)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("operation UpdateRental: %w", err)
+	}
+
+	err = o.rentalStore.Update(ctx, rental, version)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, o.updateMissError(ctx, int(rental.ID))
+	}
+	if err != nil {
+		return nil, fmt.Errorf("operation UpdateRental: %w", err)
+	}
+
+	updated, err := o.rentalStore.GetByID(ctx, int(rental.ID))
+	if err != nil {
+		return nil, fmt.Errorf("operation UpdateRental: %w", err)
+	}
+
+	return updated, nil
+}
+
+// updateMissError tells apart a missing rental from one that was modified concurrently.
+func (o *Operation) updateMissError(ctx context.Context, rentalID int) error {
+	_, err := o.rentalStore.GetByID(ctx, rentalID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return fmt.Errorf("%w: rental with id %d", svc.ErrNotFound, rentalID)
+	}
+	if err != nil {
+		return fmt.Errorf("operation UpdateRental: %w", err)
+	}
+
+	return fmt.Errorf("%w: rental with id %d was modified", svc.ErrPreconditionFailed, rentalID)
+}
@@ -0,0 +1,135 @@
+package rentalupdating_test
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/dragonator/rental-service/module/rental/internal/http/service/svc"
+	"github.com/dragonator/rental-service/module/rental/internal/model"
+	"github.com/dragonator/rental-service/module/rental/internal/operation/rentalupdating"
+)
+
+var (
+	_user = &model.User{
+		ID:        2,
+		FirstName: "FirstName 1",
+		LastName:  "LastName 1",
+	}
+	_rental = &model.Rental{
+		ID:          1,
+		UserID:      2,
+		Name:        "Rental 1",
+		Type:        "Type 1",
+		PricePerDay: 1000,
+		Latitude:    40.1234,
+		Longitude:   -75.5678,
+		Updated:     time.Date(2023, 7, 2, 10, 0, 0, 0, time.UTC),
+		User:        _user,
+	}
+)
+
+func TestOperation_UpdateRental(t *testing.T) {
+	testCases := []struct {
+		name                string
+		rental              *model.Rental
+		version             *time.Time
+		mockRentalStore     *RentalStoreMock
+		expectedUpdateCalls int
+		expectedResult      *model.Rental
+		expectedErr         error
+	}{
+		{
+			name:    "Valid update",
+			rental:  &model.Rental{ID: 1, UserID: 2, Name: "Rental 1", Type: "Type 1", PricePerDay: 1000, Latitude: 40.1234, Longitude: -75.5678, CancellationPolicy: model.CancellationPolicyModerate},
+			version: &_rental.Updated,
+			mockRentalStore: &RentalStoreMock{
+				UpdateFunc: func(ctx context.Context, rental *model.Rental, version *time.Time) error {
+					return nil
+				},
+				GetByIDFunc: func(ctx context.Context, rentalID int) (*model.Rental, error) {
+					return _rental, nil
+				},
+			},
+			expectedUpdateCalls: 1,
+			expectedResult:      _rental,
+		},
+		{
+			name:                "Invalid rental",
+			rental:              &model.Rental{ID: 1, UserID: 2, Type: "Type 1", PricePerDay: 1000, Latitude: 40.1234, Longitude: -75.5678, CancellationPolicy: model.CancellationPolicyModerate},
+			mockRentalStore:     &RentalStoreMock{},
+			expectedUpdateCalls: 0,
+			expectedErr:         svc.ErrInvalidRequestBody,
+		},
+		{
+			name:    "Stale version",
+			rental:  &model.Rental{ID: 1, UserID: 2, Name: "Rental 1", Type: "Type 1", PricePerDay: 1000, Latitude: 40.1234, Longitude: -75.5678, CancellationPolicy: model.CancellationPolicyModerate},
+			version: toPtr(_rental.Updated.Add(-time.Hour)),
+			mockRentalStore: &RentalStoreMock{
+				UpdateFunc: func(ctx context.Context, rental *model.Rental, version *time.Time) error {
+					return sql.ErrNoRows
+				},
+				GetByIDFunc: func(ctx context.Context, rentalID int) (*model.Rental, error) {
+					return _rental, nil
+				},
+			},
+			expectedUpdateCalls: 1,
+			expectedErr:         svc.ErrPreconditionFailed,
+		},
+		{
+			name:   "Missing rental",
+			rental: &model.Rental{ID: 77, UserID: 2, Name: "Rental 1", Type: "Type 1", PricePerDay: 1000, Latitude: 40.1234, Longitude: -75.5678, CancellationPolicy: model.CancellationPolicyModerate},
+			mockRentalStore: &RentalStoreMock{
+				UpdateFunc: func(ctx context.Context, rental *model.Rental, version *time.Time) error {
+					return sql.ErrNoRows
+				},
+				GetByIDFunc: func(ctx context.Context, rentalID int) (*model.Rental, error) {
+					return nil, sql.ErrNoRows
+				},
+			},
+			expectedUpdateCalls: 1,
+			expectedErr:         svc.ErrNotFound,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.Background()
+
+			mockUserStore := &UserStoreMock{
+				GetByIDFunc: func(ctx context.Context, userID int) (*model.User, error) {
+					return _user, nil
+				},
+			}
+
+			operation := rentalupdating.NewOperation(tc.mockRentalStore, mockUserStore)
+
+			rental, err := operation.UpdateRental(ctx, tc.rental, tc.version)
+
+			calls := tc.mockRentalStore.UpdateCalls()
+			if len(calls) != tc.expectedUpdateCalls {
+				t.Fatalf("Unexpected number of calls to Update:\nexpected: %d\ngot      %d", tc.expectedUpdateCalls, len(calls))
+			}
+
+			if tc.expectedUpdateCalls > 0 && calls[0].Version != tc.version {
+				t.Fatalf("Unexpected version:\nexpected: %v\ngot:      %v", tc.version, calls[0].Version)
+			}
+
+			if err != tc.expectedErr && !errors.Is(err, tc.expectedErr) {
+				t.Fatalf("Unexpected error:\nexpected: %v\ngot:      %v", tc.expectedErr, err)
+			}
+
+			if !cmp.Equal(rental, tc.expectedResult) {
+				t.Fatalf("Unxpected rental:\nexpected: %v\ngot:      %v", tc.expectedResult, rental)
+			}
+		})
+	}
+}
+
+func toPtr[T any](v T) *T {
+	return &v
+}
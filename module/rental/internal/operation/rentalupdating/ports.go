@@ -0,0 +1,23 @@
+package rentalupdating
+
+import (
+	"context"
+	"time"
+
+	"github.com/dragonator/rental-service/module/rental/internal/model"
+)
+
+// RentalStore is a contract to a rental storage.
+//
+//go:generate moq -rm -pkg rentalupdating_test -out rental_store_mock_test.go . RentalStore
+type RentalStore interface {
+	Update(ctx context.Context, rental *model.Rental, version *time.Time) error
+	GetByID(ctx context.Context, rentalID int) (*model.Rental, error)
+}
+
+// UserStore is a contract to a user storage.
+//
+//go:generate moq -rm -pkg rentalupdating_test -out user_store_mock_test.go . UserStore
+type UserStore interface {
+	GetByID(ctx context.Context, userID int) (*model.User, error)
+}
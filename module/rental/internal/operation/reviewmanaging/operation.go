@@ -0,0 +1,127 @@
+package reviewmanaging
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/dragonator/rental-service/module/rental/internal/http/service/svc"
+	"github.com/dragonator/rental-service/module/rental/internal/model"
+	"github.com/dragonator/rental-service/module/rental/internal/storage"
+)
+
+// Operation provides an API for reviewing rentals.
+type Operation struct {
+	reviewStore  ReviewStore
+	bookingStore BookingStore
+	rentalStore  RentalStore
+	paymentStore PaymentStore
+}
+
+// NewOperation is a contruction function for Operation.
+func NewOperation(
+	reviewStore ReviewStore,
+	bookingStore BookingStore,
+	rentalStore RentalStore,
+	paymentStore PaymentStore,
+) *Operation {
+	return &Operation{
+		reviewStore:  reviewStore,
+		bookingStore: bookingStore,
+		rentalStore:  rentalStore,
+		paymentStore: paymentStore,
+	}
+}
+
+// GetReviewByID returns a review by id.
+func (o *Operation) GetReviewByID(ctx context.Context, reviewID int) (*model.Review, error) {
+	review, err := o.reviewStore.GetByID(ctx, reviewID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("%w: review with id %d", svc.ErrNotFound, reviewID)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("operation GetReviewByID: %w", err)
+	}
+
+	return review, nil
+}
+
+// ListReviews returns a page of the reviews of a rental, newest first.
+func (o *Operation) ListReviews(ctx context.Context, rentalID int, page *storage.Pagination) (model.Reviews, error) {
+	if err := o.checkRentalExists(ctx, rentalID); err != nil {
+		return nil, err
+	}
+
+	reviews, err := o.reviewStore.ListByRental(ctx, rentalID, page)
+	if err != nil {
+		return nil, fmt.Errorf("operation ListReviews: %w", err)
+	}
+
+	return reviews, nil
+}
+
+// CreateReview validates and stores a new review of a rental and returns it with its assigned id.
+// Only a completed booking of the rental whose payment was captured can be reviewed, once, and
+// the review is attributed to the user who made the booking.
+func (o *Operation) CreateReview(ctx context.Context, review *model.Review) (*model.Review, error) {
+	if err := review.Validate(); err != nil {
+		return nil, fmt.Errorf("%w: %w", svc.ErrInvalidRequestBody, err)
+	}
+
+	if err := o.checkRentalExists(ctx, int(review.RentalID)); err != nil {
+		return nil, err
+	}
+
+	booking, err := o.bookingStore.GetByID(ctx, int(review.BookingID))
+	if errors.Is(err, sql.ErrNoRows) || (err == nil && booking.RentalID != review.RentalID) {
+		return nil, fmt.Errorf("%w: booking with id %d of rental with id %d does not exist",
+			svc.ErrInvalidRequestBody, review.BookingID, review.RentalID)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("operation CreateReview: %w", err)
+	}
+
+	if !booking.Completed(time.Now().UTC()) {
+		return nil, fmt.Errorf("%w: booking with id %d is not completed", svc.ErrConflict, booking.ID)
+	}
+
+	intent, err := o.paymentStore.GetByBooking(ctx, int(booking.ID))
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("operation CreateReview: %w", err)
+	}
+
+	if intent == nil || intent.Status != model.PaymentStatusCaptured {
+		return nil, fmt.Errorf("%w: booking with id %d is not paid", svc.ErrConflict, booking.ID)
+	}
+
+	review.UserID = booking.UserID
+
+	reviewID, err := o.reviewStore.Create(ctx, review)
+	if errors.Is(err, storage.ErrReviewExists) {
+		return nil, fmt.Errorf("%w: %w", svc.ErrConflict, err)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("operation CreateReview: %w", err)
+	}
+
+	created, err := o.reviewStore.GetByID(ctx, reviewID)
+	if err != nil {
+		return nil, fmt.Errorf("operation CreateReview: %w", err)
+	}
+
+	return created, nil
+}
+
+func (o *Operation) checkRentalExists(ctx context.Context, rentalID int) error {
+	_, err := o.rentalStore.GetByID(ctx, rentalID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return fmt.Errorf("%w: rental with id %d", svc.ErrNotFound, rentalID)
+	}
+	if err != nil {
+		return fmt.Errorf("checking rental: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,233 @@
+package reviewmanaging_test
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/dragonator/rental-service/module/rental/internal/http/service/svc"
+	"github.com/dragonator/rental-service/module/rental/internal/model"
+	"github.com/dragonator/rental-service/module/rental/internal/operation/reviewmanaging"
+	"github.com/dragonator/rental-service/module/rental/internal/storage"
+)
+
+func TestOperation_CreateReview(t *testing.T) {
+	completed := &model.Booking{
+		ID:        4,
+		RentalID:  2,
+		UserID:    7,
+		StartDate: time.Date(2023, 7, 1, 0, 0, 0, 0, time.UTC),
+		EndDate:   time.Date(2023, 7, 5, 0, 0, 0, 0, time.UTC),
+		Status:    model.BookingStatusConfirmed,
+	}
+	upcoming := *completed
+	upcoming.EndDate = time.Now().UTC().AddDate(0, 0, 3)
+	cancelled := *completed
+	cancelled.Status = model.BookingStatusCancelled
+	captured := &model.PaymentIntent{ID: 3, BookingID: 4, Amount: 50000, Status: model.PaymentStatusCaptured}
+	failed := &model.PaymentIntent{ID: 3, BookingID: 4, Amount: 50000, Status: model.PaymentStatusFailed}
+
+	testCases := []struct {
+		name                string
+		review              *model.Review
+		booking             *model.Booking
+		bookingErr          error
+		intent              *model.PaymentIntent
+		rentalErr           error
+		createErr           error
+		expectedCreateCalls int
+		expectedUserID      int32
+		expectedErr         error
+	}{
+		{
+			name:                "Completed booking",
+			review:              &model.Review{RentalID: 2, BookingID: 4, Rating: 5, Comment: "Great van"},
+			booking:             completed,
+			intent:              captured,
+			expectedCreateCalls: 1,
+			expectedUserID:      7,
+		},
+		{
+			name:                "Invalid rating",
+			review:              &model.Review{RentalID: 2, BookingID: 4, Rating: 6},
+			booking:             completed,
+			expectedCreateCalls: 0,
+			expectedErr:         svc.ErrInvalidRequestBody,
+		},
+		{
+			name:                "Missing rental",
+			review:              &model.Review{RentalID: 2, BookingID: 4, Rating: 5},
+			booking:             completed,
+			rentalErr:           sql.ErrNoRows,
+			expectedCreateCalls: 0,
+			expectedErr:         svc.ErrNotFound,
+		},
+		{
+			name:                "Missing booking",
+			review:              &model.Review{RentalID: 2, BookingID: 4, Rating: 5},
+			bookingErr:          sql.ErrNoRows,
+			expectedCreateCalls: 0,
+			expectedErr:         svc.ErrInvalidRequestBody,
+		},
+		{
+			name:                "Booking of another rental",
+			review:              &model.Review{RentalID: 3, BookingID: 4, Rating: 5},
+			booking:             completed,
+			expectedCreateCalls: 0,
+			expectedErr:         svc.ErrInvalidRequestBody,
+		},
+		{
+			name:                "Upcoming booking",
+			review:              &model.Review{RentalID: 2, BookingID: 4, Rating: 5},
+			booking:             &upcoming,
+			expectedCreateCalls: 0,
+			expectedErr:         svc.ErrConflict,
+		},
+		{
+			name:                "Cancelled booking",
+			review:              &model.Review{RentalID: 2, BookingID: 4, Rating: 5},
+			booking:             &cancelled,
+			expectedCreateCalls: 0,
+			expectedErr:         svc.ErrConflict,
+		},
+		{
+			name:                "Unpaid booking",
+			review:              &model.Review{RentalID: 2, BookingID: 4, Rating: 5},
+			booking:             completed,
+			expectedCreateCalls: 0,
+			expectedErr:         svc.ErrConflict,
+		},
+		{
+			name:                "Failed payment",
+			review:              &model.Review{RentalID: 2, BookingID: 4, Rating: 5},
+			booking:             completed,
+			intent:              failed,
+			expectedCreateCalls: 0,
+			expectedErr:         svc.ErrConflict,
+		},
+		{
+			name:                "Reviewed booking",
+			review:              &model.Review{RentalID: 2, BookingID: 4, Rating: 5},
+			booking:             completed,
+			intent:              captured,
+			createErr:           storage.ErrReviewExists,
+			expectedCreateCalls: 1,
+			expectedUserID:      7,
+			expectedErr:         svc.ErrConflict,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockReviewStore := &ReviewStoreMock{
+				CreateFunc: func(ctx context.Context, review *model.Review) (int, error) {
+					return 1, tc.createErr
+				},
+				GetByIDFunc: func(ctx context.Context, reviewID int) (*model.Review, error) {
+					return &model.Review{ID: int32(reviewID)}, nil
+				},
+			}
+			mockBookingStore := &BookingStoreMock{
+				GetByIDFunc: func(ctx context.Context, bookingID int) (*model.Booking, error) {
+					return tc.booking, tc.bookingErr
+				},
+			}
+			mockRentalStore := &RentalStoreMock{
+				GetByIDFunc: func(ctx context.Context, rentalID int) (*model.Rental, error) {
+					return &model.Rental{ID: int32(rentalID)}, tc.rentalErr
+				},
+			}
+			mockPaymentStore := &PaymentStoreMock{
+				GetByBookingFunc: func(ctx context.Context, bookingID int) (*model.PaymentIntent, error) {
+					if tc.intent == nil {
+						return nil, sql.ErrNoRows
+					}
+
+					return tc.intent, nil
+				},
+			}
+
+			operation := reviewmanaging.NewOperation(mockReviewStore, mockBookingStore, mockRentalStore, mockPaymentStore)
+
+			_, err := operation.CreateReview(context.Background(), tc.review)
+
+			calls := mockReviewStore.CreateCalls()
+			if len(calls) != tc.expectedCreateCalls {
+				t.Fatalf("Unexpected number of calls to Create:\nexpected: %d\ngot      %d", tc.expectedCreateCalls, len(calls))
+			}
+
+			if len(calls) > 0 && calls[0].Review.UserID != tc.expectedUserID {
+				t.Fatalf("Unexpected review author:\nexpected: %d\ngot:      %d", tc.expectedUserID, calls[0].Review.UserID)
+			}
+
+			if err != tc.expectedErr && !errors.Is(err, tc.expectedErr) {
+				t.Fatalf("Unexpected error:\nexpected: %v\ngot:      %v", tc.expectedErr, err)
+			}
+		})
+	}
+}
+
+func TestOperation_ListReviews(t *testing.T) {
+	reviews := model.Reviews{
+		{ID: 2, RentalID: 2, BookingID: 5, UserID: 7, Rating: 4},
+		{ID: 1, RentalID: 2, BookingID: 4, UserID: 7, Rating: 5},
+	}
+
+	testCases := []struct {
+		name           string
+		rentalErr      error
+		expectedResult model.Reviews
+		expectedErr    error
+	}{
+		{
+			name:           "Existing rental",
+			expectedResult: reviews,
+		},
+		{
+			name:        "Missing rental",
+			rentalErr:   sql.ErrNoRows,
+			expectedErr: svc.ErrNotFound,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			page := &storage.Pagination{Limit: toPtr(2)}
+
+			mockReviewStore := &ReviewStoreMock{
+				ListByRentalFunc: func(ctx context.Context, rentalID int, p *storage.Pagination) (model.Reviews, error) {
+					if p != page {
+						t.Fatalf("Unexpected pagination:\nexpected: %v\ngot:      %v", page, p)
+					}
+
+					return reviews, nil
+				},
+			}
+			mockRentalStore := &RentalStoreMock{
+				GetByIDFunc: func(ctx context.Context, rentalID int) (*model.Rental, error) {
+					return &model.Rental{ID: int32(rentalID)}, tc.rentalErr
+				},
+			}
+
+			operation := reviewmanaging.NewOperation(mockReviewStore, &BookingStoreMock{}, mockRentalStore, &PaymentStoreMock{})
+
+			result, err := operation.ListReviews(context.Background(), 2, page)
+
+			if err != tc.expectedErr && !errors.Is(err, tc.expectedErr) {
+				t.Fatalf("Unexpected error:\nexpected: %v\ngot:      %v", tc.expectedErr, err)
+			}
+
+			if !cmp.Equal(result, tc.expectedResult) {
+				t.Fatalf("Unxpected reviews:\nexpected: %v\ngot:      %v", tc.expectedResult, result)
+			}
+		})
+	}
+}
+
+func toPtr[T any](v T) *T {
+	return &v
+}
@@ -0,0 +1,38 @@
+package reviewmanaging
+
+import (
+	"context"
+
+	"github.com/dragonator/rental-service/module/rental/internal/model"
+	"github.com/dragonator/rental-service/module/rental/internal/storage"
+)
+
+// ReviewStore is a contract to a review storage.
+//
+//go:generate moq -rm -pkg reviewmanaging_test -out review_store_mock_test.go . ReviewStore
+type ReviewStore interface {
+	GetByID(ctx context.Context, reviewID int) (*model.Review, error)
+	ListByRental(ctx context.Context, rentalID int, page *storage.Pagination) (model.Reviews, error)
+	Create(ctx context.Context, review *model.Review) (int, error)
+}
+
+// BookingStore is a contract to a booking storage.
+//
+//go:generate moq -rm -pkg reviewmanaging_test -out booking_store_mock_test.go . BookingStore
+type BookingStore interface {
+	GetByID(ctx context.Context, bookingID int) (*model.Booking, error)
+}
+
+// PaymentStore is a contract to a payment storage.
+//
+//go:generate moq -rm -pkg reviewmanaging_test -out payment_store_mock_test.go . PaymentStore
+type PaymentStore interface {
+	GetByBooking(ctx context.Context, bookingID int) (*model.PaymentIntent, error)
+}
+
+// RentalStore is a contract to a rental storage.
+//
+//go:generate moq -rm -pkg reviewmanaging_test -out rental_store_mock_test.go . RentalStore
+type RentalStore interface {
+	GetByID(ctx context.Context, rentalID int) (*model.Rental, error)
+}
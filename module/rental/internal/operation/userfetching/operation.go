@@ -0,0 +1,48 @@
+package userfetching
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/dragonator/rental-service/module/rental/internal/http/service/svc"
+	"github.com/dragonator/rental-service/module/rental/internal/model"
+	"github.com/dragonator/rental-service/module/rental/internal/storage"
+)
+
+// Operation provides an API for fetching single or multiple users.
+type Operation struct {
+	userStore UserStore
+}
+
+// NewOperation is a contruction function for Operation.
+func NewOperation(userStore UserStore) *Operation {
+	return &Operation{
+		userStore: userStore,
+	}
+}
+
+// GetUserByID returns a user for the given id.
+func (o *Operation) GetUserByID(ctx context.Context, userID int) (*model.User, error) {
+	user, err := o.userStore.GetByID(ctx, userID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("%w: user with id %d", svc.ErrNotFound, userID)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("operation GetUserByID: %w", err)
+	}
+
+	return user, nil
+}
+
+// ListUsers returns a list of users based on the specified filters.
+// If no users are found it returns an empty list.
+func (o *Operation) ListUsers(ctx context.Context, filters *storage.UserFilters) (model.Users, error) {
+	users, err := o.userStore.List(ctx, filters)
+	if err != nil {
+		return nil, fmt.Errorf("operation ListUsers: %w", err)
+	}
+
+	return users, nil
+}
@@ -0,0 +1,121 @@
+package userfetching_test
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/dragonator/rental-service/module/rental/internal/http/service/svc"
+	"github.com/dragonator/rental-service/module/rental/internal/model"
+	"github.com/dragonator/rental-service/module/rental/internal/operation/userfetching"
+	"github.com/dragonator/rental-service/module/rental/internal/storage"
+)
+
+var _users = model.Users{
+	{
+		ID:        1,
+		FirstName: "FirstName 1",
+		LastName:  "LastName 1",
+	},
+	{
+		ID:        2,
+		FirstName: "FirstName 2",
+		LastName:  "LastName 2",
+	},
+}
+
+func TestOperation_GetUserByID(t *testing.T) {
+	testCases := []struct {
+		name           string
+		mockUserStore  *UserStoreMock
+		expectedResult *model.User
+		expectedErr    error
+	}{
+		{
+			name: "Existing user",
+			mockUserStore: &UserStoreMock{
+				GetByIDFunc: func(ctx context.Context, userID int) (*model.User, error) {
+					return _users[0], nil
+				},
+			},
+			expectedResult: _users[0],
+		},
+		{
+			name: "Not found error",
+			mockUserStore: &UserStoreMock{
+				GetByIDFunc: func(ctx context.Context, userID int) (*model.User, error) {
+					return nil, sql.ErrNoRows
+				},
+			},
+			expectedErr: svc.ErrNotFound,
+		},
+		{
+			name: "Other error",
+			mockUserStore: &UserStoreMock{
+				GetByIDFunc: func(ctx context.Context, userID int) (*model.User, error) {
+					return nil, sql.ErrConnDone
+				},
+			},
+			expectedErr: sql.ErrConnDone,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			operation := userfetching.NewOperation(tc.mockUserStore)
+
+			user, err := operation.GetUserByID(context.Background(), 1)
+
+			calls := tc.mockUserStore.GetByIDCalls()
+			if len(calls) != 1 {
+				t.Fatalf("Unexpected number of calls to GetByID:\nexpected: 1\ngot      %d", len(calls))
+			}
+
+			if calls[0].UserID != 1 {
+				t.Fatalf("Unexpected user id:\nexpected: 1\ngot:      %v", calls[0].UserID)
+			}
+
+			if err != tc.expectedErr && !errors.Is(err, tc.expectedErr) {
+				t.Fatalf("Unexpected error:\nexpected: %v\ngot:      %v", tc.expectedErr, err)
+			}
+
+			if !cmp.Equal(user, tc.expectedResult) {
+				t.Fatalf("Unxpected user:\nexpected: %v\ngot:      %v", tc.expectedResult, user)
+			}
+		})
+	}
+}
+
+func TestOperation_ListUsers(t *testing.T) {
+	name := "First"
+	filters := &storage.UserFilters{Name: &name}
+
+	mockUserStore := &UserStoreMock{
+		ListFunc: func(ctx context.Context, filters *storage.UserFilters) (model.Users, error) {
+			return _users, nil
+		},
+	}
+
+	operation := userfetching.NewOperation(mockUserStore)
+
+	users, err := operation.ListUsers(context.Background(), filters)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	calls := mockUserStore.ListCalls()
+	if len(calls) != 1 {
+		t.Fatalf("Unexpected number of calls to List:\nexpected: 1\ngot      %d", len(calls))
+	}
+
+	if calls[0].Filters != filters {
+		t.Fatalf("Unexpected filters:\nexpected: %v\ngot:      %v", filters, calls[0].Filters)
+	}
+
+	if !cmp.Equal(users, _users) {
+		t.Fatalf("Unxpected users:\nexpected: %v\ngot:      %v", _users, users)
+	}
+}
@@ -0,0 +1,16 @@
+package userfetching
+
+import (
+	"context"
+
+	"github.com/dragonator/rental-service/module/rental/internal/model"
+	"github.com/dragonator/rental-service/module/rental/internal/storage"
+)
+
+// UserStore is a contract to a user storage.
+//
+//go:generate moq -rm -pkg userfetching_test -out user_store_mock_test.go . UserStore
+type UserStore interface {
+	GetByID(ctx context.Context, userID int) (*model.User, error)
+	List(ctx context.Context, filters *storage.UserFilters) (model.Users, error)
+}
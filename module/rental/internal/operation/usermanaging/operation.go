@@ -0,0 +1,95 @@
+package usermanaging
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/dragonator/rental-service/module/rental/internal/http/service/svc"
+	"github.com/dragonator/rental-service/module/rental/internal/model"
+	"github.com/dragonator/rental-service/module/rental/internal/storage"
+)
+
+// Operation provides an API for creating, updating and deleting users.
+type Operation struct {
+	userStore UserStore
+}
+
+// NewOperation is a contruction function for Operation.
+func NewOperation(userStore UserStore) *Operation {
+	return &Operation{
+		userStore: userStore,
+	}
+}
+
+// CreateUser validates and stores the given user. It returns the user as stored.
+func (o *Operation) CreateUser(ctx context.Context, user *model.User) (*model.User, error) {
+	if err := user.Validate(); err != nil {
+		return nil, fmt.Errorf("%w: %w", svc.ErrInvalidRequestBody, err)
+	}
+
+	userID, err := o.userStore.Create(ctx, user)
+	if err != nil {
+		return nil, fmt.Errorf("operation CreateUser: %w", err)
+	}
+
+	created, err := o.userStore.GetByID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("operation CreateUser: %w", err)
+	}
+
+	return created, nil
+}
+
+// UpdateUser validates and stores the given user over the existing one with the same id.
+// It returns the user as stored.
+func (o *Operation) UpdateUser(ctx context.Context, user *model.User) (*model.User, error) {
+	if err := user.Validate(); err != nil {
+		return nil, fmt.Errorf("%w: %w", svc.ErrInvalidRequestBody, err)
+	}
+
+	err := o.userStore.Update(ctx, user)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("%w: user with id %d", svc.ErrNotFound, user.ID)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("operation UpdateUser: %w", err)
+	}
+
+	updated, err := o.userStore.GetByID(ctx, int(user.ID))
+	if err != nil {
+		return nil, fmt.Errorf("operation UpdateUser: %w", err)
+	}
+
+	return updated, nil
+}
+
+// DeleteUser deletes the user with the given id. Users that still own rentals, or that other records
+// refer to, cannot be deleted.
+func (o *Operation) DeleteUser(ctx context.Context, userID int) error {
+	hasRentals, err := o.userStore.HasRentals(ctx, userID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return fmt.Errorf("%w: user with id %d", svc.ErrNotFound, userID)
+	}
+	if err != nil {
+		return fmt.Errorf("operation DeleteUser: %w", err)
+	}
+
+	if hasRentals {
+		return fmt.Errorf("%w: user with id %d owns rentals", svc.ErrConflict, userID)
+	}
+
+	err = o.userStore.Delete(ctx, userID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return fmt.Errorf("%w: user with id %d", svc.ErrNotFound, userID)
+	}
+	if errors.Is(err, storage.ErrUserReferenced) {
+		return fmt.Errorf("%w: user with id %d is referenced by other records", svc.ErrConflict, userID)
+	}
+	if err != nil {
+		return fmt.Errorf("operation DeleteUser: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,197 @@
+package usermanaging_test
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/dragonator/rental-service/module/rental/internal/http/service/svc"
+	"github.com/dragonator/rental-service/module/rental/internal/model"
+	"github.com/dragonator/rental-service/module/rental/internal/operation/usermanaging"
+	"github.com/dragonator/rental-service/module/rental/internal/storage"
+)
+
+var _user = &model.User{
+	ID:        1,
+	FirstName: "FirstName 1",
+	LastName:  "LastName 1",
+}
+
+func TestOperation_CreateUser(t *testing.T) {
+	testCases := []struct {
+		name                string
+		user                *model.User
+		mockUserStore       *UserStoreMock
+		expectedCreateCalls int
+		expectedResult      *model.User
+		expectedErr         error
+	}{
+		{
+			name: "Valid user",
+			user: &model.User{FirstName: "FirstName 1", LastName: "LastName 1"},
+			mockUserStore: &UserStoreMock{
+				CreateFunc: func(ctx context.Context, user *model.User) (int, error) {
+					return 1, nil
+				},
+				GetByIDFunc: func(ctx context.Context, userID int) (*model.User, error) {
+					return _user, nil
+				},
+			},
+			expectedCreateCalls: 1,
+			expectedResult:      _user,
+		},
+		{
+			name:                "Missing last name",
+			user:                &model.User{FirstName: "FirstName 1"},
+			mockUserStore:       &UserStoreMock{},
+			expectedCreateCalls: 0,
+			expectedErr:         svc.ErrInvalidRequestBody,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			operation := usermanaging.NewOperation(tc.mockUserStore)
+
+			user, err := operation.CreateUser(context.Background(), tc.user)
+
+			if calls := tc.mockUserStore.CreateCalls(); len(calls) != tc.expectedCreateCalls {
+				t.Fatalf("Unexpected number of calls to Create:\nexpected: %d\ngot      %d", tc.expectedCreateCalls, len(calls))
+			}
+
+			if err != tc.expectedErr && !errors.Is(err, tc.expectedErr) {
+				t.Fatalf("Unexpected error:\nexpected: %v\ngot:      %v", tc.expectedErr, err)
+			}
+
+			if !cmp.Equal(user, tc.expectedResult) {
+				t.Fatalf("Unxpected user:\nexpected: %v\ngot:      %v", tc.expectedResult, user)
+			}
+		})
+	}
+}
+
+func TestOperation_UpdateUser(t *testing.T) {
+	testCases := []struct {
+		name           string
+		mockUserStore  *UserStoreMock
+		expectedResult *model.User
+		expectedErr    error
+	}{
+		{
+			name: "Existing user",
+			mockUserStore: &UserStoreMock{
+				UpdateFunc: func(ctx context.Context, user *model.User) error {
+					return nil
+				},
+				GetByIDFunc: func(ctx context.Context, userID int) (*model.User, error) {
+					return _user, nil
+				},
+			},
+			expectedResult: _user,
+		},
+		{
+			name: "Missing user",
+			mockUserStore: &UserStoreMock{
+				UpdateFunc: func(ctx context.Context, user *model.User) error {
+					return sql.ErrNoRows
+				},
+			},
+			expectedErr: svc.ErrNotFound,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			operation := usermanaging.NewOperation(tc.mockUserStore)
+
+			user, err := operation.UpdateUser(context.Background(), _user)
+
+			if calls := tc.mockUserStore.UpdateCalls(); len(calls) != 1 {
+				t.Fatalf("Unexpected number of calls to Update:\nexpected: 1\ngot      %d", len(calls))
+			}
+
+			if err != tc.expectedErr && !errors.Is(err, tc.expectedErr) {
+				t.Fatalf("Unexpected error:\nexpected: %v\ngot:      %v", tc.expectedErr, err)
+			}
+
+			if !cmp.Equal(user, tc.expectedResult) {
+				t.Fatalf("Unxpected user:\nexpected: %v\ngot:      %v", tc.expectedResult, user)
+			}
+		})
+	}
+}
+
+func TestOperation_DeleteUser(t *testing.T) {
+	testCases := []struct {
+		name                string
+		mockUserStore       *UserStoreMock
+		expectedDeleteCalls int
+		expectedErr         error
+	}{
+		{
+			name: "User without rentals",
+			mockUserStore: &UserStoreMock{
+				HasRentalsFunc: func(ctx context.Context, userID int) (bool, error) {
+					return false, nil
+				},
+				DeleteFunc: func(ctx context.Context, userID int) error {
+					return nil
+				},
+			},
+			expectedDeleteCalls: 1,
+		},
+		{
+			name: "User with rentals",
+			mockUserStore: &UserStoreMock{
+				HasRentalsFunc: func(ctx context.Context, userID int) (bool, error) {
+					return true, nil
+				},
+			},
+			expectedDeleteCalls: 0,
+			expectedErr:         svc.ErrConflict,
+		},
+		{
+			name: "Referenced user",
+			mockUserStore: &UserStoreMock{
+				HasRentalsFunc: func(ctx context.Context, userID int) (bool, error) {
+					return false, nil
+				},
+				DeleteFunc: func(ctx context.Context, userID int) error {
+					return fmt.Errorf("deleting user: %w", storage.ErrUserReferenced)
+				},
+			},
+			expectedDeleteCalls: 1,
+			expectedErr:         svc.ErrConflict,
+		},
+		{
+			name: "Missing user",
+			mockUserStore: &UserStoreMock{
+				HasRentalsFunc: func(ctx context.Context, userID int) (bool, error) {
+					return false, sql.ErrNoRows
+				},
+			},
+			expectedDeleteCalls: 0,
+			expectedErr:         svc.ErrNotFound,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			operation := usermanaging.NewOperation(tc.mockUserStore)
+
+			err := operation.DeleteUser(context.Background(), 1)
+
+			if calls := tc.mockUserStore.DeleteCalls(); len(calls) != tc.expectedDeleteCalls {
+				t.Fatalf("Unexpected number of calls to Delete:\nexpected: %d\ngot      %d", tc.expectedDeleteCalls, len(calls))
+			}
+
+			if err != tc.expectedErr && !errors.Is(err, tc.expectedErr) {
+				t.Fatalf("Unexpected error:\nexpected: %v\ngot:      %v", tc.expectedErr, err)
+			}
+		})
+	}
+}
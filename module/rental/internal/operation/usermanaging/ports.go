@@ -0,0 +1,18 @@
+package usermanaging
+
+import (
+	"context"
+
+	"github.com/dragonator/rental-service/module/rental/internal/model"
+)
+
+// UserStore is a contract to a user storage.
+//
+//go:generate moq -rm -pkg usermanaging_test -out user_store_mock_test.go . UserStore
+type UserStore interface {
+	GetByID(ctx context.Context, userID int) (*model.User, error)
+	Create(ctx context.Context, user *model.User) (int, error)
+	Update(ctx context.Context, user *model.User) error
+	Delete(ctx context.Context, userID int) error
+	HasRentals(ctx context.Context, userID int) (bool, error)
+}
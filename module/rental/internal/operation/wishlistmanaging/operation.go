@@ -0,0 +1,131 @@
+package wishlistmanaging
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/dragonator/rental-service/module/rental/internal/http/service/svc"
+	"github.com/dragonator/rental-service/module/rental/internal/model"
+)
+
+// Operation provides an API for managing the wishlists of users.
+type Operation struct {
+	wishlistStore WishlistStore
+	userStore     UserStore
+	rentalStore   RentalStore
+}
+
+// NewOperation is a contruction function for Operation.
+func NewOperation(wishlistStore WishlistStore, userStore UserStore, rentalStore RentalStore) *Operation {
+	return &Operation{
+		wishlistStore: wishlistStore,
+		userStore:     userStore,
+		rentalStore:   rentalStore,
+	}
+}
+
+// GetWishlistByID returns a wishlist by id.
+func (o *Operation) GetWishlistByID(ctx context.Context, wishlistID int) (*model.Wishlist, error) {
+	wishlist, err := o.wishlistStore.GetByID(ctx, wishlistID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("%w: wishlist with id %d", svc.ErrNotFound, wishlistID)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("operation GetWishlistByID: %w", err)
+	}
+
+	return wishlist, nil
+}
+
+// ListWishlists returns the wishlists of a user.
+func (o *Operation) ListWishlists(ctx context.Context, userID int) (model.Wishlists, error) {
+	if err := o.checkUserExists(ctx, userID); err != nil {
+		return nil, err
+	}
+
+	wishlists, err := o.wishlistStore.ListByUser(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("operation ListWishlists: %w", err)
+	}
+
+	return wishlists, nil
+}
+
+// CreateWishlist validates and stores a new wishlist of a user and returns it with its assigned id.
+func (o *Operation) CreateWishlist(ctx context.Context, wishlist *model.Wishlist) (*model.Wishlist, error) {
+	if err := wishlist.Validate(); err != nil {
+		return nil, fmt.Errorf("%w: %w", svc.ErrInvalidRequestBody, err)
+	}
+
+	if err := o.checkUserExists(ctx, int(wishlist.UserID)); err != nil {
+		return nil, err
+	}
+
+	wishlistID, err := o.wishlistStore.Create(ctx, wishlist)
+	if err != nil {
+		return nil, fmt.Errorf("operation CreateWishlist: %w", err)
+	}
+
+	return o.GetWishlistByID(ctx, wishlistID)
+}
+
+// DeleteWishlist deletes a wishlist along with the rentals saved in it.
+func (o *Operation) DeleteWishlist(ctx context.Context, wishlistID int) error {
+	err := o.wishlistStore.Delete(ctx, wishlistID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return fmt.Errorf("%w: wishlist with id %d", svc.ErrNotFound, wishlistID)
+	}
+	if err != nil {
+		return fmt.Errorf("operation DeleteWishlist: %w", err)
+	}
+
+	return nil
+}
+
+// AddRental saves a rental into a wishlist. Saving a rental which is already in the wishlist has no effect.
+func (o *Operation) AddRental(ctx context.Context, wishlistID, rentalID int) error {
+	if _, err := o.GetWishlistByID(ctx, wishlistID); err != nil {
+		return err
+	}
+
+	_, err := o.rentalStore.GetByID(ctx, rentalID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return fmt.Errorf("%w: rental with id %d", svc.ErrNotFound, rentalID)
+	}
+	if err != nil {
+		return fmt.Errorf("checking rental: %w", err)
+	}
+
+	if err := o.wishlistStore.AddRental(ctx, wishlistID, rentalID); err != nil {
+		return fmt.Errorf("operation AddRental: %w", err)
+	}
+
+	return nil
+}
+
+// RemoveRental removes a rental from a wishlist.
+func (o *Operation) RemoveRental(ctx context.Context, wishlistID, rentalID int) error {
+	err := o.wishlistStore.RemoveRental(ctx, wishlistID, rentalID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return fmt.Errorf("%w: rental with id %d in wishlist with id %d", svc.ErrNotFound, rentalID, wishlistID)
+	}
+	if err != nil {
+		return fmt.Errorf("operation RemoveRental: %w", err)
+	}
+
+	return nil
+}
+
+func (o *Operation) checkUserExists(ctx context.Context, userID int) error {
+	_, err := o.userStore.GetByID(ctx, userID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return fmt.Errorf("%w: user with id %d", svc.ErrNotFound, userID)
+	}
+	if err != nil {
+		return fmt.Errorf("checking user: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,128 @@
+package wishlistmanaging_test
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+
+	"github.com/dragonator/rental-service/module/rental/internal/http/service/svc"
+	"github.com/dragonator/rental-service/module/rental/internal/model"
+	"github.com/dragonator/rental-service/module/rental/internal/operation/wishlistmanaging"
+)
+
+func TestOperation_CreateWishlist(t *testing.T) {
+	testCases := []struct {
+		name                string
+		wishlist            *model.Wishlist
+		userErr             error
+		expectedCreateCalls int
+		expectedErr         error
+	}{
+		{
+			name:                "Valid wishlist",
+			wishlist:            &model.Wishlist{UserID: 1, Name: "Summer trips"},
+			expectedCreateCalls: 1,
+		},
+		{
+			name:                "Blank name",
+			wishlist:            &model.Wishlist{UserID: 1, Name: "  "},
+			expectedCreateCalls: 0,
+			expectedErr:         svc.ErrInvalidRequestBody,
+		},
+		{
+			name:                "Missing user",
+			wishlist:            &model.Wishlist{UserID: 1, Name: "Summer trips"},
+			userErr:             sql.ErrNoRows,
+			expectedCreateCalls: 0,
+			expectedErr:         svc.ErrNotFound,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockWishlistStore := &WishlistStoreMock{
+				CreateFunc: func(ctx context.Context, wishlist *model.Wishlist) (int, error) {
+					return 2, nil
+				},
+				GetByIDFunc: func(ctx context.Context, wishlistID int) (*model.Wishlist, error) {
+					return &model.Wishlist{ID: int32(wishlistID)}, nil
+				},
+			}
+			mockUserStore := &UserStoreMock{
+				GetByIDFunc: func(ctx context.Context, userID int) (*model.User, error) {
+					return &model.User{ID: int32(userID)}, tc.userErr
+				},
+			}
+
+			operation := wishlistmanaging.NewOperation(mockWishlistStore, mockUserStore, &RentalStoreMock{})
+
+			_, err := operation.CreateWishlist(context.Background(), tc.wishlist)
+
+			if calls := mockWishlistStore.CreateCalls(); len(calls) != tc.expectedCreateCalls {
+				t.Fatalf("Unexpected number of calls to Create:\nexpected: %d\ngot      %d", tc.expectedCreateCalls, len(calls))
+			}
+
+			if err != tc.expectedErr && !errors.Is(err, tc.expectedErr) {
+				t.Fatalf("Unexpected error:\nexpected: %v\ngot:      %v", tc.expectedErr, err)
+			}
+		})
+	}
+}
+
+func TestOperation_AddRental(t *testing.T) {
+	testCases := []struct {
+		name             string
+		wishlistErr      error
+		rentalErr        error
+		expectedAddCalls int
+		expectedErr      error
+	}{
+		{
+			name:             "Existing rental",
+			expectedAddCalls: 1,
+		},
+		{
+			name:             "Missing wishlist",
+			wishlistErr:      sql.ErrNoRows,
+			expectedAddCalls: 0,
+			expectedErr:      svc.ErrNotFound,
+		},
+		{
+			name:             "Missing rental",
+			rentalErr:        sql.ErrNoRows,
+			expectedAddCalls: 0,
+			expectedErr:      svc.ErrNotFound,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockWishlistStore := &WishlistStoreMock{
+				GetByIDFunc: func(ctx context.Context, wishlistID int) (*model.Wishlist, error) {
+					return &model.Wishlist{ID: int32(wishlistID)}, tc.wishlistErr
+				},
+				AddRentalFunc: func(ctx context.Context, wishlistID, rentalID int) error {
+					return nil
+				},
+			}
+			mockRentalStore := &RentalStoreMock{
+				GetByIDFunc: func(ctx context.Context, rentalID int) (*model.Rental, error) {
+					return &model.Rental{ID: int32(rentalID)}, tc.rentalErr
+				},
+			}
+
+			operation := wishlistmanaging.NewOperation(mockWishlistStore, &UserStoreMock{}, mockRentalStore)
+
+			err := operation.AddRental(context.Background(), 1, 2)
+
+			if calls := mockWishlistStore.AddRentalCalls(); len(calls) != tc.expectedAddCalls {
+				t.Fatalf("Unexpected number of calls to AddRental:\nexpected: %d\ngot      %d", tc.expectedAddCalls, len(calls))
+			}
+
+			if err != tc.expectedErr && !errors.Is(err, tc.expectedErr) {
+				t.Fatalf("Unexpected error:\nexpected: %v\ngot:      %v", tc.expectedErr, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,33 @@
+package wishlistmanaging
+
+import (
+	"context"
+
+	"github.com/dragonator/rental-service/module/rental/internal/model"
+)
+
+// WishlistStore is a contract to a wishlist storage.
+//
+//go:generate moq -rm -pkg wishlistmanaging_test -out wishlist_store_mock_test.go . WishlistStore
+type WishlistStore interface {
+	GetByID(ctx context.Context, wishlistID int) (*model.Wishlist, error)
+	ListByUser(ctx context.Context, userID int) (model.Wishlists, error)
+	Create(ctx context.Context, wishlist *model.Wishlist) (int, error)
+	Delete(ctx context.Context, wishlistID int) error
+	AddRental(ctx context.Context, wishlistID, rentalID int) error
+	RemoveRental(ctx context.Context, wishlistID, rentalID int) error
+}
+
+// UserStore is a contract to a user storage.
+//
+//go:generate moq -rm -pkg wishlistmanaging_test -out user_store_mock_test.go . UserStore
+type UserStore interface {
+	GetByID(ctx context.Context, userID int) (*model.User, error)
+}
+
+// RentalStore is a contract to a rental storage.
+//
+//go:generate moq -rm -pkg wishlistmanaging_test -out rental_store_mock_test.go . RentalStore
+type RentalStore interface {
+	GetByID(ctx context.Context, rentalID int) (*model.Rental, error)
+}
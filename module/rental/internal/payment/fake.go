@@ -0,0 +1,120 @@
+// Package payment contains payment providers.
+package payment
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+)
+
+// FakeLimit is the largest amount, in cents, the fake provider authorizes.
+const FakeLimit = 100_000_000
+
+// Errors returned by payment providers.
+var (
+	ErrDeclined             = errors.New("payment declined")
+	ErrUnknownAuthorization = errors.New("unknown authorization")
+	ErrInvalidAmount        = errors.New("invalid amount")
+)
+
+type authorization struct {
+	amount   int64
+	captured int64
+	refunded int64
+}
+
+// Fake is an in-process payment provider for local development and tests. It authorizes every
+// positive amount up to FakeLimit and declines the rest. Authorizations are numbered in the order
+// they are made, so the same sequence of calls always results in the same references.
+type Fake struct {
+	mu             sync.Mutex
+	authorizations map[string]*authorization
+	refunds        map[string]bool
+}
+
+// NewFake is a constructor function for Fake.
+func NewFake() *Fake {
+	return &Fake{
+		authorizations: make(map[string]*authorization),
+		refunds:        make(map[string]bool),
+	}
+}
+
+// Authorize reserves the amount for the payment identified by reference and returns the id of the authorization.
+func (f *Fake) Authorize(ctx context.Context, amount int64, reference string) (string, error) {
+	if amount <= 0 {
+		return "", fmt.Errorf("authorizing %s: %w", reference, ErrInvalidAmount)
+	}
+
+	if amount > FakeLimit {
+		return "", fmt.Errorf("authorizing %s: %w", reference, ErrDeclined)
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	authorizationID := fmt.Sprintf("fake_auth_%d", len(f.authorizations)+1)
+	f.authorizations[authorizationID] = &authorization{amount: amount}
+
+	return authorizationID, nil
+}
+
+// Capture collects the amount, which cannot exceed the uncaptured part of the authorization.
+func (f *Fake) Capture(ctx context.Context, authorizationID string, amount int64) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	auth, ok := f.authorizations[authorizationID]
+	if !ok {
+		return fmt.Errorf("capturing %s: %w", authorizationID, ErrUnknownAuthorization)
+	}
+
+	if amount <= 0 || auth.captured+amount > auth.amount {
+		return fmt.Errorf("capturing %s: %w", authorizationID, ErrInvalidAmount)
+	}
+
+	auth.captured += amount
+
+	return nil
+}
+
+// Void releases the uncaptured part of the authorization, after which nothing more can be captured.
+func (f *Fake) Void(ctx context.Context, authorizationID string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	auth, ok := f.authorizations[authorizationID]
+	if !ok {
+		return fmt.Errorf("voiding %s: %w", authorizationID, ErrUnknownAuthorization)
+	}
+
+	auth.amount = auth.captured
+
+	return nil
+}
+
+// Refund returns the amount, which cannot exceed the captured amount not refunded yet. A refund
+// repeating the idempotency key of an earlier refund succeeds without returning the amount again.
+func (f *Fake) Refund(ctx context.Context, authorizationID string, amount int64, idempotencyKey string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.refunds[idempotencyKey] {
+		return nil
+	}
+
+	auth, ok := f.authorizations[authorizationID]
+	if !ok {
+		return fmt.Errorf("refunding %s: %w", authorizationID, ErrUnknownAuthorization)
+	}
+
+	if amount <= 0 || auth.refunded+amount > auth.captured {
+		return fmt.Errorf("refunding %s: %w", authorizationID, ErrInvalidAmount)
+	}
+
+	auth.refunded += amount
+	f.refunds[idempotencyKey] = true
+
+	return nil
+}
@@ -0,0 +1,72 @@
+package payment_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/dragonator/rental-service/module/rental/internal/payment"
+)
+
+func TestFake(t *testing.T) {
+	ctx := context.Background()
+	fake := payment.NewFake()
+
+	authorizationID, err := fake.Authorize(ctx, 50000, "booking-1")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if authorizationID != "fake_auth_1" {
+		t.Fatalf("Unexpected authorization id: %s", authorizationID)
+	}
+
+	if _, err := fake.Authorize(ctx, payment.FakeLimit+1, "booking-2"); !errors.Is(err, payment.ErrDeclined) {
+		t.Fatalf("Expected the payment to be declined, got: %v", err)
+	}
+
+	if err := fake.Refund(ctx, authorizationID, 100, "refund-1"); !errors.Is(err, payment.ErrInvalidAmount) {
+		t.Fatalf("Expected a refund of an uncaptured payment to fail, got: %v", err)
+	}
+
+	if err := fake.Capture(ctx, authorizationID, 50000); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if err := fake.Capture(ctx, authorizationID, 1); !errors.Is(err, payment.ErrInvalidAmount) {
+		t.Fatalf("Expected a capture over the authorized amount to fail, got: %v", err)
+	}
+
+	if err := fake.Refund(ctx, authorizationID, 20000, "refund-1"); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if err := fake.Refund(ctx, authorizationID, 20000, "refund-1"); err != nil {
+		t.Fatalf("Expected a repeated refund to succeed, got: %v", err)
+	}
+
+	if err := fake.Refund(ctx, authorizationID, 30001, "refund-2"); !errors.Is(err, payment.ErrInvalidAmount) {
+		t.Fatalf("Expected a refund over the captured amount to fail, got: %v", err)
+	}
+
+	if err := fake.Refund(ctx, authorizationID, 30000, "refund-2"); err != nil {
+		t.Fatalf("Expected a repeated refund not to be returned again, got: %v", err)
+	}
+
+	if err := fake.Capture(ctx, "fake_auth_2", 100); !errors.Is(err, payment.ErrUnknownAuthorization) {
+		t.Fatalf("Expected an unknown authorization, got: %v", err)
+	}
+
+	voidedID, err := fake.Authorize(ctx, 50000, "booking-3")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if err := fake.Void(ctx, voidedID); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if err := fake.Capture(ctx, voidedID, 100); !errors.Is(err, payment.ErrInvalidAmount) {
+		t.Fatalf("Expected a capture of a voided authorization to fail, got: %v", err)
+	}
+}
@@ -0,0 +1,233 @@
+// Package pricing computes the price of a stay at a rental.
+package pricing
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/dragonator/rental-service/module/rental/internal/model"
+	"github.com/dragonator/rental-service/pkg/config"
+)
+
+// MaxStayNights is the number of nights the longest stay that can be priced covers.
+const MaxStayNights = 365
+
+// ErrInvalidStay is returned when the stay to be priced does not cover at least one night
+// or covers more than MaxStayNights nights.
+var ErrInvalidStay = errors.New("invalid stay")
+
+// LineItemType is the kind of amount a line item of a quote stands for.
+type LineItemType string
+
+// Supported line item types.
+const (
+	LineItemNights   LineItemType = "nights"
+	LineItemDiscount LineItemType = "discount"
+	LineItemFee      LineItemType = "fee"
+	LineItemTax      LineItemType = "tax"
+)
+
+// LineItem is a single amount of a quote. Amounts are in cents and discounts are negative.
+type LineItem struct {
+	Type        LineItemType
+	Description string
+	Amount      int64
+}
+
+// Quote is the price of a stay at a rental for the nights from StartDate up to,
+// but not including, EndDate. Total is the sum of all line items.
+type Quote struct {
+	RentalID  int32
+	StartDate time.Time
+	EndDate   time.Time
+	Nights    int
+	LineItems []LineItem
+	Subtotal  int64
+	Discounts int64
+	Fees      int64
+	Taxes     int64
+	Total     int64
+}
+
+// Calculator computes quotes from the price of a rental and the configured fees and taxes.
+type Calculator struct {
+	cleaningFee       int64
+	serviceFeePercent float64
+	taxPercent        float64
+}
+
+// NewCalculator is a constructor function for Calculator.
+func NewCalculator(config *config.Config) *Calculator {
+	return &Calculator{
+		cleaningFee:       config.Pricing.CleaningFee,
+		serviceFeePercent: config.Pricing.ServiceFeePercent,
+		taxPercent:        config.Pricing.TaxPercent,
+	}
+}
+
+// Quote returns the price of staying at the rental for the nights from start up to, but not including, end.
+// Each night costs the price per day of the season it falls in, or of the rental outside of seasons,
+// raised by the weekend surcharge on Friday and Saturday nights. Stays of at least a week or a month
+// get the matching length of stay discount. The service fee is charged on the discounted price of
+// the nights and taxes on the discounted price of the nights and the fees.
+func (c *Calculator) Quote(rental *model.Rental, rules model.PricingRules, start, end time.Time) (*Quote, error) {
+	if !end.After(start) {
+		return nil, fmt.Errorf("%w: end date must be after start date", ErrInvalidStay)
+	}
+
+	if end.After(start.AddDate(0, 0, MaxStayNights)) {
+		return nil, fmt.Errorf("%w: at most %d nights can be priced", ErrInvalidStay, MaxStayNights)
+	}
+
+	nights := int(end.Sub(start).Hours() / 24)
+
+	quote := &Quote{
+		RentalID:  rental.ID,
+		StartDate: start,
+		EndDate:   end,
+		Nights:    nights,
+	}
+
+	var (
+		prices      []int64
+		nightsCount = make(map[int64]int)
+		surcharge   = findRule(rules, model.PricingRuleWeekendSurcharge)
+	)
+
+	for night := start; night.Before(end); night = night.AddDate(0, 0, 1) {
+		price := nightPrice(rental, rules, surcharge, night)
+		if nightsCount[price] == 0 {
+			prices = append(prices, price)
+		}
+
+		nightsCount[price]++
+	}
+
+	for _, price := range prices {
+		quote.add(LineItem{
+			Type:        LineItemNights,
+			Description: fmt.Sprintf("%s x %s", nightsLabel(nightsCount[price]), formatAmount(price)),
+			Amount:      int64(nightsCount[price]) * price,
+		})
+	}
+
+	if discount := stayDiscount(rules, nights); discount != nil {
+		quote.add(LineItem{
+			Type:        LineItemDiscount,
+			Description: fmt.Sprintf("%s (%g%%)", discountLabel(discount.Type), *discount.Percent),
+			Amount:      -percentOf(quote.Subtotal, *discount.Percent),
+		})
+	}
+
+	if c.cleaningFee > 0 {
+		quote.add(LineItem{
+			Type:        LineItemFee,
+			Description: "Cleaning fee",
+			Amount:      c.cleaningFee,
+		})
+	}
+
+	if serviceFee := percentOf(quote.Subtotal+quote.Discounts, c.serviceFeePercent); serviceFee > 0 {
+		quote.add(LineItem{
+			Type:        LineItemFee,
+			Description: fmt.Sprintf("Service fee (%g%%)", c.serviceFeePercent),
+			Amount:      serviceFee,
+		})
+	}
+
+	if taxes := percentOf(quote.Subtotal+quote.Discounts+quote.Fees, c.taxPercent); taxes > 0 {
+		quote.add(LineItem{
+			Type:        LineItemTax,
+			Description: fmt.Sprintf("Taxes (%g%%)", c.taxPercent),
+			Amount:      taxes,
+		})
+	}
+
+	return quote, nil
+}
+
+func (q *Quote) add(item LineItem) {
+	q.LineItems = append(q.LineItems, item)
+	q.Total += item.Amount
+
+	switch item.Type {
+	case LineItemNights:
+		q.Subtotal += item.Amount
+	case LineItemDiscount:
+		q.Discounts += item.Amount
+	case LineItemFee:
+		q.Fees += item.Amount
+	case LineItemTax:
+		q.Taxes += item.Amount
+	}
+}
+
+// nightPrice returns the price of the given night with the season and weekend surcharge rules applied.
+func nightPrice(rental *model.Rental, rules model.PricingRules, surcharge *model.PricingRule, night time.Time) int64 {
+	price := rental.PricePerDay
+
+	for _, rule := range rules {
+		if rule.Type == model.PricingRuleSeason && !night.Before(*rule.StartDate) && night.Before(*rule.EndDate) {
+			price = *rule.PricePerDay
+			break
+		}
+	}
+
+	if weekday := night.Weekday(); surcharge != nil && (weekday == time.Friday || weekday == time.Saturday) {
+		price += percentOf(price, *surcharge.Percent)
+	}
+
+	return price
+}
+
+// stayDiscount returns the length of stay discount rule applying to a stay of the given nights, if any.
+// The monthly discount takes precedence over the weekly one.
+func stayDiscount(rules model.PricingRules, nights int) *model.PricingRule {
+	if nights >= model.MonthlyDiscountNights {
+		if rule := findRule(rules, model.PricingRuleMonthlyDiscount); rule != nil {
+			return rule
+		}
+	}
+
+	if nights >= model.WeeklyDiscountNights {
+		return findRule(rules, model.PricingRuleWeeklyDiscount)
+	}
+
+	return nil
+}
+
+func findRule(rules model.PricingRules, ruleType model.PricingRuleType) *model.PricingRule {
+	for _, rule := range rules {
+		if rule.Type == ruleType {
+			return rule
+		}
+	}
+
+	return nil
+}
+
+func discountLabel(ruleType model.PricingRuleType) string {
+	if ruleType == model.PricingRuleMonthlyDiscount {
+		return "Monthly discount"
+	}
+
+	return "Weekly discount"
+}
+
+func percentOf(amount int64, percent float64) int64 {
+	return int64(math.Round(float64(amount) * percent / 100))
+}
+
+func nightsLabel(nights int) string {
+	if nights == 1 {
+		return "1 night"
+	}
+
+	return fmt.Sprintf("%d nights", nights)
+}
+
+func formatAmount(amount int64) string {
+	return fmt.Sprintf("%d.%02d", amount/100, amount%100)
+}
@@ -0,0 +1,175 @@
+package pricing_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/dragonator/rental-service/module/rental/internal/model"
+	"github.com/dragonator/rental-service/module/rental/internal/pricing"
+	"github.com/dragonator/rental-service/pkg/config"
+)
+
+func TestCalculator_Quote(t *testing.T) {
+	start := time.Date(2023, 7, 1, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2023, 7, 5, 0, 0, 0, 0, time.UTC)
+	rental := &model.Rental{ID: 2, PricePerDay: 16900}
+	seasonStart := time.Date(2023, 7, 3, 0, 0, 0, 0, time.UTC)
+	seasonEnd := time.Date(2023, 7, 10, 0, 0, 0, 0, time.UTC)
+	monthEnd := time.Date(2023, 7, 31, 0, 0, 0, 0, time.UTC)
+	discounts := model.PricingRules{
+		{ID: 3, RentalID: 2, Type: model.PricingRuleWeeklyDiscount, Percent: toPtr(10.0)},
+		{ID: 4, RentalID: 2, Type: model.PricingRuleMonthlyDiscount, Percent: toPtr(20.0)},
+	}
+
+	testCases := []struct {
+		name          string
+		pricing       *config.Pricing
+		rules         model.PricingRules
+		start         time.Time
+		end           time.Time
+		expectedQuote *pricing.Quote
+		expectedError error
+	}{
+		{
+			name:    "Nights, fees and taxes",
+			pricing: &config.Pricing{CleaningFee: 5000, ServiceFeePercent: 10, TaxPercent: 7.5},
+			start:   start,
+			end:     end,
+			expectedQuote: &pricing.Quote{
+				RentalID:  2,
+				StartDate: start,
+				EndDate:   end,
+				Nights:    4,
+				LineItems: []pricing.LineItem{
+					{Type: pricing.LineItemNights, Description: "4 nights x 169.00", Amount: 67600},
+					{Type: pricing.LineItemFee, Description: "Cleaning fee", Amount: 5000},
+					{Type: pricing.LineItemFee, Description: "Service fee (10%)", Amount: 6760},
+					{Type: pricing.LineItemTax, Description: "Taxes (7.5%)", Amount: 5952},
+				},
+				Subtotal: 67600,
+				Fees:     11760,
+				Taxes:    5952,
+				Total:    85312,
+			},
+		},
+		{
+			name:    "Nights only",
+			pricing: &config.Pricing{},
+			start:   start,
+			end:     start.AddDate(0, 0, 1),
+			expectedQuote: &pricing.Quote{
+				RentalID:  2,
+				StartDate: start,
+				EndDate:   start.AddDate(0, 0, 1),
+				Nights:    1,
+				LineItems: []pricing.LineItem{
+					{Type: pricing.LineItemNights, Description: "1 night x 169.00", Amount: 16900},
+				},
+				Subtotal: 16900,
+				Total:    16900,
+			},
+		},
+		{
+			name:    "Season and weekend surcharge",
+			pricing: &config.Pricing{},
+			rules: model.PricingRules{
+				{ID: 1, RentalID: 2, Type: model.PricingRuleSeason, StartDate: &seasonStart, EndDate: &seasonEnd, PricePerDay: toPtr[int64](20000)},
+				{ID: 2, RentalID: 2, Type: model.PricingRuleWeekendSurcharge, Percent: toPtr(10.0)},
+			},
+			start: start,
+			end:   end,
+			expectedQuote: &pricing.Quote{
+				RentalID:  2,
+				StartDate: start,
+				EndDate:   end,
+				Nights:    4,
+				LineItems: []pricing.LineItem{
+					{Type: pricing.LineItemNights, Description: "1 night x 185.90", Amount: 18590},
+					{Type: pricing.LineItemNights, Description: "1 night x 169.00", Amount: 16900},
+					{Type: pricing.LineItemNights, Description: "2 nights x 200.00", Amount: 40000},
+				},
+				Subtotal: 75490,
+				Total:    75490,
+			},
+		},
+		{
+			name:    "Weekly discount",
+			pricing: &config.Pricing{ServiceFeePercent: 10},
+			rules:   discounts,
+			start:   seasonStart,
+			end:     seasonEnd,
+			expectedQuote: &pricing.Quote{
+				RentalID:  2,
+				StartDate: seasonStart,
+				EndDate:   seasonEnd,
+				Nights:    7,
+				LineItems: []pricing.LineItem{
+					{Type: pricing.LineItemNights, Description: "7 nights x 169.00", Amount: 118300},
+					{Type: pricing.LineItemDiscount, Description: "Weekly discount (10%)", Amount: -11830},
+					{Type: pricing.LineItemFee, Description: "Service fee (10%)", Amount: 10647},
+				},
+				Subtotal:  118300,
+				Discounts: -11830,
+				Fees:      10647,
+				Total:     117117,
+			},
+		},
+		{
+			name:    "Monthly discount",
+			pricing: &config.Pricing{},
+			rules:   discounts,
+			start:   seasonStart,
+			end:     monthEnd,
+			expectedQuote: &pricing.Quote{
+				RentalID:  2,
+				StartDate: seasonStart,
+				EndDate:   monthEnd,
+				Nights:    28,
+				LineItems: []pricing.LineItem{
+					{Type: pricing.LineItemNights, Description: "28 nights x 169.00", Amount: 473200},
+					{Type: pricing.LineItemDiscount, Description: "Monthly discount (20%)", Amount: -94640},
+				},
+				Subtotal:  473200,
+				Discounts: -94640,
+				Total:     378560,
+			},
+		},
+		{
+			name:          "Empty stay",
+			pricing:       &config.Pricing{},
+			start:         start,
+			end:           start,
+			expectedError: pricing.ErrInvalidStay,
+		},
+		{
+			name:          "Too long stay",
+			pricing:       &config.Pricing{},
+			start:         time.Date(1, 1, 1, 0, 0, 0, 0, time.UTC),
+			end:           time.Date(9999, 12, 31, 0, 0, 0, 0, time.UTC),
+			expectedError: pricing.ErrInvalidStay,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			calculator := pricing.NewCalculator(&config.Config{Pricing: tc.pricing})
+
+			quote, err := calculator.Quote(rental, tc.rules, tc.start, tc.end)
+
+			if !errors.Is(err, tc.expectedError) {
+				t.Fatalf("Unexpected error:\nexpected: %v\ngot:      %v", tc.expectedError, err)
+			}
+
+			if !cmp.Equal(quote, tc.expectedQuote) {
+				t.Fatalf("Unexpected quote:\nexpected: %v\ngot:      %v", tc.expectedQuote, quote)
+			}
+		})
+	}
+}
+
+func toPtr[T any](v T) *T {
+	return &v
+}
@@ -0,0 +1,114 @@
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	model "github.com/dragonator/rental-service/module/rental/internal/model"
+)
+
+// addRentalAmenityQuery adds the amenity with the slug $2 to the amenities of the rental $1.
+const addRentalAmenityQuery = "INSERT INTO rental_amenities (rental_id, amenity_id) " +
+	"SELECT $1, amenities.id FROM amenities WHERE amenities.slug = $2"
+
+// ErrUnknownAmenity is returned when an amenity slug is not in the amenities catalog.
+var ErrUnknownAmenity = errors.New("unknown amenity")
+
+var amenityColumns = []string{
+	"amenities.id",
+	"amenities.slug",
+	"amenities.name",
+}
+
+// AmenityRepository hold DB operations over the amenities catalog and the amenities of rentals.
+type AmenityRepository struct {
+	db *sql.DB
+}
+
+// NewAmenityRepository is a constructor function for AmenityRepository.
+func NewAmenityRepository(db *sql.DB) *AmenityRepository {
+	return &AmenityRepository{
+		db: db,
+	}
+}
+
+// List returns the amenities catalog ordered by name.
+func (ar *AmenityRepository) List(ctx context.Context) (model.Amenities, error) {
+	qb := NewQueryBuilder().
+		Select().
+		Columns(amenityColumns...).
+		From("amenities").
+		OrderBy("amenities.name")
+
+	rows, err := ar.db.QueryContext(ctx, qb.String())
+	if err != nil {
+		return nil, fmt.Errorf("listing amenities: %w", err)
+	}
+
+	defer rows.Close()
+
+	amenities := make(model.Amenities, 0, 20)
+
+	for rows.Next() {
+		amenity := new(model.Amenity)
+		if err := rows.Scan(&amenity.ID, &amenity.Slug, &amenity.Name); err != nil {
+			return nil, fmt.Errorf("scanning amenity: %w", err)
+		}
+
+		amenities = append(amenities, amenity)
+	}
+
+	return amenities, nil
+}
+
+// SetForRental replaces the amenities of the rental with the amenities of the given slugs.
+// If a slug is not in the catalog it returns ErrUnknownAmenity and the amenities are left unchanged.
+func (ar *AmenityRepository) SetForRental(ctx context.Context, rentalID int, slugs []string) (err error) {
+	tx, err := ar.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("setting rental amenities: %w", err)
+	}
+
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback()
+		}
+	}()
+
+	remove := NewQueryBuilder().
+		Delete("rental_amenities").
+		Where("rental_id = $1")
+
+	if _, err = tx.ExecContext(ctx, remove.String(), rentalID); err != nil {
+		return fmt.Errorf("removing rental amenities: %w", err)
+	}
+
+	for _, slug := range slugs {
+		var result sql.Result
+
+		result, err = tx.ExecContext(ctx, addRentalAmenityQuery, rentalID, slug)
+		if err != nil {
+			return fmt.Errorf("adding rental amenity: %w", err)
+		}
+
+		var affected int64
+
+		affected, err = result.RowsAffected()
+		if err != nil {
+			return fmt.Errorf("adding rental amenity: %w", err)
+		}
+
+		if affected == 0 {
+			err = fmt.Errorf("%w: %s", ErrUnknownAmenity, slug)
+			return fmt.Errorf("adding rental amenity: %w", err)
+		}
+	}
+
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("setting rental amenities: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,75 @@
+package storage_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+
+	"github.com/dragonator/rental-service/module/rental/internal/storage"
+)
+
+func TestAmenityRepository_SetForRental(t *testing.T) {
+	testCases := []struct {
+		name          string
+		slugs         []string
+		affected      []int64
+		expectedError error
+	}{
+		{
+			name:     "Known amenities",
+			slugs:    []string{"shower", "solar"},
+			affected: []int64{1, 1},
+		},
+		{
+			name:     "No amenities",
+			slugs:    []string{},
+			affected: []int64{},
+		},
+		{
+			name:          "Unknown amenity",
+			slugs:         []string{"shower", "sauna"},
+			affected:      []int64{1, 0},
+			expectedError: storage.ErrUnknownAmenity,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("failed to create sqlmock: %s", err)
+			}
+			defer db.Close()
+
+			mock.ExpectBegin()
+			mock.ExpectExec("DELETE FROM rental_amenities WHERE rental_id = \\$1").
+				WithArgs(1).
+				WillReturnResult(sqlmock.NewResult(0, 3))
+
+			for i, slug := range tc.slugs {
+				mock.ExpectExec("INSERT INTO rental_amenities \\(rental_id, amenity_id\\) "+
+					"SELECT \\$1, amenities.id FROM amenities WHERE amenities.slug = \\$2").
+					WithArgs(1, slug).
+					WillReturnResult(sqlmock.NewResult(0, tc.affected[i]))
+			}
+
+			if tc.expectedError != nil {
+				mock.ExpectRollback()
+			} else {
+				mock.ExpectCommit()
+			}
+
+			err = storage.NewAmenityRepository(db).SetForRental(context.Background(), 1, tc.slugs)
+
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Fatalf("there were unfulfilled expectations: %s", err)
+			}
+
+			if err != tc.expectedError && !errors.Is(err, tc.expectedError) {
+				t.Fatalf("error expectation mismatch: expected %v, got %v", tc.expectedError, err)
+			}
+		})
+	}
+}
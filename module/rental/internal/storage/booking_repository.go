@@ -0,0 +1,179 @@
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgconn"
+
+	model "github.com/dragonator/rental-service/module/rental/internal/model"
+)
+
+// _exclusionViolation is the Postgres error code raised when an exclusion constraint is violated.
+const _exclusionViolation = "23P01"
+
+// ErrBookingOverlap is returned when a booking overlaps a confirmed booking of the same rental.
+var ErrBookingOverlap = errors.New("booking overlaps a confirmed booking")
+
+var (
+	bookingColumns = []string{
+		"bookings.id",
+		"bookings.rental_id",
+		"bookings.user_id",
+		"bookings.start_date",
+		"bookings.end_date",
+		"bookings.status",
+		"bookings.cancellation_policy",
+		"bookings.created",
+		"bookings.updated",
+		"bookings.cancelled_at",
+	}
+	bookingWriteColumns = []string{
+		"rental_id",
+		"user_id",
+		"start_date",
+		"end_date",
+		"status",
+		"cancellation_policy",
+		"created",
+		"updated",
+	}
+)
+
+// BookingRepository hold DB operations over booking entities.
+type BookingRepository struct {
+	db *sql.DB
+}
+
+// NewBookingRepository is a constructor function for BookingRepository.
+func NewBookingRepository(db *sql.DB) *BookingRepository {
+	return &BookingRepository{
+		db: db,
+	}
+}
+
+// GetByID returns a single booking object corresponding to the requested id.
+// If no such booking exists it returns an error.
+func (br *BookingRepository) GetByID(ctx context.Context, bookingID int) (*model.Booking, error) {
+	qb := NewQueryBuilder().
+		Select().
+		Columns(bookingColumns...).
+		From("bookings").
+		Where("bookings.id = $1")
+
+	booking, err := scanBooking(br.db.QueryRowContext(ctx, qb.String(), bookingID))
+	if err != nil {
+		return nil, fmt.Errorf("getting booking by id: %w", err)
+	}
+
+	return booking, nil
+}
+
+// Create inserts a new confirmed booking and returns the id assigned to it.
+// If the booking overlaps a confirmed booking of the same rental it returns ErrBookingOverlap.
+func (br *BookingRepository) Create(ctx context.Context, booking *model.Booking) (int, error) {
+	qb := NewQueryBuilder().
+		Insert("bookings").
+		Columns(bookingWriteColumns...).
+		Returning("id")
+
+	now := time.Now().UTC()
+
+	var bookingID int
+	err := br.db.QueryRowContext(ctx, qb.String(),
+		booking.RentalID,
+		booking.UserID,
+		booking.StartDate,
+		booking.EndDate,
+		model.BookingStatusConfirmed,
+		booking.CancellationPolicy,
+		now,
+		now,
+	).Scan(&bookingID)
+
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == _exclusionViolation {
+		return 0, fmt.Errorf("creating booking: %w", ErrBookingOverlap)
+	}
+	if err != nil {
+		return 0, fmt.Errorf("creating booking: %w", err)
+	}
+
+	return bookingID, nil
+}
+
+// ListByRental returns the confirmed bookings of the rental covering any of the nights from start
+// up to, but not including, end, ordered by their start date.
+func (br *BookingRepository) ListByRental(ctx context.Context, rentalID int, start, end time.Time) (model.Bookings, error) {
+	qb := NewQueryBuilder().
+		Select().
+		Columns(bookingColumns...).
+		From("bookings").
+		Where("bookings.rental_id = $1").
+		Where(fmt.Sprintf("bookings.status = '%s'", model.BookingStatusConfirmed)).
+		Where("bookings.start_date < $3").
+		Where("bookings.end_date > $2").
+		OrderBy("bookings.start_date")
+
+	rows, err := br.db.QueryContext(ctx, qb.String(), rentalID, start, end)
+	if err != nil {
+		return nil, fmt.Errorf("listing bookings: %w", err)
+	}
+
+	defer rows.Close()
+
+	bookings := make(model.Bookings, 0, 10)
+
+	for rows.Next() {
+		booking, err := scanBooking(rows)
+		if err != nil {
+			return nil, fmt.Errorf("scanning booking: %w", err)
+		}
+
+		bookings = append(bookings, booking)
+	}
+
+	return bookings, nil
+}
+
+// Cancel marks a confirmed booking as cancelled, releasing its dates.
+// If no confirmed booking matches it returns sql.ErrNoRows.
+func (br *BookingRepository) Cancel(ctx context.Context, bookingID int) error {
+	qb := NewQueryBuilder().
+		Update("bookings").
+		Columns("status", "cancelled_at", "updated").
+		Where("id = $4").
+		Where(fmt.Sprintf("status = '%s'", model.BookingStatusConfirmed))
+
+	now := time.Now().UTC()
+
+	if err := execAffectingRow(ctx, br.db, qb.String(), model.BookingStatusCancelled, now, now, bookingID); err != nil {
+		return fmt.Errorf("cancelling booking: %w", err)
+	}
+
+	return nil
+}
+
+func scanBooking(row rowScanner) (*model.Booking, error) {
+	booking := new(model.Booking)
+
+	if err := row.Scan(
+		&booking.ID,
+		&booking.RentalID,
+		&booking.UserID,
+		&booking.StartDate,
+		&booking.EndDate,
+		&booking.Status,
+		&booking.CancellationPolicy,
+		&booking.Created,
+		&booking.Updated,
+		&booking.CancelledAt,
+	); err != nil {
+		return nil, err
+	}
+
+	return booking, nil
+}
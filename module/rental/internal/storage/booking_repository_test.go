@@ -0,0 +1,246 @@
+package storage_test
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/go-cmp/cmp"
+	"github.com/jackc/pgx/v5/pgconn"
+
+	model "github.com/dragonator/rental-service/module/rental/internal/model"
+	"github.com/dragonator/rental-service/module/rental/internal/storage"
+)
+
+var (
+	_bookingColumns = []string{
+		"bookings.id",
+		"bookings.rental_id",
+		"bookings.user_id",
+		"bookings.start_date",
+		"bookings.end_date",
+		"bookings.status",
+		"bookings.cancellation_policy",
+		"bookings.created",
+		"bookings.updated",
+		"bookings.cancelled_at",
+	}
+	_booking = &model.Booking{
+		ID:                 1,
+		RentalID:           2,
+		UserID:             3,
+		StartDate:          time.Date(2023, 7, 1, 0, 0, 0, 0, time.UTC),
+		EndDate:            time.Date(2023, 7, 5, 0, 0, 0, 0, time.UTC),
+		Status:             model.BookingStatusConfirmed,
+		CancellationPolicy: model.CancellationPolicyModerate,
+		Created:            time.Date(2023, 6, 1, 10, 0, 0, 0, time.UTC),
+		Updated:            time.Date(2023, 6, 1, 10, 0, 0, 0, time.UTC),
+	}
+)
+
+func TestBookingRepository_GetByID(t *testing.T) {
+	selectQuery := "SELECT bookings.id, .* FROM bookings WHERE bookings.id = \\$1"
+
+	testCases := []struct {
+		name            string
+		idParam         int
+		expectedBooking *model.Booking
+		expectedError   error
+		mockFunc        func(mock sqlmock.Sqlmock)
+	}{
+		{
+			name:            "Valid booking ID",
+			idParam:         1,
+			expectedBooking: _booking,
+			mockFunc: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(selectQuery).
+					WithArgs([]driver.Value{1}...).
+					WillReturnRows(sqlmock.NewRows(_bookingColumns).AddRow(bookingValues(_booking)...))
+			},
+		},
+		{
+			name:          "Missing booking ID",
+			idParam:       77,
+			expectedError: sql.ErrNoRows,
+			mockFunc: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(selectQuery).
+					WithArgs([]driver.Value{77}...).
+					WillReturnError(sql.ErrNoRows)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("failed to create sqlmock: %s", err)
+			}
+			defer db.Close()
+
+			tc.mockFunc(mock)
+
+			booking, err := storage.NewBookingRepository(db).GetByID(context.Background(), tc.idParam)
+
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Fatalf("there were unfulfilled expectations: %s", err)
+			}
+
+			if !errors.Is(err, tc.expectedError) {
+				t.Fatalf("error expectation mismatch: expected %v, got %v", tc.expectedError, err)
+			}
+
+			if !cmp.Equal(booking, tc.expectedBooking) {
+				t.Fatalf("result expectation mismatch: expected %v, got %v", tc.expectedBooking, booking)
+			}
+		})
+	}
+}
+
+func TestBookingRepository_Create(t *testing.T) {
+	insertQuery := "INSERT INTO bookings \\(rental_id, user_id, start_date, end_date, status, cancellation_policy, created, updated\\) " +
+		"VALUES \\(\\$1, \\$2, \\$3, \\$4, \\$5, \\$6, \\$7, \\$8\\) RETURNING id"
+
+	testCases := []struct {
+		name          string
+		expectedID    int
+		expectedError error
+		mockFunc      func(mock sqlmock.Sqlmock)
+	}{
+		{
+			name:       "Free dates",
+			expectedID: 1,
+			mockFunc: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(insertQuery).
+					WithArgs(2, 3, _booking.StartDate, _booking.EndDate, model.BookingStatusConfirmed, model.CancellationPolicyModerate, sqlmock.AnyArg(), sqlmock.AnyArg()).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+			},
+		},
+		{
+			name:          "Overlapping dates",
+			expectedError: storage.ErrBookingOverlap,
+			mockFunc: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(insertQuery).
+					WillReturnError(&pgconn.PgError{Code: "23P01"})
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("failed to create sqlmock: %s", err)
+			}
+			defer db.Close()
+
+			tc.mockFunc(mock)
+
+			bookingID, err := storage.NewBookingRepository(db).Create(context.Background(), _booking)
+
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Fatalf("there were unfulfilled expectations: %s", err)
+			}
+
+			if !errors.Is(err, tc.expectedError) {
+				t.Fatalf("error expectation mismatch: expected %v, got %v", tc.expectedError, err)
+			}
+
+			if bookingID != tc.expectedID {
+				t.Fatalf("result expectation mismatch: expected %d, got %d", tc.expectedID, bookingID)
+			}
+		})
+	}
+}
+
+func TestBookingRepository_ListByRental(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to create sqlmock: %s", err)
+	}
+	defer db.Close()
+
+	start := time.Date(2023, 7, 1, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2023, 8, 1, 0, 0, 0, 0, time.UTC)
+
+	mock.ExpectQuery("SELECT bookings.id, .* FROM bookings WHERE bookings.rental_id = \\$1 AND bookings.status = 'confirmed' "+
+		"AND bookings.start_date < \\$3 AND bookings.end_date > \\$2 ORDER BY bookings.start_date").
+		WithArgs(2, start, end).
+		WillReturnRows(sqlmock.NewRows(_bookingColumns).AddRow(bookingValues(_booking)...))
+
+	bookings, err := storage.NewBookingRepository(db).ListByRental(context.Background(), 2, start, end)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatalf("there were unfulfilled expectations: %s", err)
+	}
+
+	if expected := (model.Bookings{_booking}); !cmp.Equal(bookings, expected) {
+		t.Fatalf("result expectation mismatch: expected %v, got %v", expected, bookings)
+	}
+}
+
+func TestBookingRepository_Cancel(t *testing.T) {
+	updateQuery := "UPDATE bookings SET status = \\$1, cancelled_at = \\$2, updated = \\$3 WHERE id = \\$4 AND status = 'confirmed'"
+
+	testCases := []struct {
+		name          string
+		affected      int64
+		expectedError error
+	}{
+		{
+			name:     "Confirmed booking",
+			affected: 1,
+		},
+		{
+			name:          "Missing or cancelled booking",
+			affected:      0,
+			expectedError: sql.ErrNoRows,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("failed to create sqlmock: %s", err)
+			}
+			defer db.Close()
+
+			mock.ExpectExec(updateQuery).
+				WithArgs(model.BookingStatusCancelled, sqlmock.AnyArg(), sqlmock.AnyArg(), 1).
+				WillReturnResult(sqlmock.NewResult(0, tc.affected))
+
+			err = storage.NewBookingRepository(db).Cancel(context.Background(), 1)
+
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Fatalf("there were unfulfilled expectations: %s", err)
+			}
+
+			if !errors.Is(err, tc.expectedError) {
+				t.Fatalf("error expectation mismatch: expected %v, got %v", tc.expectedError, err)
+			}
+		})
+	}
+}
+
+func bookingValues(b *model.Booking) []driver.Value {
+	return []driver.Value{
+		b.ID,
+		b.RentalID,
+		b.UserID,
+		b.StartDate,
+		b.EndDate,
+		string(b.Status),
+		string(b.CancellationPolicy),
+		b.Created,
+		b.Updated,
+		b.CancelledAt,
+	}
+}
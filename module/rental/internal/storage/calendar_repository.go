@@ -0,0 +1,152 @@
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	model "github.com/dragonator/rental-service/module/rental/internal/model"
+)
+
+// CalendarRepository hold DB operations over the blocked days and stay rules of rentals.
+type CalendarRepository struct {
+	db *sql.DB
+}
+
+// NewCalendarRepository is a constructor function for CalendarRepository.
+func NewCalendarRepository(db *sql.DB) *CalendarRepository {
+	return &CalendarRepository{
+		db: db,
+	}
+}
+
+// GetStayRules returns the stay rules of the rental with the given id.
+// If the owner has not set any it returns the default rules.
+func (cr *CalendarRepository) GetStayRules(ctx context.Context, rentalID int) (*model.StayRules, error) {
+	qb := NewQueryBuilder().
+		Select().
+		Columns("rental_stay_rules.min_nights", "rental_stay_rules.max_nights").
+		From("rental_stay_rules").
+		Where("rental_stay_rules.rental_id = $1")
+
+	rules := new(model.StayRules)
+
+	err := cr.db.QueryRowContext(ctx, qb.String(), rentalID).Scan(&rules.MinNights, &rules.MaxNights)
+	if errors.Is(err, sql.ErrNoRows) {
+		return model.DefaultStayRules(), nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("getting stay rules: %w", err)
+	}
+
+	return rules, nil
+}
+
+// ListBlockedDays returns the days from start up to, but not including, end which the owner
+// of the rental has blocked, in chronological order.
+func (cr *CalendarRepository) ListBlockedDays(ctx context.Context, rentalID int, start, end time.Time) ([]time.Time, error) {
+	qb := NewQueryBuilder().
+		Select().
+		Columns("rental_blocked_days.day").
+		From("rental_blocked_days").
+		Where("rental_blocked_days.rental_id = $1").
+		Where("rental_blocked_days.day >= $2").
+		Where("rental_blocked_days.day < $3").
+		OrderBy("rental_blocked_days.day")
+
+	rows, err := cr.db.QueryContext(ctx, qb.String(), rentalID, start, end)
+	if err != nil {
+		return nil, fmt.Errorf("listing blocked days: %w", err)
+	}
+
+	defer rows.Close()
+
+	days := make([]time.Time, 0, 10)
+
+	for rows.Next() {
+		var day time.Time
+		if err := rows.Scan(&day); err != nil {
+			return nil, fmt.Errorf("scanning blocked day: %w", err)
+		}
+
+		days = append(days, day)
+	}
+
+	return days, nil
+}
+
+// HasBlockedDays reports whether the owner of the rental has blocked any of the days
+// from start up to, but not including, end. If no such rental exists it returns sql.ErrNoRows.
+func (cr *CalendarRepository) HasBlockedDays(ctx context.Context, rentalID int, start, end time.Time) (bool, error) {
+	qb := NewQueryBuilder().
+		Select().
+		Columns("EXISTS (SELECT 1 FROM rental_blocked_days WHERE rental_blocked_days.rental_id = rentals.id " +
+			"AND rental_blocked_days.day >= $2 AND rental_blocked_days.day < $3)").
+		From("rentals").
+		Where("rentals.id = $1")
+
+	var blocked bool
+	if err := cr.db.QueryRowContext(ctx, qb.String(), rentalID, start, end).Scan(&blocked); err != nil {
+		return false, fmt.Errorf("checking blocked days: %w", err)
+	}
+
+	return blocked, nil
+}
+
+// Update stores the stay rules of the rental and replaces its blocked days from start up to,
+// but not including, end with the given ones.
+func (cr *CalendarRepository) Update(
+	ctx context.Context,
+	rentalID int,
+	rules *model.StayRules,
+	start, end time.Time,
+	blockedDays []time.Time,
+) (err error) {
+	tx, err := cr.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("updating calendar: %w", err)
+	}
+
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback()
+		}
+	}()
+
+	upsertRules := NewQueryBuilder().
+		Insert("rental_stay_rules").
+		Columns("rental_id", "min_nights", "max_nights").
+		OnConflict("(rental_id) DO UPDATE SET min_nights = EXCLUDED.min_nights, max_nights = EXCLUDED.max_nights")
+
+	if _, err = tx.ExecContext(ctx, upsertRules.String(), rentalID, rules.MinNights, rules.MaxNights); err != nil {
+		return fmt.Errorf("updating stay rules: %w", err)
+	}
+
+	deleteDays := NewQueryBuilder().
+		Delete("rental_blocked_days").
+		Where("rental_id = $1").
+		Where("day >= $2").
+		Where("day < $3")
+
+	if _, err = tx.ExecContext(ctx, deleteDays.String(), rentalID, start, end); err != nil {
+		return fmt.Errorf("unblocking days: %w", err)
+	}
+
+	insertDay := NewQueryBuilder().
+		Insert("rental_blocked_days").
+		Columns("rental_id", "day")
+
+	for _, day := range blockedDays {
+		if _, err = tx.ExecContext(ctx, insertDay.String(), rentalID, day); err != nil {
+			return fmt.Errorf("blocking day: %w", err)
+		}
+	}
+
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("updating calendar: %w", err)
+	}
+
+	return nil
+}
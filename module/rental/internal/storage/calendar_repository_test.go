@@ -0,0 +1,188 @@
+package storage_test
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/go-cmp/cmp"
+
+	model "github.com/dragonator/rental-service/module/rental/internal/model"
+	"github.com/dragonator/rental-service/module/rental/internal/storage"
+)
+
+var (
+	_julyStart = time.Date(2023, 7, 1, 0, 0, 0, 0, time.UTC)
+	_julyEnd   = time.Date(2023, 8, 1, 0, 0, 0, 0, time.UTC)
+)
+
+func TestCalendarRepository_GetStayRules(t *testing.T) {
+	selectQuery := "SELECT rental_stay_rules.min_nights, rental_stay_rules.max_nights FROM rental_stay_rules " +
+		"WHERE rental_stay_rules.rental_id = \\$1"
+
+	testCases := []struct {
+		name          string
+		expectedRules *model.StayRules
+		mockFunc      func(mock sqlmock.Sqlmock)
+	}{
+		{
+			name:          "Stored rules",
+			expectedRules: &model.StayRules{MinNights: 2, MaxNights: toPtr(14)},
+			mockFunc: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(selectQuery).
+					WithArgs(1).
+					WillReturnRows(sqlmock.NewRows([]string{"min_nights", "max_nights"}).AddRow(2, 14))
+			},
+		},
+		{
+			name:          "Default rules",
+			expectedRules: model.DefaultStayRules(),
+			mockFunc: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(selectQuery).
+					WithArgs(1).
+					WillReturnError(sql.ErrNoRows)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("failed to create sqlmock: %s", err)
+			}
+			defer db.Close()
+
+			tc.mockFunc(mock)
+
+			rules, err := storage.NewCalendarRepository(db).GetStayRules(context.Background(), 1)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Fatalf("there were unfulfilled expectations: %s", err)
+			}
+
+			if !cmp.Equal(rules, tc.expectedRules) {
+				t.Fatalf("result expectation mismatch: expected %v, got %v", tc.expectedRules, rules)
+			}
+		})
+	}
+}
+
+func TestCalendarRepository_ListBlockedDays(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to create sqlmock: %s", err)
+	}
+	defer db.Close()
+
+	blockedDay := time.Date(2023, 7, 10, 0, 0, 0, 0, time.UTC)
+
+	mock.ExpectQuery("SELECT rental_blocked_days.day FROM rental_blocked_days WHERE rental_blocked_days.rental_id = \\$1 "+
+		"AND rental_blocked_days.day >= \\$2 AND rental_blocked_days.day < \\$3 ORDER BY rental_blocked_days.day").
+		WithArgs(1, _julyStart, _julyEnd).
+		WillReturnRows(sqlmock.NewRows([]string{"day"}).AddRow(blockedDay))
+
+	days, err := storage.NewCalendarRepository(db).ListBlockedDays(context.Background(), 1, _julyStart, _julyEnd)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatalf("there were unfulfilled expectations: %s", err)
+	}
+
+	if expected := []time.Time{blockedDay}; !cmp.Equal(days, expected) {
+		t.Fatalf("result expectation mismatch: expected %v, got %v", expected, days)
+	}
+}
+
+func TestCalendarRepository_HasBlockedDays(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to create sqlmock: %s", err)
+	}
+	defer db.Close()
+
+	mock.ExpectQuery("SELECT EXISTS \\(SELECT 1 FROM rental_blocked_days WHERE rental_blocked_days.rental_id = rentals.id "+
+		"AND rental_blocked_days.day >= \\$2 AND rental_blocked_days.day < \\$3\\) FROM rentals WHERE rentals.id = \\$1").
+		WithArgs(1, _julyStart, _julyEnd).
+		WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(true))
+
+	blocked, err := storage.NewCalendarRepository(db).HasBlockedDays(context.Background(), 1, _julyStart, _julyEnd)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatalf("there were unfulfilled expectations: %s", err)
+	}
+
+	if !blocked {
+		t.Fatalf("result expectation mismatch: expected blocked days")
+	}
+}
+
+func TestCalendarRepository_Update(t *testing.T) {
+	upsertQuery := "INSERT INTO rental_stay_rules \\(rental_id, min_nights, max_nights\\) VALUES \\(\\$1, \\$2, \\$3\\) " +
+		"ON CONFLICT \\(rental_id\\) DO UPDATE SET min_nights = EXCLUDED.min_nights, max_nights = EXCLUDED.max_nights"
+	deleteQuery := "DELETE FROM rental_blocked_days WHERE rental_id = \\$1 AND day >= \\$2 AND day < \\$3"
+	insertQuery := "INSERT INTO rental_blocked_days \\(rental_id, day\\) VALUES \\(\\$1, \\$2\\)"
+
+	blockedDay := time.Date(2023, 7, 10, 0, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		name          string
+		expectedError error
+		mockFunc      func(mock sqlmock.Sqlmock)
+	}{
+		{
+			name: "Successful update",
+			mockFunc: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectExec(upsertQuery).WithArgs(1, 2, nil).WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec(deleteQuery).WithArgs(1, _julyStart, _julyEnd).WillReturnResult(sqlmock.NewResult(0, 3))
+				mock.ExpectExec(insertQuery).WithArgs(1, blockedDay).WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
+			},
+		},
+		{
+			name:          "Failed update",
+			expectedError: sql.ErrConnDone,
+			mockFunc: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectExec(upsertQuery).WithArgs(1, 2, nil).WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec(deleteQuery).WithArgs(1, _julyStart, _julyEnd).WillReturnError(sql.ErrConnDone)
+				mock.ExpectRollback()
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("failed to create sqlmock: %s", err)
+			}
+			defer db.Close()
+
+			tc.mockFunc(mock)
+
+			err = storage.NewCalendarRepository(db).Update(context.Background(), 1,
+				&model.StayRules{MinNights: 2}, _julyStart, _julyEnd, []time.Time{blockedDay})
+
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Fatalf("there were unfulfilled expectations: %s", err)
+			}
+
+			if !errors.Is(err, tc.expectedError) {
+				t.Fatalf("error expectation mismatch: expected %v, got %v", tc.expectedError, err)
+			}
+		})
+	}
+}
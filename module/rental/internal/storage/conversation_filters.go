@@ -0,0 +1,8 @@
+package storage
+
+// CursorPagination specifies a page of results following the result with the id held by After.
+// Without After the page starts with the first result.
+type CursorPagination struct {
+	Limit int
+	After *int32
+}
@@ -0,0 +1,326 @@
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgconn"
+
+	model "github.com/dragonator/rental-service/module/rental/internal/model"
+)
+
+// recordMessageQuery moves the last message time of a conversation to a new message, which
+// counts as read by its sender.
+const recordMessageQuery = "UPDATE conversations SET last_message_at = $1, " +
+	"renter_last_read_id = CASE WHEN renter_id = $2 THEN $3 ELSE renter_last_read_id END, " +
+	"owner_last_read_id = CASE WHEN owner_id = $2 THEN $3 ELSE owner_last_read_id END " +
+	"WHERE id = $4"
+
+// markReadQuery marks every message of a conversation as read by one of its participants.
+const markReadQuery = "UPDATE conversations SET " +
+	"renter_last_read_id = CASE WHEN renter_id = $2 THEN COALESCE(last_message.id, 0) ELSE renter_last_read_id END, " +
+	"owner_last_read_id = CASE WHEN owner_id = $2 THEN COALESCE(last_message.id, 0) ELSE owner_last_read_id END " +
+	"FROM (SELECT MAX(messages.id) AS id FROM messages WHERE messages.conversation_id = $1) AS last_message " +
+	"WHERE conversations.id = $1"
+
+// ErrConversationExists is returned when the renter already has a conversation about the rental.
+var ErrConversationExists = errors.New("conversation already exists")
+
+var (
+	conversationColumns = []string{
+		"conversations.id",
+		"conversations.rental_id",
+		"conversations.renter_id",
+		"conversations.owner_id",
+		"conversations.last_message_at",
+		"conversations.created",
+	}
+	messageColumns = []string{
+		"messages.id",
+		"messages.conversation_id",
+		"messages.sender_id",
+		"messages.body",
+		"messages.created",
+	}
+)
+
+// ConversationRepository hold DB operations over conversation and message entities.
+type ConversationRepository struct {
+	db *sql.DB
+}
+
+// NewConversationRepository is a constructor function for ConversationRepository.
+func NewConversationRepository(db *sql.DB) *ConversationRepository {
+	return &ConversationRepository{
+		db: db,
+	}
+}
+
+// GetByID returns a single conversation object corresponding to the requested id.
+// If no such conversation exists it returns an error.
+func (cr *ConversationRepository) GetByID(ctx context.Context, conversationID int) (*model.Conversation, error) {
+	qb := NewQueryBuilder().
+		Select().
+		Columns(conversationColumns...).
+		Columns("0").
+		From("conversations").
+		Where("conversations.id = $1")
+
+	conversation, err := scanConversation(cr.db.QueryRowContext(ctx, qb.String(), conversationID))
+	if err != nil {
+		return nil, fmt.Errorf("getting conversation by id: %w", err)
+	}
+
+	return conversation, nil
+}
+
+// ListByUser returns the conversations the user with the given id takes part in, along with the
+// number of messages unread by the user, ordered by their last message with the latest first.
+func (cr *ConversationRepository) ListByUser(ctx context.Context, userID int) (model.Conversations, error) {
+	qb := NewQueryBuilder().
+		Select().
+		Columns(conversationColumns...).
+		Columns(unreadCount("$1")).
+		From("conversations").
+		Where("(conversations.renter_id = $1 OR conversations.owner_id = $1)").
+		OrderBy("conversations.last_message_at DESC, conversations.id DESC")
+
+	rows, err := cr.db.QueryContext(ctx, qb.String(), userID)
+	if err != nil {
+		return nil, fmt.Errorf("listing conversations: %w", err)
+	}
+
+	defer rows.Close()
+
+	conversations := make(model.Conversations, 0, 10)
+
+	for rows.Next() {
+		conversation, err := scanConversation(rows)
+		if err != nil {
+			return nil, fmt.Errorf("scanning conversation: %w", err)
+		}
+
+		conversations = append(conversations, conversation)
+	}
+
+	return conversations, nil
+}
+
+// CountUnread returns the number of messages unread by the user with the given id across all of their conversations.
+func (cr *ConversationRepository) CountUnread(ctx context.Context, userID int) (int, error) {
+	qb := NewQueryBuilder().
+		Select().
+		Columns(fmt.Sprintf("COALESCE(SUM(%s), 0)", unreadCount("$1"))).
+		From("conversations").
+		Where("(conversations.renter_id = $1 OR conversations.owner_id = $1)")
+
+	var count int
+	if err := cr.db.QueryRowContext(ctx, qb.String(), userID).Scan(&count); err != nil {
+		return 0, fmt.Errorf("counting unread messages: %w", err)
+	}
+
+	return count, nil
+}
+
+// Create inserts a new conversation starting with the given message from the renter and returns
+// the id assigned to the conversation. If the renter already has a conversation about the rental
+// it returns ErrConversationExists.
+func (cr *ConversationRepository) Create(ctx context.Context, conversation *model.Conversation, message *model.Message) (_ int, err error) {
+	tx, err := cr.db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, fmt.Errorf("creating conversation: %w", err)
+	}
+
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback()
+		}
+	}()
+
+	qb := NewQueryBuilder().
+		Insert("conversations").
+		Columns("rental_id", "renter_id", "owner_id", "last_message_at", "created").
+		Returning("id")
+
+	now := time.Now().UTC()
+
+	var conversationID int
+	err = tx.QueryRowContext(ctx, qb.String(),
+		conversation.RentalID,
+		conversation.RenterID,
+		conversation.OwnerID,
+		now,
+		now,
+	).Scan(&conversationID)
+
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == _uniqueViolation {
+		return 0, fmt.Errorf("creating conversation: %w", ErrConversationExists)
+	}
+	if err != nil {
+		return 0, fmt.Errorf("creating conversation: %w", err)
+	}
+
+	if _, err = insertMessage(ctx, tx, int32(conversationID), message.SenderID, message.Body, now); err != nil {
+		return 0, err
+	}
+
+	if err = tx.Commit(); err != nil {
+		return 0, fmt.Errorf("creating conversation: %w", err)
+	}
+
+	return conversationID, nil
+}
+
+// GetMessageByID returns a single message object corresponding to the requested id.
+// If no such message exists it returns an error.
+func (cr *ConversationRepository) GetMessageByID(ctx context.Context, messageID int) (*model.Message, error) {
+	qb := NewQueryBuilder().
+		Select().
+		Columns(messageColumns...).
+		From("messages").
+		Where("messages.id = $1")
+
+	message, err := scanMessage(cr.db.QueryRowContext(ctx, qb.String(), messageID))
+	if err != nil {
+		return nil, fmt.Errorf("getting message by id: %w", err)
+	}
+
+	return message, nil
+}
+
+// ListMessages returns a page of the messages of the conversation with the given id, latest first.
+func (cr *ConversationRepository) ListMessages(ctx context.Context, conversationID int, page *CursorPagination) (model.Messages, error) {
+	qb := NewQueryBuilder().
+		Select().
+		Columns(messageColumns...).
+		From("messages")
+
+	qb.Where(fmt.Sprintf("messages.conversation_id = %s", qb.Arg(conversationID)))
+
+	if page.After != nil {
+		qb.Where(fmt.Sprintf("messages.id < %s", qb.Arg(*page.After)))
+	}
+
+	qb.OrderBy("messages.id DESC").
+		Limit(page.Limit)
+
+	rows, err := cr.db.QueryContext(ctx, qb.String(), qb.Args()...)
+	if err != nil {
+		return nil, fmt.Errorf("listing messages: %w", err)
+	}
+
+	defer rows.Close()
+
+	messages := make(model.Messages, 0, page.Limit)
+
+	for rows.Next() {
+		message, err := scanMessage(rows)
+		if err != nil {
+			return nil, fmt.Errorf("scanning message: %w", err)
+		}
+
+		messages = append(messages, message)
+	}
+
+	return messages, nil
+}
+
+// CreateMessage posts a new message to its conversation and returns the id assigned to it.
+// The message counts as read by its sender.
+func (cr *ConversationRepository) CreateMessage(ctx context.Context, message *model.Message) (_ int, err error) {
+	tx, err := cr.db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, fmt.Errorf("creating message: %w", err)
+	}
+
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback()
+		}
+	}()
+
+	messageID, err := insertMessage(ctx, tx, message.ConversationID, message.SenderID, message.Body, time.Now().UTC())
+	if err != nil {
+		return 0, err
+	}
+
+	if err = tx.Commit(); err != nil {
+		return 0, fmt.Errorf("creating message: %w", err)
+	}
+
+	return messageID, nil
+}
+
+// MarkRead marks every message of the conversation with the given id as read by the user with the given id.
+func (cr *ConversationRepository) MarkRead(ctx context.Context, conversationID, userID int) error {
+	if _, err := cr.db.ExecContext(ctx, markReadQuery, conversationID, userID); err != nil {
+		return fmt.Errorf("marking conversation as read: %w", err)
+	}
+
+	return nil
+}
+
+// insertMessage inserts a message and records it as the last message of its conversation.
+func insertMessage(ctx context.Context, tx *sql.Tx, conversationID, senderID int32, body string, created time.Time) (int, error) {
+	qb := NewQueryBuilder().
+		Insert("messages").
+		Columns("conversation_id", "sender_id", "body", "created").
+		Returning("id")
+
+	var messageID int
+	if err := tx.QueryRowContext(ctx, qb.String(), conversationID, senderID, body, created).Scan(&messageID); err != nil {
+		return 0, fmt.Errorf("creating message: %w", err)
+	}
+
+	if _, err := tx.ExecContext(ctx, recordMessageQuery, created, senderID, messageID, conversationID); err != nil {
+		return 0, fmt.Errorf("updating conversation: %w", err)
+	}
+
+	return messageID, nil
+}
+
+// unreadCount returns an expression counting the messages of a conversation which were
+// neither sent nor read by the user with the id held by the given placeholder.
+func unreadCount(userID string) string {
+	return fmt.Sprintf("(SELECT COUNT(*) FROM messages WHERE messages.conversation_id = conversations.id "+
+		"AND messages.sender_id <> %[1]s AND messages.id > CASE WHEN conversations.renter_id = %[1]s "+
+		"THEN conversations.renter_last_read_id ELSE conversations.owner_last_read_id END)", userID)
+}
+
+func scanConversation(row rowScanner) (*model.Conversation, error) {
+	conversation := new(model.Conversation)
+
+	if err := row.Scan(
+		&conversation.ID,
+		&conversation.RentalID,
+		&conversation.RenterID,
+		&conversation.OwnerID,
+		&conversation.LastMessageAt,
+		&conversation.Created,
+		&conversation.UnreadCount,
+	); err != nil {
+		return nil, err
+	}
+
+	return conversation, nil
+}
+
+func scanMessage(row rowScanner) (*model.Message, error) {
+	message := new(model.Message)
+
+	if err := row.Scan(
+		&message.ID,
+		&message.ConversationID,
+		&message.SenderID,
+		&message.Body,
+		&message.Created,
+	); err != nil {
+		return nil, err
+	}
+
+	return message, nil
+}
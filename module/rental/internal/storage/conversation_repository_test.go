@@ -0,0 +1,148 @@
+package storage_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/go-cmp/cmp"
+	"github.com/jackc/pgx/v5/pgconn"
+
+	model "github.com/dragonator/rental-service/module/rental/internal/model"
+	"github.com/dragonator/rental-service/module/rental/internal/storage"
+)
+
+var _messageColumns = []string{
+	"messages.id",
+	"messages.conversation_id",
+	"messages.sender_id",
+	"messages.body",
+	"messages.created",
+}
+
+func TestConversationRepository_Create(t *testing.T) {
+	insertQuery := "INSERT INTO conversations \\(rental_id, renter_id, owner_id, last_message_at, created\\) " +
+		"VALUES \\(\\$1, \\$2, \\$3, \\$4, \\$5\\) RETURNING id"
+	conversation := &model.Conversation{RentalID: 1, RenterID: 2, OwnerID: 3}
+	message := &model.Message{SenderID: 2, Body: "Is the van pet friendly?"}
+
+	testCases := []struct {
+		name          string
+		expectedID    int
+		expectedError error
+		mockFunc      func(mock sqlmock.Sqlmock)
+	}{
+		{
+			name:       "New conversation",
+			expectedID: 4,
+			mockFunc: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery(insertQuery).
+					WithArgs(1, 2, 3, sqlmock.AnyArg(), sqlmock.AnyArg()).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(4))
+				mock.ExpectQuery("INSERT INTO messages \\(conversation_id, sender_id, body, created\\) "+
+					"VALUES \\(\\$1, \\$2, \\$3, \\$4\\) RETURNING id").
+					WithArgs(4, 2, message.Body, sqlmock.AnyArg()).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(7))
+				mock.ExpectExec("UPDATE conversations SET last_message_at = \\$1, .* WHERE id = \\$4").
+					WithArgs(sqlmock.AnyArg(), 2, 7, 4).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
+			},
+		},
+		{
+			name:          "Existing conversation",
+			expectedError: storage.ErrConversationExists,
+			mockFunc: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery(insertQuery).
+					WillReturnError(&pgconn.PgError{Code: "23505"})
+				mock.ExpectRollback()
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("failed to create sqlmock: %s", err)
+			}
+			defer db.Close()
+
+			tc.mockFunc(mock)
+
+			conversationID, err := storage.NewConversationRepository(db).Create(context.Background(), conversation, message)
+
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Fatalf("there were unfulfilled expectations: %s", err)
+			}
+
+			if !errors.Is(err, tc.expectedError) {
+				t.Fatalf("error expectation mismatch: expected %v, got %v", tc.expectedError, err)
+			}
+
+			if conversationID != tc.expectedID {
+				t.Fatalf("result expectation mismatch: expected %d, got %d", tc.expectedID, conversationID)
+			}
+		})
+	}
+}
+
+func TestConversationRepository_ListMessages(t *testing.T) {
+	selectQuery := "SELECT messages.id, .* FROM messages WHERE messages.conversation_id = \\$1"
+	created := time.Date(2023, 7, 1, 10, 0, 0, 0, time.UTC)
+	message := &model.Message{ID: 5, ConversationID: 4, SenderID: 2, Body: "Hello", Created: created}
+
+	testCases := []struct {
+		name     string
+		page     *storage.CursorPagination
+		mockFunc func(mock sqlmock.Sqlmock)
+	}{
+		{
+			name: "First page",
+			page: &storage.CursorPagination{Limit: 2},
+			mockFunc: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(selectQuery + " ORDER BY messages.id DESC LIMIT 2").
+					WithArgs(4).
+					WillReturnRows(sqlmock.NewRows(_messageColumns).AddRow(5, 4, 2, "Hello", created))
+			},
+		},
+		{
+			name: "Page after cursor",
+			page: &storage.CursorPagination{Limit: 2, After: toPtr(int32(6))},
+			mockFunc: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(selectQuery+" AND messages.id < \\$2 ORDER BY messages.id DESC LIMIT 2").
+					WithArgs(4, int32(6)).
+					WillReturnRows(sqlmock.NewRows(_messageColumns).AddRow(5, 4, 2, "Hello", created))
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("failed to create sqlmock: %s", err)
+			}
+			defer db.Close()
+
+			tc.mockFunc(mock)
+
+			messages, err := storage.NewConversationRepository(db).ListMessages(context.Background(), 4, tc.page)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Fatalf("there were unfulfilled expectations: %s", err)
+			}
+
+			if expected := (model.Messages{message}); !cmp.Equal(messages, expected) {
+				t.Fatalf("result expectation mismatch: expected %v, got %v", expected, messages)
+			}
+		})
+	}
+}
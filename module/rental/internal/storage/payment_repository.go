@@ -0,0 +1,252 @@
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	model "github.com/dragonator/rental-service/module/rental/internal/model"
+)
+
+var (
+	paymentIntentColumns = []string{
+		"payment_intents.id",
+		"payment_intents.booking_id",
+		"payment_intents.amount",
+		"payment_intents.refunded_amount",
+		"payment_intents.status",
+		"payment_intents.provider_ref",
+		"payment_intents.created",
+		"payment_intents.updated",
+	}
+	paymentTransitionColumns = []string{
+		"payment_transitions.id",
+		"payment_transitions.payment_intent_id",
+		"COALESCE(payment_transitions.from_status, '')",
+		"payment_transitions.to_status",
+		"payment_transitions.amount",
+		"payment_transitions.created",
+	}
+	paymentTransitionWriteColumns = []string{
+		"payment_intent_id",
+		"from_status",
+		"to_status",
+		"amount",
+		"created",
+	}
+)
+
+// PaymentRepository hold DB operations over payment intent entities and their transitions.
+type PaymentRepository struct {
+	db *sql.DB
+}
+
+// NewPaymentRepository is a constructor function for PaymentRepository.
+func NewPaymentRepository(db *sql.DB) *PaymentRepository {
+	return &PaymentRepository{
+		db: db,
+	}
+}
+
+// GetByBooking returns the payment intent of the booking with the given id along with its transitions.
+// If the booking has no payment intent it returns an error.
+func (pr *PaymentRepository) GetByBooking(ctx context.Context, bookingID int) (*model.PaymentIntent, error) {
+	qb := NewQueryBuilder().
+		Select().
+		Columns(paymentIntentColumns...).
+		From("payment_intents").
+		Where("payment_intents.booking_id = $1")
+
+	intent, err := scanPaymentIntent(pr.db.QueryRowContext(ctx, qb.String(), bookingID))
+	if err != nil {
+		return nil, fmt.Errorf("getting payment intent by booking: %w", err)
+	}
+
+	transitions, err := pr.listTransitions(ctx, intent.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	intent.Transitions = transitions
+
+	return intent, nil
+}
+
+// Create inserts a new pending payment intent along with the transition creating it
+// and returns the id assigned to it.
+func (pr *PaymentRepository) Create(ctx context.Context, intent *model.PaymentIntent) (_ int, err error) {
+	tx, err := pr.db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, fmt.Errorf("creating payment intent: %w", err)
+	}
+
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback()
+		}
+	}()
+
+	insert := NewQueryBuilder().
+		Insert("payment_intents").
+		Columns("booking_id", "amount", "status", "created", "updated").
+		Returning("id")
+
+	now := time.Now().UTC()
+
+	var intentID int
+	if err = tx.QueryRowContext(ctx, insert.String(),
+		intent.BookingID,
+		intent.Amount,
+		model.PaymentStatusPending,
+		now,
+		now,
+	).Scan(&intentID); err != nil {
+		return 0, fmt.Errorf("creating payment intent: %w", err)
+	}
+
+	if err = insertPaymentTransition(ctx, tx, intentID, nil, model.PaymentStatusPending, intent.Amount, now); err != nil {
+		return 0, err
+	}
+
+	if err = tx.Commit(); err != nil {
+		return 0, fmt.Errorf("creating payment intent: %w", err)
+	}
+
+	return intentID, nil
+}
+
+// Transition moves the payment intent from its current status to the next one, storing its provider
+// reference and refunded amount, and records the change along with the amount it moved.
+// If the intent is no longer in its current status it returns sql.ErrNoRows.
+func (pr *PaymentRepository) Transition(ctx context.Context, intent *model.PaymentIntent, next model.PaymentStatus, amount int64) (err error) {
+	tx, err := pr.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("transitioning payment intent: %w", err)
+	}
+
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback()
+		}
+	}()
+
+	update := NewQueryBuilder().
+		Update("payment_intents").
+		Columns("status", "provider_ref", "refunded_amount", "updated").
+		Where("id = $5").
+		Where("status = $6")
+
+	now := time.Now().UTC()
+
+	result, err := tx.ExecContext(ctx, update.String(),
+		next,
+		intent.ProviderRef,
+		intent.RefundedAmount,
+		now,
+		intent.ID,
+		intent.Status,
+	)
+	if err != nil {
+		return fmt.Errorf("transitioning payment intent: %w", err)
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("transitioning payment intent: %w", err)
+	}
+
+	if affected == 0 {
+		err = sql.ErrNoRows
+		return fmt.Errorf("transitioning payment intent: %w", err)
+	}
+
+	if err = insertPaymentTransition(ctx, tx, int(intent.ID), intent.Status, next, amount, now); err != nil {
+		return err
+	}
+
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("transitioning payment intent: %w", err)
+	}
+
+	return nil
+}
+
+func (pr *PaymentRepository) listTransitions(ctx context.Context, intentID int32) (model.PaymentTransitions, error) {
+	qb := NewQueryBuilder().
+		Select().
+		Columns(paymentTransitionColumns...).
+		From("payment_transitions").
+		Where("payment_transitions.payment_intent_id = $1").
+		OrderBy("payment_transitions.id")
+
+	rows, err := pr.db.QueryContext(ctx, qb.String(), intentID)
+	if err != nil {
+		return nil, fmt.Errorf("listing payment transitions: %w", err)
+	}
+
+	defer rows.Close()
+
+	transitions := make(model.PaymentTransitions, 0, 4)
+
+	for rows.Next() {
+		transition, err := scanPaymentTransition(rows)
+		if err != nil {
+			return nil, fmt.Errorf("scanning payment transition: %w", err)
+		}
+
+		transitions = append(transitions, transition)
+	}
+
+	return transitions, nil
+}
+
+// insertPaymentTransition records a change of the status of a payment intent. A nil from
+// stands for the creation of the intent.
+func insertPaymentTransition(ctx context.Context, tx *sql.Tx, intentID int, from any, to model.PaymentStatus, amount int64, created time.Time) error {
+	qb := NewQueryBuilder().
+		Insert("payment_transitions").
+		Columns(paymentTransitionWriteColumns...)
+
+	if _, err := tx.ExecContext(ctx, qb.String(), intentID, from, to, amount, created); err != nil {
+		return fmt.Errorf("recording payment transition: %w", err)
+	}
+
+	return nil
+}
+
+func scanPaymentIntent(row rowScanner) (*model.PaymentIntent, error) {
+	intent := new(model.PaymentIntent)
+
+	if err := row.Scan(
+		&intent.ID,
+		&intent.BookingID,
+		&intent.Amount,
+		&intent.RefundedAmount,
+		&intent.Status,
+		&intent.ProviderRef,
+		&intent.Created,
+		&intent.Updated,
+	); err != nil {
+		return nil, err
+	}
+
+	return intent, nil
+}
+
+func scanPaymentTransition(row rowScanner) (*model.PaymentTransition, error) {
+	transition := new(model.PaymentTransition)
+
+	if err := row.Scan(
+		&transition.ID,
+		&transition.PaymentIntentID,
+		&transition.From,
+		&transition.To,
+		&transition.Amount,
+		&transition.Created,
+	); err != nil {
+		return nil, err
+	}
+
+	return transition, nil
+}
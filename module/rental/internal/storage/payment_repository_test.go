@@ -0,0 +1,73 @@
+package storage_test
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+
+	"github.com/dragonator/rental-service/module/rental/internal/model"
+	"github.com/dragonator/rental-service/module/rental/internal/storage"
+)
+
+func TestPaymentRepository_Transition(t *testing.T) {
+	testCases := []struct {
+		name          string
+		affected      int64
+		expectedError error
+	}{
+		{
+			name:     "Current status",
+			affected: 1,
+		},
+		{
+			name:          "Stale status",
+			affected:      0,
+			expectedError: sql.ErrNoRows,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("failed to create sqlmock: %s", err)
+			}
+			defer db.Close()
+
+			intent := &model.PaymentIntent{
+				ID:          1,
+				Amount:      50000,
+				Status:      model.PaymentStatusAuthorized,
+				ProviderRef: "fake_auth_1",
+			}
+
+			mock.ExpectBegin()
+			mock.ExpectExec("UPDATE payment_intents SET status = \\$1, provider_ref = \\$2, refunded_amount = \\$3, updated = \\$4 "+
+				"WHERE id = \\$5 AND status = \\$6").
+				WithArgs(model.PaymentStatusCaptured, "fake_auth_1", int64(0), sqlmock.AnyArg(), int32(1), model.PaymentStatusAuthorized).
+				WillReturnResult(sqlmock.NewResult(0, tc.affected))
+
+			if tc.expectedError == nil {
+				mock.ExpectExec("INSERT INTO payment_transitions \\(payment_intent_id, from_status, to_status, amount, created\\)").
+					WithArgs(1, model.PaymentStatusAuthorized, model.PaymentStatusCaptured, int64(50000), sqlmock.AnyArg()).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectCommit()
+			} else {
+				mock.ExpectRollback()
+			}
+
+			err = storage.NewPaymentRepository(db).Transition(context.Background(), intent, model.PaymentStatusCaptured, intent.Amount)
+
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Fatalf("there were unfulfilled expectations: %s", err)
+			}
+
+			if !errors.Is(err, tc.expectedError) {
+				t.Fatalf("error expectation mismatch: expected %v, got %v", tc.expectedError, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,163 @@
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	model "github.com/dragonator/rental-service/module/rental/internal/model"
+)
+
+var (
+	pricingRuleColumns = []string{
+		"pricing_rules.id",
+		"pricing_rules.rental_id",
+		"pricing_rules.type",
+		"pricing_rules.start_date",
+		"pricing_rules.end_date",
+		"pricing_rules.price_per_day",
+		"pricing_rules.percent",
+	}
+	pricingRuleWriteColumns = []string{
+		"rental_id",
+		"type",
+		"start_date",
+		"end_date",
+		"price_per_day",
+		"percent",
+	}
+)
+
+// PricingRuleRepository hold DB operations over pricing rule entities.
+type PricingRuleRepository struct {
+	db *sql.DB
+}
+
+// NewPricingRuleRepository is a constructor function for PricingRuleRepository.
+func NewPricingRuleRepository(db *sql.DB) *PricingRuleRepository {
+	return &PricingRuleRepository{
+		db: db,
+	}
+}
+
+// GetByID returns a single pricing rule object corresponding to the requested id.
+// If no such rule exists it returns an error.
+func (pr *PricingRuleRepository) GetByID(ctx context.Context, ruleID int) (*model.PricingRule, error) {
+	qb := NewQueryBuilder().
+		Select().
+		Columns(pricingRuleColumns...).
+		From("pricing_rules").
+		Where("pricing_rules.id = $1")
+
+	rule, err := scanPricingRule(pr.db.QueryRowContext(ctx, qb.String(), ruleID))
+	if err != nil {
+		return nil, fmt.Errorf("getting pricing rule by id: %w", err)
+	}
+
+	return rule, nil
+}
+
+// ListByRental returns the pricing rules of the rental with the given id ordered by id.
+func (pr *PricingRuleRepository) ListByRental(ctx context.Context, rentalID int) (model.PricingRules, error) {
+	qb := NewQueryBuilder().
+		Select().
+		Columns(pricingRuleColumns...).
+		From("pricing_rules").
+		Where("pricing_rules.rental_id = $1").
+		OrderBy("pricing_rules.id")
+
+	rows, err := pr.db.QueryContext(ctx, qb.String(), rentalID)
+	if err != nil {
+		return nil, fmt.Errorf("listing pricing rules: %w", err)
+	}
+
+	defer rows.Close()
+
+	rules := make(model.PricingRules, 0, 4)
+
+	for rows.Next() {
+		rule, err := scanPricingRule(rows)
+		if err != nil {
+			return nil, fmt.Errorf("scanning pricing rule: %w", err)
+		}
+
+		rules = append(rules, rule)
+	}
+
+	return rules, nil
+}
+
+// Create inserts a new pricing rule and returns the id assigned to it.
+func (pr *PricingRuleRepository) Create(ctx context.Context, rule *model.PricingRule) (int, error) {
+	qb := NewQueryBuilder().
+		Insert("pricing_rules").
+		Columns(pricingRuleWriteColumns...).
+		Returning("id")
+
+	var ruleID int
+	if err := pr.db.QueryRowContext(ctx, qb.String(), pricingRuleWriteValues(rule)...).Scan(&ruleID); err != nil {
+		return 0, fmt.Errorf("creating pricing rule: %w", err)
+	}
+
+	return ruleID, nil
+}
+
+// Update overwrites the stored pricing rule with the given one.
+// If no rule matches it returns sql.ErrNoRows.
+func (pr *PricingRuleRepository) Update(ctx context.Context, rule *model.PricingRule) error {
+	qb := NewQueryBuilder().
+		Update("pricing_rules").
+		Columns(pricingRuleWriteColumns...).
+		Where("id = $7")
+
+	args := append(pricingRuleWriteValues(rule), rule.ID)
+
+	if err := execAffectingRow(ctx, pr.db, qb.String(), args...); err != nil {
+		return fmt.Errorf("updating pricing rule: %w", err)
+	}
+
+	return nil
+}
+
+// Delete removes the pricing rule with the given id.
+// If no rule matches it returns sql.ErrNoRows.
+func (pr *PricingRuleRepository) Delete(ctx context.Context, ruleID int) error {
+	qb := NewQueryBuilder().
+		Delete("pricing_rules").
+		Where("id = $1")
+
+	if err := execAffectingRow(ctx, pr.db, qb.String(), ruleID); err != nil {
+		return fmt.Errorf("deleting pricing rule: %w", err)
+	}
+
+	return nil
+}
+
+func pricingRuleWriteValues(rule *model.PricingRule) []any {
+	return []any{
+		rule.RentalID,
+		rule.Type,
+		rule.StartDate,
+		rule.EndDate,
+		rule.PricePerDay,
+		rule.Percent,
+	}
+}
+
+func scanPricingRule(row rowScanner) (*model.PricingRule, error) {
+	rule := new(model.PricingRule)
+
+	if err := row.Scan(
+		&rule.ID,
+		&rule.RentalID,
+		&rule.Type,
+		&rule.StartDate,
+		&rule.EndDate,
+		&rule.PricePerDay,
+		&rule.Percent,
+	); err != nil {
+		return nil, err
+	}
+
+	return rule, nil
+}
@@ -0,0 +1,105 @@
+package storage_test
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/go-cmp/cmp"
+
+	model "github.com/dragonator/rental-service/module/rental/internal/model"
+	"github.com/dragonator/rental-service/module/rental/internal/storage"
+)
+
+var _pricingRuleColumns = []string{"id", "rental_id", "type", "start_date", "end_date", "price_per_day", "percent"}
+
+func TestPricingRuleRepository_ListByRental(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to create sqlmock: %s", err)
+	}
+	defer db.Close()
+
+	seasonStart := time.Date(2023, 7, 1, 0, 0, 0, 0, time.UTC)
+	seasonEnd := time.Date(2023, 9, 1, 0, 0, 0, 0, time.UTC)
+
+	mock.ExpectQuery("SELECT pricing_rules.id, pricing_rules.rental_id, pricing_rules.type, pricing_rules.start_date, " +
+		"pricing_rules.end_date, pricing_rules.price_per_day, pricing_rules.percent FROM pricing_rules " +
+		"WHERE pricing_rules.rental_id = \\$1 ORDER BY pricing_rules.id").
+		WithArgs(2).
+		WillReturnRows(sqlmock.NewRows(_pricingRuleColumns).
+			AddRow(1, 2, "season", seasonStart, seasonEnd, 20000, nil).
+			AddRow(2, 2, "weekly_discount", nil, nil, nil, 10.5))
+
+	rules, err := storage.NewPricingRuleRepository(db).ListByRental(context.Background(), 2)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatalf("there were unfulfilled expectations: %s", err)
+	}
+
+	expectedRules := model.PricingRules{
+		{ID: 1, RentalID: 2, Type: model.PricingRuleSeason, StartDate: &seasonStart, EndDate: &seasonEnd, PricePerDay: toPtr[int64](20000)},
+		{ID: 2, RentalID: 2, Type: model.PricingRuleWeeklyDiscount, Percent: toPtr(10.5)},
+	}
+	if !cmp.Equal(rules, expectedRules) {
+		t.Fatalf("result expectation mismatch: expected %v, got %v", expectedRules, rules)
+	}
+}
+
+func TestPricingRuleRepository_Update(t *testing.T) {
+	updateQuery := "UPDATE pricing_rules SET rental_id = \\$1, type = \\$2, start_date = \\$3, end_date = \\$4, " +
+		"price_per_day = \\$5, percent = \\$6 WHERE id = \\$7"
+	rule := &model.PricingRule{ID: 3, RentalID: 2, Type: model.PricingRuleWeekendSurcharge, Percent: toPtr(15.0)}
+
+	testCases := []struct {
+		name          string
+		expectedError error
+		mockFunc      func(mock sqlmock.Sqlmock)
+	}{
+		{
+			name: "Existing rule",
+			mockFunc: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec(updateQuery).
+					WithArgs(rule.RentalID, rule.Type, rule.StartDate, rule.EndDate, rule.PricePerDay, rule.Percent, rule.ID).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+		},
+		{
+			name:          "Missing rule",
+			expectedError: sql.ErrNoRows,
+			mockFunc: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec(updateQuery).
+					WithArgs(rule.RentalID, rule.Type, rule.StartDate, rule.EndDate, rule.PricePerDay, rule.Percent, rule.ID).
+					WillReturnResult(sqlmock.NewResult(0, 0))
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("failed to create sqlmock: %s", err)
+			}
+			defer db.Close()
+
+			tc.mockFunc(mock)
+
+			err = storage.NewPricingRuleRepository(db).Update(context.Background(), rule)
+
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Fatalf("there were unfulfilled expectations: %s", err)
+			}
+
+			if err != tc.expectedError && !errors.Is(err, tc.expectedError) {
+				t.Fatalf("error expectation mismatch: %s", cmp.Diff(err, tc.expectedError))
+			}
+		})
+	}
+}
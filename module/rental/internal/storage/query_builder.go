@@ -1,10 +1,18 @@
 package storage
 
 import (
+	"fmt"
 	"strconv"
 	"strings"
 )
 
+const (
+	_queryTypeSelect = "SELECT"
+	_queryTypeInsert = "INSERT INTO"
+	_queryTypeUpdate = "UPDATE"
+	_queryTypeDelete = "DELETE FROM"
+)
+
 // QueryBuilder provides convenient API to construct SQL queries.
 type QueryBuilder struct {
 	queryType   string
@@ -14,7 +22,10 @@ type QueryBuilder struct {
 	conditions  []string
 	limit       *int
 	offset      *int
-	orderBy     *string
+	orderBy     []string
+	onConflict  *string
+	returning   []string
+	args        []any
 }
 
 // NewQueryBuilder is a constructor function for QueryBuilder.
@@ -24,7 +35,15 @@ func NewQueryBuilder() *QueryBuilder {
 
 // Select defines a SELECT query type.
 func (qb *QueryBuilder) Select() *QueryBuilder {
-	qb.queryType = "SELECT "
+	qb.queryType = _queryTypeSelect
+	return qb
+}
+
+// Insert defines an INSERT query type for the given table. A positional
+// placeholder is generated for every column set with Columns.
+func (qb *QueryBuilder) Insert(table string) *QueryBuilder {
+	qb.queryType = _queryTypeInsert
+	qb.targetTable = table
 	return qb
 }
 
@@ -34,6 +53,15 @@ func (qb *QueryBuilder) From(table string) *QueryBuilder {
 	return qb
 }
 
+// FromSubquery defines a FROM clause selecting from the given query under the given alias.
+// The arguments of the subquery are carried over, so placeholders registered afterwards with Arg
+// continue its numbering.
+func (qb *QueryBuilder) FromSubquery(subquery *QueryBuilder, alias string) *QueryBuilder {
+	qb.targetTable = fmt.Sprintf("(%s) %s", subquery.String(), alias)
+	qb.args = append(qb.args, subquery.args...)
+	return qb
+}
+
 // Join defines a JOIN clause.
 func (qb *QueryBuilder) Join(joins ...string) *QueryBuilder {
 	qb.joins = append(qb.joins, joins...)
@@ -64,17 +92,135 @@ func (qb *QueryBuilder) Offset(offset int) *QueryBuilder {
 	return qb
 }
 
-// OrderBy defines a ORDER BY clause.
-func (qb *QueryBuilder) OrderBy(orderBy string) *QueryBuilder {
-	qb.orderBy = &orderBy
+// OrderBy defines a ORDER BY clause. Terms of repeated calls are appended to the clause.
+func (qb *QueryBuilder) OrderBy(terms ...string) *QueryBuilder {
+	qb.orderBy = append(qb.orderBy, terms...)
+	return qb
+}
+
+// Update defines an UPDATE query type for the given table. Every column set with
+// Columns is assigned a positional placeholder, so placeholders used in conditions
+// should continue the numbering after them.
+func (qb *QueryBuilder) Update(table string) *QueryBuilder {
+	qb.queryType = _queryTypeUpdate
+	qb.targetTable = table
+	return qb
+}
+
+// Delete defines a DELETE query type for the given table.
+func (qb *QueryBuilder) Delete(table string) *QueryBuilder {
+	qb.queryType = _queryTypeDelete
+	qb.targetTable = table
 	return qb
 }
 
+// OnConflict defines an ON CONFLICT clause of an INSERT query, e.g. "(id) DO NOTHING".
+func (qb *QueryBuilder) OnConflict(clause string) *QueryBuilder {
+	qb.onConflict = &clause
+	return qb
+}
+
+// Returning defines a RETURNING clause.
+func (qb *QueryBuilder) Returning(columns ...string) *QueryBuilder {
+	qb.returning = append(qb.returning, columns...)
+	return qb
+}
+
+// Arg registers a query argument and returns the positional placeholder referring to it.
+// Placeholders are numbered in the order of registration starting from 1.
+func (qb *QueryBuilder) Arg(value any) string {
+	qb.args = append(qb.args, value)
+	return fmt.Sprintf("$%d", len(qb.args))
+}
+
+// Args returns the query arguments registered with Arg.
+func (qb *QueryBuilder) Args() []any {
+	return qb.args
+}
+
 // String returns the constructed query as string.
 func (qb *QueryBuilder) String() string {
 	var sb strings.Builder
 
-	sb.WriteString(qb.queryType)
+	switch qb.queryType {
+	case _queryTypeInsert:
+		qb.writeInsert(&sb)
+	case _queryTypeUpdate:
+		qb.writeUpdate(&sb)
+	case _queryTypeDelete:
+		qb.writeDelete(&sb)
+	default:
+		qb.writeSelect(&sb)
+	}
+
+	return sb.String()
+}
+
+func (qb *QueryBuilder) writeInsert(sb *strings.Builder) {
+	placeholders := make([]string, 0, len(qb.columns))
+	for i := range qb.columns {
+		placeholders = append(placeholders, fmt.Sprintf("$%d", i+1))
+	}
+
+	sb.WriteString(_queryTypeInsert)
+	sb.WriteString(" ")
+	sb.WriteString(qb.targetTable)
+	sb.WriteString(" (")
+	sb.WriteString(strings.Join(qb.columns, ", "))
+	sb.WriteString(") VALUES (")
+	sb.WriteString(strings.Join(placeholders, ", "))
+	sb.WriteString(")")
+
+	if qb.onConflict != nil {
+		sb.WriteString(" ON CONFLICT ")
+		sb.WriteString(*qb.onConflict)
+	}
+
+	qb.writeReturning(sb)
+}
+
+func (qb *QueryBuilder) writeUpdate(sb *strings.Builder) {
+	assignments := make([]string, 0, len(qb.columns))
+	for i, column := range qb.columns {
+		assignments = append(assignments, fmt.Sprintf("%s = $%d", column, i+1))
+	}
+
+	sb.WriteString(_queryTypeUpdate)
+	sb.WriteString(" ")
+	sb.WriteString(qb.targetTable)
+	sb.WriteString(" SET ")
+	sb.WriteString(strings.Join(assignments, ", "))
+
+	qb.writeWhere(sb)
+	qb.writeReturning(sb)
+}
+
+func (qb *QueryBuilder) writeDelete(sb *strings.Builder) {
+	sb.WriteString(_queryTypeDelete)
+	sb.WriteString(" ")
+	sb.WriteString(qb.targetTable)
+
+	qb.writeWhere(sb)
+	qb.writeReturning(sb)
+}
+
+func (qb *QueryBuilder) writeWhere(sb *strings.Builder) {
+	if len(qb.conditions) > 0 {
+		sb.WriteString(" WHERE ")
+		sb.WriteString(strings.Join(qb.conditions, " AND "))
+	}
+}
+
+func (qb *QueryBuilder) writeReturning(sb *strings.Builder) {
+	if len(qb.returning) > 0 {
+		sb.WriteString(" RETURNING ")
+		sb.WriteString(strings.Join(qb.returning, ", "))
+	}
+}
+
+func (qb *QueryBuilder) writeSelect(sb *strings.Builder) {
+	sb.WriteString(_queryTypeSelect)
+	sb.WriteString(" ")
 	sb.WriteString(strings.Join(qb.columns, ", "))
 	sb.WriteString(" FROM ")
 	sb.WriteString(qb.targetTable)
@@ -86,14 +232,11 @@ func (qb *QueryBuilder) String() string {
 		}
 	}
 
-	if len(qb.conditions) > 0 {
-		sb.WriteString(" WHERE ")
-		sb.WriteString(strings.Join(qb.conditions, " AND "))
-	}
+	qb.writeWhere(sb)
 
-	if qb.orderBy != nil {
+	if len(qb.orderBy) > 0 {
 		sb.WriteString(" ORDER BY ")
-		sb.WriteString(*qb.orderBy)
+		sb.WriteString(strings.Join(qb.orderBy, ", "))
 	}
 
 	if qb.limit != nil {
@@ -105,6 +248,4 @@ func (qb *QueryBuilder) String() string {
 		sb.WriteString(" OFFSET ")
 		sb.WriteString(strconv.Itoa(*qb.offset))
 	}
-
-	return sb.String()
 }
@@ -93,6 +93,17 @@ func TestQueryBuilder(t *testing.T) {
 			},
 			expectedQuery: "SELECT * FROM users ORDER BY name ASC",
 		},
+		{
+			name: "OrderBy multiple terms",
+			queryBuilderFunc: func(qb *storage.QueryBuilder) *storage.QueryBuilder {
+				return qb.Select().
+					Columns("*").
+					From("users").
+					OrderBy("last_name DESC", "first_name").
+					OrderBy("id")
+			},
+			expectedQuery: "SELECT * FROM users ORDER BY last_name DESC, first_name, id",
+		},
 		{
 			name: "Complex",
 			queryBuilderFunc: func(qb *storage.QueryBuilder) *storage.QueryBuilder {
@@ -108,6 +119,76 @@ func TestQueryBuilder(t *testing.T) {
 			},
 			expectedQuery: "SELECT users.name, orders.order_id, payments.amount FROM users JOIN orders ON users.id = orders.user_id JOIN payments ON users.id = payments.user_id WHERE users.age > 18 ORDER BY users.name ASC LIMIT 10 OFFSET 20",
 		},
+		{
+			name: "Insert",
+			queryBuilderFunc: func(qb *storage.QueryBuilder) *storage.QueryBuilder {
+				return qb.Insert("users").
+					Columns("first_name", "last_name")
+			},
+			expectedQuery: "INSERT INTO users (first_name, last_name) VALUES ($1, $2)",
+		},
+		{
+			name: "Insert returning",
+			queryBuilderFunc: func(qb *storage.QueryBuilder) *storage.QueryBuilder {
+				return qb.Insert("users").
+					Columns("first_name", "last_name").
+					Returning("id")
+			},
+			expectedQuery: "INSERT INTO users (first_name, last_name) VALUES ($1, $2) RETURNING id",
+		},
+		{
+			name: "Update",
+			queryBuilderFunc: func(qb *storage.QueryBuilder) *storage.QueryBuilder {
+				return qb.Update("users").
+					Columns("first_name", "last_name").
+					Where("id = $3").
+					Returning("id")
+			},
+			expectedQuery: "UPDATE users SET first_name = $1, last_name = $2 WHERE id = $3 RETURNING id",
+		},
+		{
+			name: "Delete",
+			queryBuilderFunc: func(qb *storage.QueryBuilder) *storage.QueryBuilder {
+				return qb.Delete("users").
+					Where("id = $1")
+			},
+			expectedQuery: "DELETE FROM users WHERE id = $1",
+		},
+		{
+			name: "Args",
+			queryBuilderFunc: func(qb *storage.QueryBuilder) *storage.QueryBuilder {
+				qb.Select().
+					Columns("*").
+					From("users")
+
+				return qb.Where("age > " + qb.Arg(18)).
+					Where("country = " + qb.Arg("USA"))
+			},
+			expectedQuery: "SELECT * FROM users WHERE age > $1 AND country = $2",
+		},
+		{
+			name: "Insert on conflict",
+			queryBuilderFunc: func(qb *storage.QueryBuilder) *storage.QueryBuilder {
+				return qb.Insert("users").
+					Columns("id", "name").
+					OnConflict("(id) DO UPDATE SET name = EXCLUDED.name")
+			},
+			expectedQuery: "INSERT INTO users (id, name) VALUES ($1, $2) ON CONFLICT (id) DO UPDATE SET name = EXCLUDED.name",
+		},
+		{
+			name: "Subquery",
+			queryBuilderFunc: func(qb *storage.QueryBuilder) *storage.QueryBuilder {
+				subquery := storage.NewQueryBuilder().Select().Columns("*").From("users")
+				subquery.Where("age > " + subquery.Arg(18))
+
+				qb.Select().
+					Columns("name").
+					FromSubquery(subquery, "adults")
+
+				return qb.Where("country = " + qb.Arg("USA"))
+			},
+			expectedQuery: "SELECT name FROM (SELECT * FROM users WHERE age > $1) adults WHERE country = $2",
+		},
 	}
 
 	for _, test := range tests {
@@ -1,5 +1,7 @@
 package storage
 
+import "time"
+
 // Pagination specifies a pagination for the request.
 type Pagination struct {
 	Limit  *int
@@ -15,11 +17,73 @@ type Location struct {
 // RentalFilters is a filters type to be used for listing rentals.
 type RentalFilters struct {
 	Pagination
-	IDs      []int32
+
+	// After lists only the rentals following the rental with the key, paging without an offset.
+	After *RentalKey
+
+	IDs     []int32
+	UserIDs []int32
+
+	// WishlistID limits the rentals to the ones saved in the wishlist.
+	WishlistID *int32
+	// Amenities lists the slugs of amenities a rental must have all of.
+	Amenities []string
+
+	// Search matches rentals by their name, description, make and model.
+	// Results are ranked by relevance unless OrderBy is set.
+	Search *string
+	// Types lists the accepted rental types.
+	Types []string
+	// Make and Model match regardless of case.
+	Make  *string
+	Model *string
+
+	// The year, length and sleeps bounds are inclusive.
+	YearMin   *int32
+	YearMax   *int32
+	LengthMin *float32
+	LengthMax *float32
+	SleepsMin *int32
+
 	PriceMin *int64
 	PriceMax *int64
-	Near     *Location
-	OrderBy  *string
+
+	// RatingMin excludes rentals without reviews.
+	RatingMin *float64
+
+	// Near lists the rentals near the location along with their distance.
+	Near *Location
+	// Radius is the distance in miles from Near, the configured radius when nil.
+	Radius *float64
+
+	// AvailableFrom and AvailableTo list only rentals which can be booked for the nights between them.
+	AvailableFrom *time.Time
+	AvailableTo   *time.Time
+	// EffectivePrice applies PriceMin and PriceMax to the average price per night of the stay
+	// between AvailableFrom and AvailableTo according to the pricing rules of each rental.
+	EffectivePrice bool
+
+	// OrderBy orders the results, by id last so that pages are stable.
+	// Results without OrderBy or Search are ordered by id.
+	OrderBy []SortTerm
+
+	// IncludeDeleted lists archived rentals as well.
+	IncludeDeleted bool
+}
+
+// RentalKey is the position of a rental in rentals ordered by the terms of RentalFilters.OrderBy:
+// the values of the sort fields of the rental, in the order of the terms, and its id.
+// Rentals without rating have a nil rating value, and lengths are decimal strings compared exactly
+// with the numeric column.
+type RentalKey struct {
+	Values []any
+	ID     int32
+}
+
+// SortTerm is a field to sort rentals by and its direction.
+type SortTerm struct {
+	Field      string
+	Descending bool
 }
 
 // RentalSortFields defines allowed fields for sorting.
@@ -33,6 +97,27 @@ var RentalSortFields = []string{
 	"length",
 	"sleeps",
 	"price_per_day",
+	"rating",
+	"distance",
+}
+
+// nullableSortFields lists the sort fields rentals may have no value of.
+var nullableSortFields = map[string]bool{
+	"rating": true,
+}
+
+// numericSortFields lists the sort fields of numeric columns, whose key values are decimal strings.
+var numericSortFields = map[string]bool{
+	"length": true,
+}
+
+// rentalSortColumns maps the sort fields which differ from the name of their column.
+var rentalSortColumns = map[string]string{
+	"make":   "vehicle_make",
+	"model":  "vehicle_model",
+	"year":   "vehicle_year",
+	"length": "vehicle_length",
+	"rating": "rating_average",
 }
 
 // SortFieldAllowed checks whether the given field in allowed to sort rentals by.
@@ -0,0 +1,288 @@
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	model "github.com/dragonator/rental-service/module/rental/internal/model"
+)
+
+// refreshPrimaryImageQuery sets the primary image url of a rental to the url of its first image.
+const refreshPrimaryImageQuery = "UPDATE rentals SET primary_image_url = COALESCE((SELECT rental_images.url " +
+	"FROM rental_images WHERE rental_images.rental_id = rentals.id " +
+	"ORDER BY rental_images.position, rental_images.id LIMIT 1), '') " +
+	"WHERE rentals.id = $1"
+
+var (
+	rentalImageColumns = []string{
+		"rental_images.id",
+		"rental_images.rental_id",
+		"rental_images.url",
+		"rental_images.caption",
+		"rental_images.position",
+		"rental_images.storage_key",
+		"rental_images.thumbnails",
+		"rental_images.created",
+	}
+	rentalImageWriteColumns = []string{
+		"rental_id",
+		"url",
+		"caption",
+		"position",
+		"storage_key",
+		"thumbnails",
+		"created",
+	}
+)
+
+// RentalImageRepository hold DB operations over rental image entities.
+type RentalImageRepository struct {
+	db *sql.DB
+}
+
+// NewRentalImageRepository is a constructor function for RentalImageRepository.
+func NewRentalImageRepository(db *sql.DB) *RentalImageRepository {
+	return &RentalImageRepository{
+		db: db,
+	}
+}
+
+// GetByID returns a single image object corresponding to the requested id.
+// If no such image exists it returns an error.
+func (rr *RentalImageRepository) GetByID(ctx context.Context, imageID int) (*model.RentalImage, error) {
+	qb := NewQueryBuilder().
+		Select().
+		Columns(rentalImageColumns...).
+		From("rental_images").
+		Where("rental_images.id = $1")
+
+	image, err := scanRentalImage(rr.db.QueryRowContext(ctx, qb.String(), imageID))
+	if err != nil {
+		return nil, fmt.Errorf("getting rental image by id: %w", err)
+	}
+
+	return image, nil
+}
+
+// ListByRental returns the images of the rental with the given id in their order.
+func (rr *RentalImageRepository) ListByRental(ctx context.Context, rentalID int) (model.RentalImages, error) {
+	images, err := listRentalImages(ctx, rr.db, []int32{int32(rentalID)})
+	if err != nil {
+		return nil, err
+	}
+
+	return images, nil
+}
+
+// Create appends a new image to the images of its rental and returns the id assigned to it.
+func (rr *RentalImageRepository) Create(ctx context.Context, image *model.RentalImage) (_ int, err error) {
+	tx, err := rr.db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, fmt.Errorf("creating rental image: %w", err)
+	}
+
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback()
+		}
+	}()
+
+	nextPosition := NewQueryBuilder().
+		Select().
+		Columns("COALESCE(MAX(rental_images.position) + 1, 0)").
+		From("rental_images").
+		Where("rental_images.rental_id = $1")
+
+	var position int
+	if err = tx.QueryRowContext(ctx, nextPosition.String(), image.RentalID).Scan(&position); err != nil {
+		return 0, fmt.Errorf("getting next image position: %w", err)
+	}
+
+	insert := NewQueryBuilder().
+		Insert("rental_images").
+		Columns(rentalImageWriteColumns...).
+		Returning("id")
+
+	args, err := rentalImageWriteValues(image, position, time.Now().UTC())
+	if err != nil {
+		return 0, fmt.Errorf("creating rental image: %w", err)
+	}
+
+	var imageID int
+	if err = tx.QueryRowContext(ctx, insert.String(), args...).Scan(&imageID); err != nil {
+		return 0, fmt.Errorf("creating rental image: %w", err)
+	}
+
+	if _, err = tx.ExecContext(ctx, refreshPrimaryImageQuery, image.RentalID); err != nil {
+		return 0, fmt.Errorf("updating primary image: %w", err)
+	}
+
+	if err = tx.Commit(); err != nil {
+		return 0, fmt.Errorf("creating rental image: %w", err)
+	}
+
+	return imageID, nil
+}
+
+// Reorder moves the images of the rental into the order of the given ids.
+func (rr *RentalImageRepository) Reorder(ctx context.Context, rentalID int, imageIDs []int32) (err error) {
+	tx, err := rr.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("reordering rental images: %w", err)
+	}
+
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback()
+		}
+	}()
+
+	update := NewQueryBuilder().
+		Update("rental_images").
+		Columns("position").
+		Where("id = $2").
+		Where("rental_id = $3")
+
+	for position, imageID := range imageIDs {
+		if _, err = tx.ExecContext(ctx, update.String(), position, imageID, rentalID); err != nil {
+			return fmt.Errorf("moving rental image: %w", err)
+		}
+	}
+
+	if _, err = tx.ExecContext(ctx, refreshPrimaryImageQuery, rentalID); err != nil {
+		return fmt.Errorf("updating primary image: %w", err)
+	}
+
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("reordering rental images: %w", err)
+	}
+
+	return nil
+}
+
+// Delete removes the image with the given id from the images of the rental.
+// If no image matches it returns sql.ErrNoRows.
+func (rr *RentalImageRepository) Delete(ctx context.Context, rentalID, imageID int) (err error) {
+	tx, err := rr.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("deleting rental image: %w", err)
+	}
+
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback()
+		}
+	}()
+
+	qb := NewQueryBuilder().
+		Delete("rental_images").
+		Where("id = $1").
+		Where("rental_id = $2")
+
+	result, err := tx.ExecContext(ctx, qb.String(), imageID, rentalID)
+	if err != nil {
+		return fmt.Errorf("deleting rental image: %w", err)
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("deleting rental image: %w", err)
+	}
+
+	if affected == 0 {
+		err = sql.ErrNoRows
+		return fmt.Errorf("deleting rental image: %w", err)
+	}
+
+	if _, err = tx.ExecContext(ctx, refreshPrimaryImageQuery, rentalID); err != nil {
+		return fmt.Errorf("updating primary image: %w", err)
+	}
+
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("deleting rental image: %w", err)
+	}
+
+	return nil
+}
+
+// listRentalImages returns the images of the rentals with the given ids ordered by rental and position.
+func listRentalImages(ctx context.Context, db *sql.DB, rentalIDs []int32) (model.RentalImages, error) {
+	qb := NewQueryBuilder().
+		Select().
+		Columns(rentalImageColumns...).
+		From("rental_images").
+		Where(fmt.Sprintf("rental_images.rental_id IN (%s)", joinIDs(rentalIDs))).
+		OrderBy("rental_images.rental_id, rental_images.position, rental_images.id")
+
+	rows, err := db.QueryContext(ctx, qb.String())
+	if err != nil {
+		return nil, fmt.Errorf("listing rental images: %w", err)
+	}
+
+	defer rows.Close()
+
+	images := make(model.RentalImages, 0, len(rentalIDs))
+
+	for rows.Next() {
+		image, err := scanRentalImage(rows)
+		if err != nil {
+			return nil, fmt.Errorf("scanning rental image: %w", err)
+		}
+
+		images = append(images, image)
+	}
+
+	return images, nil
+}
+
+func rentalImageWriteValues(image *model.RentalImage, position int, created time.Time) ([]any, error) {
+	var thumbnails any
+	if len(image.Thumbnails) > 0 {
+		encoded, err := json.Marshal(image.Thumbnails)
+		if err != nil {
+			return nil, fmt.Errorf("encoding thumbnails: %w", err)
+		}
+
+		thumbnails = encoded
+	}
+
+	return []any{
+		image.RentalID,
+		image.URL,
+		image.Caption,
+		position,
+		image.StorageKey,
+		thumbnails,
+		created,
+	}, nil
+}
+
+func scanRentalImage(row rowScanner) (*model.RentalImage, error) {
+	image := new(model.RentalImage)
+
+	var thumbnails []byte
+
+	if err := row.Scan(
+		&image.ID,
+		&image.RentalID,
+		&image.URL,
+		&image.Caption,
+		&image.Position,
+		&image.StorageKey,
+		&thumbnails,
+		&image.Created,
+	); err != nil {
+		return nil, err
+	}
+
+	if thumbnails != nil {
+		if err := json.Unmarshal(thumbnails, &image.Thumbnails); err != nil {
+			return nil, fmt.Errorf("decoding thumbnails: %w", err)
+		}
+	}
+
+	return image, nil
+}
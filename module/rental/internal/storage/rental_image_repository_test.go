@@ -0,0 +1,91 @@
+package storage_test
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+
+	"github.com/dragonator/rental-service/module/rental/internal/storage"
+)
+
+func TestRentalImageRepository_Reorder(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to create sqlmock: %s", err)
+	}
+	defer db.Close()
+
+	updateQuery := "UPDATE rental_images SET position = \\$1 WHERE id = \\$2 AND rental_id = \\$3"
+
+	mock.ExpectBegin()
+	mock.ExpectExec(updateQuery).WithArgs(0, 3, 1).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(updateQuery).WithArgs(1, 2, 1).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec("UPDATE rentals SET primary_image_url = .* WHERE rentals.id = \\$1").
+		WithArgs(1).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	if err := storage.NewRentalImageRepository(db).Reorder(context.Background(), 1, []int32{3, 2}); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatalf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestRentalImageRepository_Delete(t *testing.T) {
+	deleteQuery := "DELETE FROM rental_images WHERE id = \\$1 AND rental_id = \\$2"
+
+	testCases := []struct {
+		name          string
+		expectedError error
+		mockFunc      func(mock sqlmock.Sqlmock)
+	}{
+		{
+			name: "Image of the rental",
+			mockFunc: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectExec(deleteQuery).WithArgs(2, 1).WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec("UPDATE rentals SET primary_image_url = .* WHERE rentals.id = \\$1").
+					WithArgs(1).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
+			},
+		},
+		{
+			name:          "Missing image",
+			expectedError: sql.ErrNoRows,
+			mockFunc: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectExec(deleteQuery).WithArgs(2, 1).WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectRollback()
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("failed to create sqlmock: %s", err)
+			}
+			defer db.Close()
+
+			tc.mockFunc(mock)
+
+			err = storage.NewRentalImageRepository(db).Delete(context.Background(), 1, 2)
+
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Fatalf("there were unfulfilled expectations: %s", err)
+			}
+
+			if !errors.Is(err, tc.expectedError) {
+				t.Fatalf("error expectation mismatch: expected %v, got %v", tc.expectedError, err)
+			}
+		})
+	}
+}
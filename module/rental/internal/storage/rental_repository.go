@@ -3,14 +3,38 @@ package storage
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"fmt"
 	"strconv"
 	"strings"
+	"sync"
+	"time"
 
 	model "github.com/dragonator/rental-service/module/rental/internal/model"
 	"github.com/dragonator/rental-service/pkg/config"
 )
 
+// earthRadiusMiles is the mean radius of the Earth in miles.
+const earthRadiusMiles = 3958.8
+
+// metersPerMile is the number of meters in a mile, the unit PostGIS measures geography distances in.
+const metersPerMile = 1609.344
+
+// hasLocationQuery checks whether rentals have the PostGIS location column.
+const hasLocationQuery = "SELECT EXISTS (SELECT 1 FROM information_schema.columns " +
+	"WHERE table_name = 'rentals' AND column_name = 'location')"
+
+// shiftRentalImagesQuery moves every image of the rental $1 one position back.
+const shiftRentalImagesQuery = "UPDATE rental_images SET position = position + 1 WHERE rental_id = $1"
+
+// promoteRentalImageQuery moves the image of the rental $1 with the url $2 to the first position.
+const promoteRentalImageQuery = "UPDATE rental_images SET position = 0 WHERE id = (SELECT rental_images.id " +
+	"FROM rental_images WHERE rental_images.rental_id = $1 AND rental_images.url = $2 " +
+	"ORDER BY rental_images.position, rental_images.id LIMIT 1)"
+
+// searchConfig is the text search configuration the search vector of rentals is built with.
+const searchConfig = "english"
+
 var (
 	rentalColums = []string{
 		"rentals.id",
@@ -31,6 +55,31 @@ var (
 		"rentals.lat",
 		"rentals.lng",
 		"rentals.primary_image_url",
+		"rentals.cancellation_policy",
+		"rentals.rating_average",
+		"rentals.rating_count",
+		"rentals.created",
+		"rentals.updated",
+	}
+	rentalWriteColumns = []string{
+		"user_id",
+		"name",
+		"type",
+		"description",
+		"sleeps",
+		"price_per_day",
+		"home_city",
+		"home_state",
+		"home_zip",
+		"home_country",
+		"vehicle_make",
+		"vehicle_model",
+		"vehicle_year",
+		"vehicle_length",
+		"lat",
+		"lng",
+		"primary_image_url",
+		"cancellation_policy",
 	}
 	userColumns = []string{
 		"users.id as users_id",
@@ -43,10 +92,17 @@ type rowScanner interface {
 	Scan(dest ...any) error
 }
 
+type execer interface {
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+}
+
 // RentalRepository hold DB operations over rental entities.
 type RentalRepository struct {
 	db                  *sql.DB
 	nearThresholdRadius int
+
+	postgisMu sync.Mutex
+	postgis   *bool
 }
 
 // NewRentalRepository is a constructor function for RentalRepository.
@@ -66,42 +122,445 @@ func (rr *RentalRepository) GetByID(ctx context.Context, rentalID int) (*model.R
 		Columns(userColumns...).
 		From("rentals").
 		Join("users ON users.id = rentals.user_id").
-		Where("rentals.id = $1")
+		Where("rentals.id = $1").
+		Where("rentals.deleted_at IS NULL")
 
 	rental, err := scanRental(rr.db.QueryRowContext(ctx, qb.String(), rentalID))
 	if err != nil {
 		return nil, fmt.Errorf("getting rental by id: %w", err)
 	}
 
+	if err := rr.attachImages(ctx, model.Rentals{rental}); err != nil {
+		return nil, err
+	}
+
+	if err := rr.attachAmenities(ctx, model.Rentals{rental}); err != nil {
+		return nil, err
+	}
+
 	return rental, nil
 }
 
+// Create inserts a new rental and returns the id assigned to it.
+// A primary image url of the rental becomes its first image.
+func (rr *RentalRepository) Create(ctx context.Context, rental *model.Rental) (_ int, err error) {
+	tx, err := rr.db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, fmt.Errorf("creating rental: %w", err)
+	}
+
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback()
+		}
+	}()
+
+	qb := NewQueryBuilder().
+		Insert("rentals").
+		Columns(rentalWriteColumns...).
+		Columns("created", "updated").
+		Returning("id")
+
+	now := time.Now().UTC()
+	args := append(rentalWriteValues(rental), now, now)
+
+	var rentalID int
+	if err = tx.QueryRowContext(ctx, qb.String(), args...).Scan(&rentalID); err != nil {
+		return 0, fmt.Errorf("creating rental: %w", err)
+	}
+
+	if rental.PrimaryImageURL != "" {
+		insertImage := NewQueryBuilder().
+			Insert("rental_images").
+			Columns(rentalImageWriteColumns...)
+
+		primaryImage := &model.RentalImage{
+			RentalID: int32(rentalID),
+			URL:      rental.PrimaryImageURL,
+		}
+
+		imageArgs, err := rentalImageWriteValues(primaryImage, 0, now)
+		if err != nil {
+			return 0, fmt.Errorf("creating primary image: %w", err)
+		}
+
+		if _, err = tx.ExecContext(ctx, insertImage.String(), imageArgs...); err != nil {
+			return 0, fmt.Errorf("creating primary image: %w", err)
+		}
+	}
+
+	if err = tx.Commit(); err != nil {
+		return 0, fmt.Errorf("creating rental: %w", err)
+	}
+
+	return rentalID, nil
+}
+
+// Update overwrites the stored rental with the given one. Archived rentals are not updated.
+// When version is given the rental is updated only if its last update time still equals
+// version. If no rental matches it returns sql.ErrNoRows.
+// A primary image url of a rental with images becomes its first image.
+func (rr *RentalRepository) Update(ctx context.Context, rental *model.Rental, version *time.Time) (err error) {
+	tx, err := rr.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("updating rental: %w", err)
+	}
+
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback()
+		}
+	}()
+
+	qb := NewQueryBuilder().
+		Update("rentals").
+		Columns(rentalWriteColumns...).
+		Columns("updated")
+
+	args := append(rentalWriteValues(rental), time.Now().UTC(), rental.ID)
+	qb.Where(fmt.Sprintf("id = $%d", len(args))).
+		Where("deleted_at IS NULL")
+
+	if version != nil {
+		args = append(args, *version)
+		qb.Where(fmt.Sprintf("updated = $%d", len(args)))
+	}
+
+	if err = execAffectingRow(ctx, tx, qb.String(), args...); err != nil {
+		return fmt.Errorf("updating rental: %w", err)
+	}
+
+	if rental.PrimaryImageURL != "" {
+		if err = promotePrimaryImage(ctx, tx, rental.ID, rental.PrimaryImageURL); err != nil {
+			return fmt.Errorf("updating primary image: %w", err)
+		}
+	}
+
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("updating rental: %w", err)
+	}
+
+	return nil
+}
+
+// promotePrimaryImage makes the image of the given url the first image of the rental, adding the
+// image if the rental has none of that url. Rentals without images are left as they are.
+func promotePrimaryImage(ctx context.Context, tx *sql.Tx, rentalID int32, url string) error {
+	first := NewQueryBuilder().
+		Select().
+		Columns("rental_images.url").
+		From("rental_images").
+		Where("rental_images.rental_id = $1").
+		OrderBy("rental_images.position", "rental_images.id").
+		Limit(1)
+
+	var firstURL string
+
+	err := tx.QueryRowContext(ctx, first.String(), rentalID).Scan(&firstURL)
+	if errors.Is(err, sql.ErrNoRows) || (err == nil && firstURL == url) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	if _, err := tx.ExecContext(ctx, shiftRentalImagesQuery, rentalID); err != nil {
+		return err
+	}
+
+	result, err := tx.ExecContext(ctx, promoteRentalImageQuery, rentalID, url)
+	if err != nil {
+		return err
+	}
+
+	promoted, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if promoted > 0 {
+		return nil
+	}
+
+	insert := NewQueryBuilder().
+		Insert("rental_images").
+		Columns(rentalImageWriteColumns...)
+
+	args, err := rentalImageWriteValues(&model.RentalImage{RentalID: rentalID, URL: url}, 0, time.Now().UTC())
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.ExecContext(ctx, insert.String(), args...)
+
+	return err
+}
+
+// Archive marks an active rental as deleted, hiding it from GetByID and List.
+// If no active rental matches it returns sql.ErrNoRows.
+func (rr *RentalRepository) Archive(ctx context.Context, rentalID int) error {
+	qb := NewQueryBuilder().
+		Update("rentals").
+		Columns("deleted_at", "updated").
+		Where("id = $3").
+		Where("deleted_at IS NULL")
+
+	now := time.Now().UTC()
+
+	if err := execAffectingRow(ctx, rr.db, qb.String(), now, now, rentalID); err != nil {
+		return fmt.Errorf("archiving rental: %w", err)
+	}
+
+	return nil
+}
+
+// Restore makes an archived rental active again.
+// If no archived rental matches it returns sql.ErrNoRows.
+func (rr *RentalRepository) Restore(ctx context.Context, rentalID int) error {
+	qb := NewQueryBuilder().
+		Update("rentals").
+		Columns("deleted_at", "updated").
+		Where("id = $3").
+		Where("deleted_at IS NOT NULL")
+
+	if err := execAffectingRow(ctx, rr.db, qb.String(), nil, time.Now().UTC(), rentalID); err != nil {
+		return fmt.Errorf("restoring rental: %w", err)
+	}
+
+	return nil
+}
+
+// execAffectingRow executes the query and returns sql.ErrNoRows if it did not affect any rows.
+func execAffectingRow(ctx context.Context, db execer, query string, args ...any) error {
+	result, err := db.ExecContext(ctx, query, args...)
+	if err != nil {
+		return err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if affected == 0 {
+		return sql.ErrNoRows
+	}
+
+	return nil
+}
+
 // List returns a list of rentals based on the given filters. If no results are found it returns an empty list.
 func (rr *RentalRepository) List(ctx context.Context, filters *RentalFilters) (model.Rentals, error) {
 	rentals := make(model.Rentals, 0, 10)
 
-	sqlQuery := rr.buildListQuery(filters)
+	postgis, err := rr.usePostGIS(ctx, filters)
+	if err != nil {
+		return nil, fmt.Errorf("listing rentals: %w", err)
+	}
+
+	qb := rr.buildListQuery(filters, postgis)
 
-	rows, err := rr.db.QueryContext(ctx, sqlQuery)
+	rows, err := rr.db.QueryContext(ctx, qb.String(), qb.Args()...)
 	if err != nil {
 		return nil, fmt.Errorf("listing rentals: %w", err)
 	}
 
 	defer rows.Close()
 
+	near := filters != nil && filters.Near != nil
+
 	for rows.Next() {
-		rental, err := scanRental(rows)
+		var distance float64
+
+		dest := make([]any, 0, 1)
+		if near {
+			dest = append(dest, &distance)
+		}
+
+		rental, err := scanRental(rows, dest...)
 		if err != nil {
 			return nil, fmt.Errorf("scanning rental: %w", err)
 		}
 
+		if near {
+			rental.Distance = &distance
+		}
+
 		rentals = append(rentals, rental)
 	}
 
+	if err := rr.attachImages(ctx, rentals); err != nil {
+		return nil, err
+	}
+
+	if err := rr.attachAmenities(ctx, rentals); err != nil {
+		return nil, err
+	}
+
 	return rentals, nil
 }
 
-func (rr *RentalRepository) buildListQuery(f *RentalFilters) string {
+// Count returns the number of rentals matching the given filters, regardless of their pagination.
+func (rr *RentalRepository) Count(ctx context.Context, filters *RentalFilters) (int, error) {
+	postgis, err := rr.usePostGIS(ctx, filters)
+	if err != nil {
+		return 0, fmt.Errorf("counting rentals: %w", err)
+	}
+
+	filtered, _ := rr.buildFilteredQuery(filters, postgis)
+
+	qb := NewQueryBuilder().
+		Select().
+		Columns("COUNT(*)").
+		FromSubquery(filtered, "filtered")
+
+	var count int
+	if err := rr.db.QueryRowContext(ctx, qb.String(), qb.Args()...).Scan(&count); err != nil {
+		return 0, fmt.Errorf("counting rentals: %w", err)
+	}
+
+	return count, nil
+}
+
+// usePostGIS reports whether rentals near a location are looked up with PostGIS. Whether rentals
+// have the location column is checked once; without it the haversine formula is used instead.
+func (rr *RentalRepository) usePostGIS(ctx context.Context, filters *RentalFilters) (bool, error) {
+	if filters == nil || filters.Near == nil {
+		return false, nil
+	}
+
+	rr.postgisMu.Lock()
+	defer rr.postgisMu.Unlock()
+
+	if rr.postgis == nil {
+		var exists bool
+		if err := rr.db.QueryRowContext(ctx, hasLocationQuery).Scan(&exists); err != nil {
+			return false, fmt.Errorf("checking for postgis: %w", err)
+		}
+
+		rr.postgis = &exists
+	}
+
+	return *rr.postgis, nil
+}
+
+// attachImages loads the images of the given rentals with a single query.
+func (rr *RentalRepository) attachImages(ctx context.Context, rentals model.Rentals) error {
+	if len(rentals) == 0 {
+		return nil
+	}
+
+	ids := make([]int32, 0, len(rentals))
+	byID := make(map[int32]*model.Rental, len(rentals))
+
+	for _, rental := range rentals {
+		ids = append(ids, rental.ID)
+		byID[rental.ID] = rental
+		rental.Images = model.RentalImages{}
+	}
+
+	images, err := listRentalImages(ctx, rr.db, ids)
+	if err != nil {
+		return err
+	}
+
+	for _, image := range images {
+		if rental, ok := byID[image.RentalID]; ok {
+			rental.Images = append(rental.Images, image)
+		}
+	}
+
+	return nil
+}
+
+// attachAmenities loads the amenity slugs of the given rentals with a single query.
+func (rr *RentalRepository) attachAmenities(ctx context.Context, rentals model.Rentals) error {
+	if len(rentals) == 0 {
+		return nil
+	}
+
+	ids := make([]int32, 0, len(rentals))
+	byID := make(map[int32]*model.Rental, len(rentals))
+
+	for _, rental := range rentals {
+		ids = append(ids, rental.ID)
+		byID[rental.ID] = rental
+		rental.Amenities = []string{}
+	}
+
+	qb := NewQueryBuilder().
+		Select().
+		Columns("rental_amenities.rental_id", "amenities.slug").
+		From("rental_amenities").
+		Join("amenities ON amenities.id = rental_amenities.amenity_id").
+		Where(fmt.Sprintf("rental_amenities.rental_id IN (%s)", joinIDs(ids))).
+		OrderBy("rental_amenities.rental_id, amenities.slug")
+
+	rows, err := rr.db.QueryContext(ctx, qb.String())
+	if err != nil {
+		return fmt.Errorf("listing rental amenities: %w", err)
+	}
+
+	defer rows.Close()
+
+	for rows.Next() {
+		var (
+			rentalID int32
+			slug     string
+		)
+
+		if err := rows.Scan(&rentalID, &slug); err != nil {
+			return fmt.Errorf("scanning rental amenity: %w", err)
+		}
+
+		if rental, ok := byID[rentalID]; ok {
+			rental.Amenities = append(rental.Amenities, slug)
+		}
+	}
+
+	return nil
+}
+
+func (rr *RentalRepository) buildListQuery(f *RentalFilters, postgis bool) *QueryBuilder {
+	qb, rank := rr.buildFilteredQuery(f, postgis)
+
+	if f == nil {
+		return qb
+	}
+
+	if f.After != nil {
+		table := "rentals"
+		if f.Near != nil {
+			table = "subquery"
+		}
+
+		qb.Where(keysetCondition(qb, table, f.OrderBy, f.After))
+	}
+
+	switch {
+	case len(f.OrderBy) > 0:
+		qb.OrderBy(orderByTerms(f.OrderBy)...)
+	case rank != "":
+		qb.OrderBy(rank+" DESC", "id")
+	default:
+		qb.OrderBy("id")
+	}
+
+	if f.Limit != nil {
+		qb.Limit(*f.Limit)
+	}
+
+	if f.Offset != nil {
+		qb.Offset(*f.Offset)
+	}
+
+	return qb
+}
+
+// buildFilteredQuery returns a query selecting the rentals matching the filters, regardless of
+// their order and pagination, and the expression ranking them by relevance to the search, if any.
+// Rentals near a location are looked up with PostGIS if postgis is set.
+func (rr *RentalRepository) buildFilteredQuery(f *RentalFilters, postgis bool) (*QueryBuilder, string) {
 	qb := NewQueryBuilder().
 		Select().
 		Columns(rentalColums...).
@@ -109,34 +568,141 @@ func (rr *RentalRepository) buildListQuery(f *RentalFilters) string {
 		From("rentals").
 		Join("users ON users.id = rentals.user_id")
 
+	if f == nil || !f.IncludeDeleted {
+		qb.Where("rentals.deleted_at IS NULL")
+	}
+
 	if f == nil {
-		return qb.String()
+		return qb, ""
 	}
 
 	if len(f.IDs) > 0 {
-		ids := make([]string, 0, len(f.IDs))
-		for _, id := range f.IDs {
-			ids = append(ids, strconv.Itoa(int(id)))
+		qb.Where(fmt.Sprintf("rentals.id IN (%s)", joinIDs(f.IDs)))
+	}
+
+	if len(f.UserIDs) > 0 {
+		qb.Where(fmt.Sprintf("rentals.user_id IN (%s)", joinIDs(f.UserIDs)))
+	}
+
+	if f.WishlistID != nil {
+		qb.Where(fmt.Sprintf("rentals.id IN (SELECT wishlist_rentals.rental_id FROM wishlist_rentals "+
+			"WHERE wishlist_rentals.wishlist_id = %s)", qb.Arg(*f.WishlistID)))
+	}
+
+	if len(f.Amenities) > 0 {
+		slugs := make([]string, 0, len(f.Amenities))
+		seen := make(map[string]bool, len(f.Amenities))
+
+		for _, slug := range f.Amenities {
+			if !seen[slug] {
+				seen[slug] = true
+				slugs = append(slugs, qb.Arg(slug))
+			}
+		}
+
+		qb.Where(fmt.Sprintf("rentals.id IN (SELECT rental_amenities.rental_id FROM rental_amenities "+
+			"JOIN amenities ON amenities.id = rental_amenities.amenity_id WHERE amenities.slug IN (%s) "+
+			"GROUP BY rental_amenities.rental_id HAVING COUNT(*) = %d)", strings.Join(slugs, ", "), len(slugs)))
+	}
+
+	if len(f.Types) > 0 {
+		types := make([]string, 0, len(f.Types))
+		for _, rentalType := range f.Types {
+			types = append(types, qb.Arg(rentalType))
+		}
+
+		qb.Where(fmt.Sprintf("rentals.type IN (%s)", strings.Join(types, ", ")))
+	}
+
+	if f.Make != nil {
+		qb.Where(fmt.Sprintf("LOWER(rentals.vehicle_make) = LOWER(%s)", qb.Arg(*f.Make)))
+	}
+
+	if f.Model != nil {
+		qb.Where(fmt.Sprintf("LOWER(rentals.vehicle_model) = LOWER(%s)", qb.Arg(*f.Model)))
+	}
+
+	if f.YearMin != nil {
+		qb.Where(fmt.Sprintf("rentals.vehicle_year >= %s", qb.Arg(*f.YearMin)))
+	}
+
+	if f.YearMax != nil {
+		qb.Where(fmt.Sprintf("rentals.vehicle_year <= %s", qb.Arg(*f.YearMax)))
+	}
+
+	if f.LengthMin != nil {
+		qb.Where(fmt.Sprintf("rentals.vehicle_length >= %s", qb.Arg(*f.LengthMin)))
+	}
+
+	if f.LengthMax != nil {
+		qb.Where(fmt.Sprintf("rentals.vehicle_length <= %s", qb.Arg(*f.LengthMax)))
+	}
+
+	if f.SleepsMin != nil {
+		qb.Where(fmt.Sprintf("rentals.sleeps >= %s", qb.Arg(*f.SleepsMin)))
+	}
+
+	var rank string
+
+	if f.Search != nil {
+		query := fmt.Sprintf("websearch_to_tsquery('%s', %s)", searchConfig, qb.Arg(*f.Search))
+		qb.Where(fmt.Sprintf("rentals.search_vector @@ %s", query))
+
+		if len(f.OrderBy) == 0 {
+			rank = fmt.Sprintf("ts_rank(rentals.search_vector, %s)", query)
 		}
+	}
+
+	priceColumn := "price_per_day"
+
+	if f.AvailableFrom != nil && f.AvailableTo != nil {
+		stayNights := int(f.AvailableTo.Sub(*f.AvailableFrom).Hours() / 24)
+		from, to, nights := qb.Arg(*f.AvailableFrom), qb.Arg(*f.AvailableTo), qb.Arg(stayNights)
 
-		qb.Where(fmt.Sprintf("rentals.id IN (%s)", strings.Join(ids, ", ")))
+		qb.Where(fmt.Sprintf("NOT EXISTS (SELECT 1 FROM bookings WHERE bookings.rental_id = rentals.id "+
+			"AND bookings.status = '%s' AND daterange(bookings.start_date, bookings.end_date) && daterange(%s::date, %s::date))",
+			model.BookingStatusConfirmed, from, to))
+		qb.Where(fmt.Sprintf("NOT EXISTS (SELECT 1 FROM rental_blocked_days WHERE rental_blocked_days.rental_id = rentals.id "+
+			"AND rental_blocked_days.day >= %s::date AND rental_blocked_days.day < %s::date)", from, to))
+		qb.Where(fmt.Sprintf("NOT EXISTS (SELECT 1 FROM rental_stay_rules WHERE rental_stay_rules.rental_id = rentals.id "+
+			"AND (rental_stay_rules.min_nights > %[1]s OR rental_stay_rules.max_nights < %[1]s))", nights))
+
+		if f.EffectivePrice {
+			priceColumn = effectivePricePerDay(from, to, nights, stayNights)
+		}
 	}
 
 	if f.PriceMin != nil {
-		qb.Where(fmt.Sprintf("price_per_day >= %d", *f.PriceMin))
+		qb.Where(fmt.Sprintf("%s >= %d", priceColumn, *f.PriceMin))
 	}
 
 	if f.PriceMax != nil {
-		qb.Where(fmt.Sprintf("price_per_day <= %d", *f.PriceMax))
+		qb.Where(fmt.Sprintf("%s <= %d", priceColumn, *f.PriceMax))
 	}
 
-	if f.Near != nil {
-		qb.Columns(
-			fmt.Sprintf("ABS(lat - %.2f) as a", f.Near.Latitude),
-			fmt.Sprintf("ABS(lng - %.2f) as b", f.Near.Longitude),
-		)
-		qb.Where(fmt.Sprintf("ABS(lat - %.2f) <= %d", f.Near.Latitude, rr.nearThresholdRadius))
-		qb.Where(fmt.Sprintf("ABS(lng - %.2f) <= %d", f.Near.Longitude, rr.nearThresholdRadius))
+	if f.RatingMin != nil {
+		qb.Where(fmt.Sprintf("rentals.rating_average >= %s", qb.Arg(*f.RatingMin)))
+	}
+
+	radius := float64(rr.nearThresholdRadius)
+	if f.Radius != nil {
+		radius = *f.Radius
+	}
+
+	switch {
+	case f.Near != nil && postgis:
+		point := fmt.Sprintf("ST_SetSRID(ST_MakePoint(%s, %s), 4326)::geography",
+			qb.Arg(f.Near.Longitude), qb.Arg(f.Near.Latitude))
+
+		qb.Columns(fmt.Sprintf("ST_Distance(rentals.location, %s) / %g AS distance", point, metersPerMile))
+		qb.Where(fmt.Sprintf("ST_DWithin(rentals.location, %s, %s)", point, qb.Arg(radius*metersPerMile)))
+	case f.Near != nil:
+		qb.Columns(haversineDistance(qb.Arg(f.Near.Latitude), qb.Arg(f.Near.Longitude)) + " AS distance")
+	}
+
+	if f.Near != nil && rank != "" {
+		qb.Columns(rank + " AS rank")
+		rank = "subquery.rank"
 	}
 
 	if f.Near != nil {
@@ -145,26 +711,168 @@ func (rr *RentalRepository) buildListQuery(f *RentalFilters) string {
 		qbTmp := NewQueryBuilder().
 			Select().
 			Columns(newRentalColumns...).
-			Columns("subquery.users_id", "subquery.first_name", "subquery.last_name").
-			From(fmt.Sprintf("(%s) subquery", qb.String())).
-			Where(fmt.Sprintf("SQRT(POW(a, 2) + POW(b, 2)) <= %d", rr.nearThresholdRadius))
+			Columns("subquery.users_id", "subquery.first_name", "subquery.last_name", "subquery.distance").
+			FromSubquery(qb, "subquery")
+
+		if !postgis {
+			qbTmp.Where(fmt.Sprintf("subquery.distance <= %s", qbTmp.Arg(radius)))
+		}
 
 		qb = qbTmp
 	}
 
-	if f.OrderBy != nil {
-		qb.OrderBy(*f.OrderBy)
+	return qb, rank
+}
+
+// haversineDistance returns an expression evaluating the great-circle distance in miles between
+// a rental and the location of the given latitude and longitude, for databases without PostGIS.
+func haversineDistance(lat, lng string) string {
+	return fmt.Sprintf("%[3]g * 2 * ASIN(LEAST(1, SQRT(POWER(SIN(RADIANS(rentals.lat - %[1]s) / 2), 2) + "+
+		"COS(RADIANS(%[1]s)) * COS(RADIANS(rentals.lat)) * POWER(SIN(RADIANS(rentals.lng - %[2]s) / 2), 2))))",
+		lat, lng, earthRadiusMiles)
+}
+
+// effectivePricePerDay returns an expression evaluating the average price per night of a rental
+// for a stay of the given number of nights from the from date up to the to date, with the pricing
+// rules of the rental applied the same way as in quotes.
+func effectivePricePerDay(from, to, nights string, stayNights int) string {
+	rulePercent := func(ruleType model.PricingRuleType) string {
+		return fmt.Sprintf("(SELECT pricing_rules.percent FROM pricing_rules WHERE pricing_rules.rental_id = rentals.id "+
+			"AND pricing_rules.type = '%s' LIMIT 1)", ruleType)
 	}
 
-	if f.Limit != nil {
-		qb.Limit(*f.Limit)
+	nightPrice := fmt.Sprintf("ROUND(COALESCE((SELECT pricing_rules.price_per_day FROM pricing_rules "+
+		"WHERE pricing_rules.rental_id = rentals.id AND pricing_rules.type = '%s' "+
+		"AND pricing_rules.start_date <= stay.night AND pricing_rules.end_date > stay.night LIMIT 1), rentals.price_per_day) * "+
+		"CASE WHEN EXTRACT(ISODOW FROM stay.night) IN (5, 6) THEN 1 + COALESCE(%s, 0) / 100 ELSE 1 END)",
+		model.PricingRuleSeason, rulePercent(model.PricingRuleWeekendSurcharge))
+
+	discount := "0"
+
+	switch {
+	case stayNights >= model.MonthlyDiscountNights:
+		discount = fmt.Sprintf("COALESCE(%s, %s, 0)",
+			rulePercent(model.PricingRuleMonthlyDiscount), rulePercent(model.PricingRuleWeeklyDiscount))
+	case stayNights >= model.WeeklyDiscountNights:
+		discount = fmt.Sprintf("COALESCE(%s, 0)", rulePercent(model.PricingRuleWeeklyDiscount))
 	}
 
-	if f.Offset != nil {
-		qb.Offset(*f.Offset)
+	return fmt.Sprintf("(SELECT ROUND(SUM(%s) * (1 - %s / 100) / %s) "+
+		"FROM generate_series(%s::date, %s::date - 1, interval '1 day') AS stay(night))",
+		nightPrice, discount, nights, from, to)
+}
+
+// orderByTerms returns the ORDER BY terms of the given sort terms followed by id, unless
+// the results are already ordered by id. Descending terms put missing values last.
+func orderByTerms(sort []SortTerm) []string {
+	terms := make([]string, 0, len(sort)+1)
+	orderedByID := false
+
+	for _, term := range sort {
+		column := sortColumn(term.Field)
+		if term.Descending {
+			column += " DESC NULLS LAST"
+		}
+
+		terms = append(terms, column)
+		orderedByID = orderedByID || term.Field == "id"
 	}
 
-	return qb.String()
+	if !orderedByID {
+		terms = append(terms, "id")
+	}
+
+	return terms
+}
+
+// keysetCondition returns a condition matching the rentals which follow the rental with the given key
+// in rentals ordered by the given sort terms and id. As descending terms, ascending terms put missing
+// values last.
+func keysetCondition(qb *QueryBuilder, table string, sort []SortTerm, key *RentalKey) string {
+	type keyColumn struct {
+		column     string
+		descending bool
+		nullable   bool
+		numeric    bool
+		value      any
+	}
+
+	columns := make([]keyColumn, 0, len(sort)+1)
+	orderedByID := false
+
+	for i, term := range sort {
+		columns = append(columns, keyColumn{
+			column:     table + "." + sortColumn(term.Field),
+			descending: term.Descending,
+			nullable:   nullableSortFields[term.Field],
+			numeric:    numericSortFields[term.Field],
+			value:      key.Values[i],
+		})
+		orderedByID = orderedByID || term.Field == "id"
+	}
+
+	if !orderedByID {
+		columns = append(columns, keyColumn{column: table + ".id", value: key.ID})
+	}
+
+	var (
+		equal        []string
+		alternatives []string
+	)
+
+	for _, c := range columns {
+		if c.value == nil {
+			// Missing values come last, so only rentals missing the value as well can follow.
+			equal = append(equal, c.column+" IS NULL")
+			continue
+		}
+
+		placeholder := qb.Arg(c.value)
+		if c.numeric {
+			placeholder += "::numeric"
+		}
+
+		operator := ">"
+		if c.descending {
+			operator = "<"
+		}
+
+		following := fmt.Sprintf("%s %s %s", c.column, operator, placeholder)
+		if c.nullable {
+			following = fmt.Sprintf("(%s OR %s IS NULL)", following, c.column)
+		}
+
+		if len(equal) > 0 {
+			following = "(" + strings.Join(equal, " AND ") + " AND " + following + ")"
+		}
+
+		alternatives = append(alternatives, following)
+		equal = append(equal, fmt.Sprintf("%s = %s", c.column, placeholder))
+	}
+
+	if len(alternatives) == 0 {
+		return "FALSE"
+	}
+
+	return "(" + strings.Join(alternatives, " OR ") + ")"
+}
+
+// sortColumn returns the column holding the values of the given sort field.
+func sortColumn(field string) string {
+	if column, ok := rentalSortColumns[field]; ok {
+		return column
+	}
+
+	return field
+}
+
+func joinIDs(ids []int32) string {
+	values := make([]string, 0, len(ids))
+	for _, id := range ids {
+		values = append(values, strconv.Itoa(int(id)))
+	}
+
+	return strings.Join(values, ", ")
 }
 
 func changeColumnTable(oldPrefix string, newPrefix string, columns ...string) []string {
@@ -177,11 +885,35 @@ func changeColumnTable(oldPrefix string, newPrefix string, columns ...string) []
 	return newColumns
 }
 
-func scanRental(row rowScanner) (*model.Rental, error) {
+func rentalWriteValues(rental *model.Rental) []any {
+	return []any{
+		rental.UserID,
+		rental.Name,
+		rental.Type,
+		rental.Description,
+		rental.Sleeps,
+		rental.PricePerDay,
+		rental.HomeCity,
+		rental.HomeState,
+		rental.HomeZip,
+		rental.HomeCountry,
+		rental.VehicleMake,
+		rental.VehicleModel,
+		rental.VehicleYear,
+		rental.VehicleLength,
+		rental.Latitude,
+		rental.Longitude,
+		rental.PrimaryImageURL,
+		rental.CancellationPolicy,
+	}
+}
+
+// scanRental scans a rental with its owner, followed by the given destinations of additional columns.
+func scanRental(row rowScanner, dest ...any) (*model.Rental, error) {
 	rental := new(model.Rental)
 	rental.User = new(model.User)
 
-	if err := row.Scan(
+	if err := row.Scan(append([]any{
 		&rental.ID,
 		&rental.UserID,
 		&rental.Name,
@@ -200,10 +932,15 @@ func scanRental(row rowScanner) (*model.Rental, error) {
 		&rental.Latitude,
 		&rental.Longitude,
 		&rental.PrimaryImageURL,
+		&rental.CancellationPolicy,
+		&rental.RatingAverage,
+		&rental.RatingCount,
+		&rental.Created,
+		&rental.Updated,
 		&rental.User.ID,
 		&rental.User.FirstName,
 		&rental.User.LastName,
-	); err != nil {
+	}, dest...)...); err != nil {
 		return nil, err
 	}
 
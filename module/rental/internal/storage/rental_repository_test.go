@@ -6,8 +6,10 @@ import (
 	"database/sql/driver"
 	"errors"
 	"fmt"
+	"strconv"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/google/go-cmp/cmp"
@@ -21,54 +23,81 @@ var (
 	_nearThresholdRadius = 100
 	_rentals             = model.Rentals{
 		{
-			ID:              1,
-			UserID:          2,
-			Name:            "Rental 1",
-			Type:            "Type 1",
-			Description:     "Description 1",
-			Sleeps:          4,
-			PricePerDay:     1000,
-			HomeCity:        "City 1",
-			HomeState:       "State 1",
-			HomeZip:         "Zip 1",
-			HomeCountry:     "Country 1",
-			VehicleMake:     "Make 1",
-			VehicleModel:    "Model 1",
-			VehicleYear:     2022,
-			VehicleLength:   10.5,
-			Latitude:        40.1234,
-			Longitude:       -75.5678,
-			PrimaryImageURL: "ImageURL 1",
+			ID:                 1,
+			UserID:             2,
+			Name:               "Rental 1",
+			Type:               "Type 1",
+			Description:        "Description 1",
+			Sleeps:             4,
+			PricePerDay:        1000,
+			HomeCity:           "City 1",
+			HomeState:          "State 1",
+			HomeZip:            "Zip 1",
+			HomeCountry:        "Country 1",
+			VehicleMake:        "Make 1",
+			VehicleModel:       "Model 1",
+			VehicleYear:        2022,
+			VehicleLength:      10.5,
+			Latitude:           40.1234,
+			Longitude:          -75.5678,
+			PrimaryImageURL:    "https://example.com/1.jpg",
+			CancellationPolicy: model.CancellationPolicyModerate,
+			RatingAverage:      toPtr(4.5),
+			RatingCount:        2,
+			Created:            time.Date(2023, 7, 1, 10, 0, 0, 0, time.UTC),
+			Updated:            time.Date(2023, 7, 2, 10, 0, 0, 0, time.UTC),
 			User: &model.User{
 				ID:        2,
 				FirstName: "FirstName 1",
 				LastName:  "LastName 1",
 			},
+			Images: model.RentalImages{
+				{
+					ID:       1,
+					RentalID: 1,
+					URL:      "https://example.com/1.jpg",
+					Caption:  "Front",
+					Created:  time.Date(2023, 7, 1, 10, 0, 0, 0, time.UTC),
+				},
+				{
+					ID:       2,
+					RentalID: 1,
+					URL:      "https://example.com/2.jpg",
+					Position: 1,
+					Created:  time.Date(2023, 7, 1, 10, 0, 0, 0, time.UTC),
+				},
+			},
+			Amenities: []string{"shower", "solar"},
 		},
 		{
-			ID:              2,
-			UserID:          3,
-			Name:            "Rental 2",
-			Type:            "Type 2",
-			Description:     "Description 2",
-			Sleeps:          6,
-			PricePerDay:     1500,
-			HomeCity:        "City 2",
-			HomeState:       "State 2",
-			HomeZip:         "Zip 2",
-			HomeCountry:     "Country 2",
-			VehicleMake:     "Make 2",
-			VehicleModel:    "Model 2",
-			VehicleYear:     2023,
-			VehicleLength:   12.5,
-			Latitude:        35.6789,
-			Longitude:       -80.9012,
-			PrimaryImageURL: "ImageURL 2",
+			ID:                 2,
+			UserID:             3,
+			Name:               "Rental 2",
+			Type:               "Type 2",
+			Description:        "Description 2",
+			Sleeps:             6,
+			PricePerDay:        1500,
+			HomeCity:           "City 2",
+			HomeState:          "State 2",
+			HomeZip:            "Zip 2",
+			HomeCountry:        "Country 2",
+			VehicleMake:        "Make 2",
+			VehicleModel:       "Model 2",
+			VehicleYear:        2023,
+			VehicleLength:      12.5,
+			Latitude:           35.6789,
+			Longitude:          -80.9012,
+			PrimaryImageURL:    "ImageURL 2",
+			CancellationPolicy: model.CancellationPolicyModerate,
+			Created:            time.Date(2023, 7, 3, 10, 0, 0, 0, time.UTC),
+			Updated:            time.Date(2023, 7, 4, 10, 0, 0, 0, time.UTC),
 			User: &model.User{
 				ID:        3,
 				FirstName: "FirstName 2",
 				LastName:  "LastName 2",
 			},
+			Images:    model.RentalImages{},
+			Amenities: []string{},
 		},
 	}
 	_columns = []string{
@@ -90,6 +119,11 @@ var (
 		"rentals.lat",
 		"rentals.lng",
 		"rentals.primary_image_url",
+		"rentals.cancellation_policy",
+		"rentals.rating_average",
+		"rentals.rating_count",
+		"rentals.created",
+		"rentals.updated",
 		"users.id as users_id",
 		"users.first_name",
 		"users.last_name",
@@ -98,7 +132,7 @@ var (
 
 func TestRentalRepository_GetByID(t *testing.T) {
 	selectQuery := fmt.Sprintf(
-		"SELECT %s FROM rentals JOIN users ON users.id = rentals.user_id WHERE rentals.id = \\$1",
+		"SELECT %s FROM rentals JOIN users ON users.id = rentals.user_id WHERE rentals.id = \\$1 AND rentals.deleted_at IS NULL",
 		strings.Join(_columns, ", "),
 	)
 
@@ -118,6 +152,8 @@ func TestRentalRepository_GetByID(t *testing.T) {
 				mock.ExpectQuery(selectQuery).
 					WithArgs([]driver.Value{1}...).
 					WillReturnRows(sqlmock.NewRows(_columns).AddRow(rentalValues(_rentals[0])...))
+				expectRentalImages(mock, _rentals[0])
+				expectRentalAmenities(mock, _rentals[0])
 			},
 		},
 		{
@@ -162,8 +198,369 @@ func TestRentalRepository_GetByID(t *testing.T) {
 	}
 }
 
+func TestRentalRepository_Create(t *testing.T) {
+	insertQuery := "INSERT INTO rentals \\(user_id, name, type, description, sleeps, price_per_day, " +
+		"home_city, home_state, home_zip, home_country, vehicle_make, vehicle_model, vehicle_year, " +
+		"vehicle_length, lat, lng, primary_image_url, cancellation_policy, created, updated\\) " +
+		"VALUES \\(\\$1, \\$2, \\$3, \\$4, \\$5, \\$6, \\$7, \\$8, \\$9, \\$10, \\$11, \\$12, \\$13, " +
+		"\\$14, \\$15, \\$16, \\$17, \\$18, \\$19, \\$20\\) RETURNING id"
+
+	rental := _rentals[0]
+	args := []driver.Value{
+		rental.UserID,
+		rental.Name,
+		rental.Type,
+		rental.Description,
+		rental.Sleeps,
+		rental.PricePerDay,
+		rental.HomeCity,
+		rental.HomeState,
+		rental.HomeZip,
+		rental.HomeCountry,
+		rental.VehicleMake,
+		rental.VehicleModel,
+		rental.VehicleYear,
+		rental.VehicleLength,
+		rental.Latitude,
+		rental.Longitude,
+		rental.PrimaryImageURL,
+		rental.CancellationPolicy,
+		sqlmock.AnyArg(),
+		sqlmock.AnyArg(),
+	}
+
+	testCases := []struct {
+		name          string
+		expectedID    int
+		expectedError error
+		mockFunc      func(mock sqlmock.Sqlmock)
+	}{
+		{
+			name:       "Valid rental",
+			expectedID: 9,
+			mockFunc: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery(insertQuery).
+					WithArgs(args...).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(9))
+				mock.ExpectExec("INSERT INTO rental_images \\(rental_id, url, caption, position, storage_key, thumbnails, created\\) "+
+					"VALUES \\(\\$1, \\$2, \\$3, \\$4, \\$5, \\$6, \\$7\\)").
+					WithArgs(9, rental.PrimaryImageURL, "", 0, "", nil, sqlmock.AnyArg()).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
+			},
+		},
+		{
+			name:          "Insert error",
+			expectedError: sql.ErrConnDone,
+			mockFunc: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery(insertQuery).
+					WithArgs(args...).
+					WillReturnError(sql.ErrConnDone)
+				mock.ExpectRollback()
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("failed to create sqlmock: %s", err)
+			}
+			defer db.Close()
+
+			repo := storage.NewRentalRepository(&config.Config{}, db)
+
+			tc.mockFunc(mock)
+
+			rentalID, err := repo.Create(context.Background(), rental)
+
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Fatalf("there were unfulfilled expectations: %s", err)
+			}
+
+			if rentalID != tc.expectedID {
+				t.Fatalf("result expectation mismatch: expected %d, got %d", tc.expectedID, rentalID)
+			}
+
+			if err != tc.expectedError && !errors.Is(err, tc.expectedError) {
+				t.Fatalf("error expectation mismatch: %s", cmp.Diff(err, tc.expectedError))
+			}
+		})
+	}
+}
+
+func TestRentalRepository_Update(t *testing.T) {
+	updateQuery := "UPDATE rentals SET user_id = \\$1, name = \\$2, type = \\$3, description = \\$4, sleeps = \\$5, " +
+		"price_per_day = \\$6, home_city = \\$7, home_state = \\$8, home_zip = \\$9, home_country = \\$10, " +
+		"vehicle_make = \\$11, vehicle_model = \\$12, vehicle_year = \\$13, vehicle_length = \\$14, lat = \\$15, " +
+		"lng = \\$16, primary_image_url = \\$17, cancellation_policy = \\$18, updated = \\$19 WHERE id = \\$20 AND deleted_at IS NULL"
+
+	firstImageQuery := "SELECT rental_images.url FROM rental_images WHERE rental_images.rental_id = \\$1 " +
+		"ORDER BY rental_images.position, rental_images.id LIMIT 1"
+	shiftImagesQuery := "UPDATE rental_images SET position = position \\+ 1 WHERE rental_id = \\$1"
+	promoteImageQuery := "UPDATE rental_images SET position = 0 WHERE id = \\(SELECT rental_images.id FROM rental_images " +
+		"WHERE rental_images.rental_id = \\$1 AND rental_images.url = \\$2 ORDER BY rental_images.position, rental_images.id LIMIT 1\\)"
+
+	rental := _rentals[0]
+	args := []driver.Value{
+		rental.UserID,
+		rental.Name,
+		rental.Type,
+		rental.Description,
+		rental.Sleeps,
+		rental.PricePerDay,
+		rental.HomeCity,
+		rental.HomeState,
+		rental.HomeZip,
+		rental.HomeCountry,
+		rental.VehicleMake,
+		rental.VehicleModel,
+		rental.VehicleYear,
+		rental.VehicleLength,
+		rental.Latitude,
+		rental.Longitude,
+		rental.PrimaryImageURL,
+		rental.CancellationPolicy,
+		sqlmock.AnyArg(),
+		rental.ID,
+	}
+
+	testCases := []struct {
+		name          string
+		version       *time.Time
+		expectedError error
+		mockFunc      func(mock sqlmock.Sqlmock)
+	}{
+		{
+			name: "Unconditional update",
+			mockFunc: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectExec(updateQuery + "$").
+					WithArgs(args...).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectQuery(firstImageQuery).
+					WithArgs(rental.ID).
+					WillReturnRows(sqlmock.NewRows([]string{"url"}).AddRow(rental.PrimaryImageURL))
+				mock.ExpectCommit()
+			},
+		},
+		{
+			name:    "Conditional update",
+			version: &rental.Updated,
+			mockFunc: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectExec(updateQuery + " AND updated = \\$21$").
+					WithArgs(append(args, rental.Updated)...).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectQuery(firstImageQuery).
+					WithArgs(rental.ID).
+					WillReturnRows(sqlmock.NewRows([]string{"url"}).AddRow(rental.PrimaryImageURL))
+				mock.ExpectCommit()
+			},
+		},
+		{
+			name:          "Stale version",
+			version:       &rental.Created,
+			expectedError: sql.ErrNoRows,
+			mockFunc: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectExec(updateQuery + " AND updated = \\$21$").
+					WithArgs(append(args, rental.Created)...).
+					WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectRollback()
+			},
+		},
+		{
+			name: "Rental without images",
+			mockFunc: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectExec(updateQuery + "$").
+					WithArgs(args...).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectQuery(firstImageQuery).
+					WithArgs(rental.ID).
+					WillReturnRows(sqlmock.NewRows([]string{"url"}))
+				mock.ExpectCommit()
+			},
+		},
+		{
+			name: "Existing image becomes primary",
+			mockFunc: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectExec(updateQuery + "$").
+					WithArgs(args...).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectQuery(firstImageQuery).
+					WithArgs(rental.ID).
+					WillReturnRows(sqlmock.NewRows([]string{"url"}).AddRow("https://example.com/other.jpg"))
+				mock.ExpectExec(shiftImagesQuery).
+					WithArgs(rental.ID).
+					WillReturnResult(sqlmock.NewResult(0, 2))
+				mock.ExpectExec(promoteImageQuery).
+					WithArgs(rental.ID, rental.PrimaryImageURL).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
+			},
+		},
+		{
+			name: "New primary image",
+			mockFunc: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectExec(updateQuery + "$").
+					WithArgs(args...).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectQuery(firstImageQuery).
+					WithArgs(rental.ID).
+					WillReturnRows(sqlmock.NewRows([]string{"url"}).AddRow("https://example.com/other.jpg"))
+				mock.ExpectExec(shiftImagesQuery).
+					WithArgs(rental.ID).
+					WillReturnResult(sqlmock.NewResult(0, 2))
+				mock.ExpectExec(promoteImageQuery).
+					WithArgs(rental.ID, rental.PrimaryImageURL).
+					WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectExec("INSERT INTO rental_images \\(rental_id, url, caption, position, storage_key, thumbnails, created\\) "+
+					"VALUES \\(\\$1, \\$2, \\$3, \\$4, \\$5, \\$6, \\$7\\)").
+					WithArgs(rental.ID, rental.PrimaryImageURL, "", 0, "", nil, sqlmock.AnyArg()).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("failed to create sqlmock: %s", err)
+			}
+			defer db.Close()
+
+			repo := storage.NewRentalRepository(&config.Config{}, db)
+
+			tc.mockFunc(mock)
+
+			err = repo.Update(context.Background(), rental, tc.version)
+
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Fatalf("there were unfulfilled expectations: %s", err)
+			}
+
+			if err != tc.expectedError && !errors.Is(err, tc.expectedError) {
+				t.Fatalf("error expectation mismatch: %s", cmp.Diff(err, tc.expectedError))
+			}
+		})
+	}
+}
+
+func TestRentalRepository_Archive(t *testing.T) {
+	archiveQuery := "UPDATE rentals SET deleted_at = \\$1, updated = \\$2 WHERE id = \\$3 AND deleted_at IS NULL"
+
+	testCases := []struct {
+		name          string
+		expectedError error
+		mockFunc      func(mock sqlmock.Sqlmock)
+	}{
+		{
+			name: "Active rental",
+			mockFunc: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec(archiveQuery).
+					WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), 1).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+		},
+		{
+			name:          "Missing or archived rental",
+			expectedError: sql.ErrNoRows,
+			mockFunc: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec(archiveQuery).
+					WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), 1).
+					WillReturnResult(sqlmock.NewResult(0, 0))
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("failed to create sqlmock: %s", err)
+			}
+			defer db.Close()
+
+			repo := storage.NewRentalRepository(&config.Config{}, db)
+
+			tc.mockFunc(mock)
+
+			err = repo.Archive(context.Background(), 1)
+
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Fatalf("there were unfulfilled expectations: %s", err)
+			}
+
+			if err != tc.expectedError && !errors.Is(err, tc.expectedError) {
+				t.Fatalf("error expectation mismatch: %s", cmp.Diff(err, tc.expectedError))
+			}
+		})
+	}
+}
+
+func TestRentalRepository_Restore(t *testing.T) {
+	restoreQuery := "UPDATE rentals SET deleted_at = \\$1, updated = \\$2 WHERE id = \\$3 AND deleted_at IS NOT NULL"
+
+	testCases := []struct {
+		name          string
+		expectedError error
+		mockFunc      func(mock sqlmock.Sqlmock)
+	}{
+		{
+			name: "Archived rental",
+			mockFunc: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec(restoreQuery).
+					WithArgs(nil, sqlmock.AnyArg(), 1).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+		},
+		{
+			name:          "Missing or active rental",
+			expectedError: sql.ErrNoRows,
+			mockFunc: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec(restoreQuery).
+					WithArgs(nil, sqlmock.AnyArg(), 1).
+					WillReturnResult(sqlmock.NewResult(0, 0))
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("failed to create sqlmock: %s", err)
+			}
+			defer db.Close()
+
+			repo := storage.NewRentalRepository(&config.Config{}, db)
+
+			tc.mockFunc(mock)
+
+			err = repo.Restore(context.Background(), 1)
+
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Fatalf("there were unfulfilled expectations: %s", err)
+			}
+
+			if err != tc.expectedError && !errors.Is(err, tc.expectedError) {
+				t.Fatalf("error expectation mismatch: %s", cmp.Diff(err, tc.expectedError))
+			}
+		})
+	}
+}
+
 func TestRentalRepository_List(t *testing.T) {
-	sq := "SELECT %s FROM rentals JOIN users ON users.id = rentals.user_id"
+	sq := "SELECT %s FROM rentals JOIN users ON users.id = rentals.user_id WHERE rentals.deleted_at IS NULL"
 
 	testCases := []struct {
 		name           string
@@ -182,6 +579,8 @@ func TestRentalRepository_List(t *testing.T) {
 					WillReturnRows(sqlmock.NewRows(_columns).
 						AddRow(rentalValues(_rentals[0])...).
 						AddRow(rentalValues(_rentals[1])...))
+				expectRentalImages(mock, _rentals...)
+				expectRentalAmenities(mock, _rentals...)
 			},
 		},
 		{
@@ -195,10 +594,30 @@ func TestRentalRepository_List(t *testing.T) {
 			expectedError: nil,
 			mockFunc: func(mock sqlmock.Sqlmock) {
 				selectQuery := fmt.Sprintf(sq, strings.Join(_columns, ", "))
-				mock.ExpectQuery(selectQuery + " WHERE rentals.id IN \\(1, 2\\)").
+				mock.ExpectQuery(selectQuery + " AND rentals.id IN \\(1, 2\\)").
 					// WithArgs(2).
 					WillReturnRows(sqlmock.NewRows(_columns).
 						AddRow(rentalValues(_rentals[1])...))
+				expectRentalImages(mock, _rentals[1])
+				expectRentalAmenities(mock, _rentals[1])
+			},
+		},
+		{
+			name: "List with user ID filter",
+			filters: &storage.RentalFilters{
+				UserIDs: []int32{2},
+			},
+			expectedResult: model.Rentals{
+				_rentals[1],
+			},
+			expectedError: nil,
+			mockFunc: func(mock sqlmock.Sqlmock) {
+				selectQuery := fmt.Sprintf(sq, strings.Join(_columns, ", "))
+				mock.ExpectQuery(selectQuery + " AND rentals.user_id IN \\(2\\)").
+					WillReturnRows(sqlmock.NewRows(_columns).
+						AddRow(rentalValues(_rentals[1])...))
+				expectRentalImages(mock, _rentals[1])
+				expectRentalAmenities(mock, _rentals[1])
 			},
 		},
 		{
@@ -213,9 +632,11 @@ func TestRentalRepository_List(t *testing.T) {
 			expectedError: nil,
 			mockFunc: func(mock sqlmock.Sqlmock) {
 				selectQuery := fmt.Sprintf(sq, strings.Join(_columns, ", "))
-				mock.ExpectQuery(selectQuery + " WHERE price_per_day >= 1500 AND price_per_day <= 2000").
+				mock.ExpectQuery(selectQuery + " AND price_per_day >= 1500 AND price_per_day <= 2000").
 					WillReturnRows(sqlmock.NewRows(_columns).
 						AddRow(rentalValues(_rentals[1])...))
+				expectRentalImages(mock, _rentals[1])
+				expectRentalAmenities(mock, _rentals[1])
 			},
 		},
 		{
@@ -227,39 +648,477 @@ func TestRentalRepository_List(t *testing.T) {
 				},
 			},
 			expectedResult: model.Rentals{
-				_rentals[1],
+				withDistance(_rentals[1], 12.5),
 			},
 			expectedError: nil,
 			mockFunc: func(mock sqlmock.Sqlmock) {
-				subqueryColumns := strings.Join(_columns, ", ")
-				subqueryColumns += ", ABS\\(lat - 53.28\\) as a"
-				subqueryColumns += ", ABS\\(lng - -129.12\\) as b"
+				expectPostGIS(mock, false)
 
-				parentQueryColumns := strings.Join(_columns, ", ")
-				parentQueryColumns = strings.ReplaceAll(parentQueryColumns, "users.id as users_id", "subquery.users_id")
-				parentQueryColumns = strings.ReplaceAll(parentQueryColumns, "rentals.", "subquery.")
-				parentQueryColumns = strings.ReplaceAll(parentQueryColumns, "users.", "subquery.")
+				selectQuery := fmt.Sprintf(sq, strings.Join(_columns, ", ")+", "+distanceColumn("\\$1", "\\$2"))
+				mock.ExpectQuery(
+					fmt.Sprintf("SELECT %s, subquery.distance FROM \\(%s\\) subquery WHERE subquery.distance <= \\$3 ORDER BY id$",
+						subqueryColumns(), selectQuery)).
+					WithArgs(float32(53.28), float32(-129.12), float64(100)).
+					WillReturnRows(sqlmock.NewRows(append(_columns, "distance")).
+						AddRow(append(rentalValues(_rentals[1]), 12.5)...))
+				expectRentalImages(mock, _rentals[1])
+				expectRentalAmenities(mock, _rentals[1])
+			},
+		},
+		{
+			name: "List with location filter within radius ordered by distance",
+			filters: &storage.RentalFilters{
+				Near: &storage.Location{
+					Latitude:  53.28,
+					Longitude: -129.12,
+				},
+				Radius:  toPtr(25.0),
+				OrderBy: []storage.SortTerm{{Field: "distance"}},
+			},
+			expectedResult: model.Rentals{
+				withDistance(_rentals[1], 3.25),
+				withDistance(_rentals[0], 20),
+			},
+			expectedError: nil,
+			mockFunc: func(mock sqlmock.Sqlmock) {
+				expectPostGIS(mock, false)
 
-				selectQuery := fmt.Sprintf(sq, subqueryColumns) + " WHERE ABS\\(lat - 53.28\\) <= 100 AND ABS\\(lng - -129.12\\) <= 100"
+				selectQuery := fmt.Sprintf(sq, strings.Join(_columns, ", ")+", "+distanceColumn("\\$1", "\\$2"))
 				mock.ExpectQuery(
-					fmt.Sprintf("SELECT %s FROM \\(%s\\) subquery WHERE SQRT\\(POW\\(a, 2\\) \\+ POW\\(b, 2\\)\\) <= 100", parentQueryColumns, selectQuery)).
+					fmt.Sprintf("SELECT %s, subquery.distance FROM \\(%s\\) subquery WHERE subquery.distance <= \\$3 ORDER BY distance, id$",
+						subqueryColumns(), selectQuery)).
+					WithArgs(float32(53.28), float32(-129.12), 25.0).
+					WillReturnRows(sqlmock.NewRows(append(_columns, "distance")).
+						AddRow(append(rentalValues(_rentals[1]), 3.25)...).
+						AddRow(append(rentalValues(_rentals[0]), 20.0)...))
+				expectRentalImages(mock, _rentals[1], _rentals[0])
+				expectRentalAmenities(mock, _rentals[1], _rentals[0])
+			},
+		},
+		{
+			name: "List with location filter using PostGIS",
+			filters: &storage.RentalFilters{
+				Near: &storage.Location{
+					Latitude:  53.28,
+					Longitude: -129.12,
+				},
+				Radius:  toPtr(25.0),
+				OrderBy: []storage.SortTerm{{Field: "distance"}},
+			},
+			expectedResult: model.Rentals{
+				withDistance(_rentals[1], 3.25),
+			},
+			expectedError: nil,
+			mockFunc: func(mock sqlmock.Sqlmock) {
+				expectPostGIS(mock, true)
+
+				point := "ST_SetSRID\\(ST_MakePoint\\(\\$1, \\$2\\), 4326\\)::geography"
+				selectQuery := fmt.Sprintf(sq, strings.Join(_columns, ", ")+
+					", ST_Distance\\(rentals.location, "+point+"\\) / 1609.344 AS distance") +
+					" AND ST_DWithin\\(rentals.location, " + point + ", \\$3\\)"
+				mock.ExpectQuery(
+					fmt.Sprintf("SELECT %s, subquery.distance FROM \\(%s\\) subquery ORDER BY distance, id$",
+						subqueryColumns(), selectQuery)).
+					WithArgs(float32(-129.12), float32(53.28), 25*1609.344).
+					WillReturnRows(sqlmock.NewRows(append(_columns, "distance")).
+						AddRow(append(rentalValues(_rentals[1]), 3.25)...))
+				expectRentalImages(mock, _rentals[1])
+				expectRentalAmenities(mock, _rentals[1])
+			},
+		},
+		{
+			name: "List including deleted",
+			filters: &storage.RentalFilters{
+				IncludeDeleted: true,
+			},
+			expectedResult: _rentals,
+			expectedError:  nil,
+			mockFunc: func(mock sqlmock.Sqlmock) {
+				selectQuery := fmt.Sprintf("SELECT %s FROM rentals JOIN users ON users.id = rentals.user_id ORDER BY id$", strings.Join(_columns, ", "))
+				mock.ExpectQuery(selectQuery).
+					WillReturnRows(sqlmock.NewRows(_columns).
+						AddRow(rentalValues(_rentals[0])...).
+						AddRow(rentalValues(_rentals[1])...))
+				expectRentalImages(mock, _rentals...)
+				expectRentalAmenities(mock, _rentals...)
+			},
+		},
+		{
+			name: "List with availability filter",
+			filters: &storage.RentalFilters{
+				AvailableFrom: toPtr(time.Date(2023, 7, 1, 0, 0, 0, 0, time.UTC)),
+				AvailableTo:   toPtr(time.Date(2023, 7, 5, 0, 0, 0, 0, time.UTC)),
+			},
+			expectedResult: model.Rentals{
+				_rentals[1],
+			},
+			expectedError: nil,
+			mockFunc: func(mock sqlmock.Sqlmock) {
+				selectQuery := fmt.Sprintf(sq, strings.Join(_columns, ", "))
+				mock.ExpectQuery(selectQuery+" AND NOT EXISTS \\(SELECT 1 FROM bookings WHERE bookings.rental_id = rentals.id "+
+					"AND bookings.status = 'confirmed' AND daterange\\(bookings.start_date, bookings.end_date\\) && "+
+					"daterange\\(\\$1::date, \\$2::date\\)\\) "+
+					"AND NOT EXISTS \\(SELECT 1 FROM rental_blocked_days WHERE rental_blocked_days.rental_id = rentals.id "+
+					"AND rental_blocked_days.day >= \\$1::date AND rental_blocked_days.day < \\$2::date\\) "+
+					"AND NOT EXISTS \\(SELECT 1 FROM rental_stay_rules WHERE rental_stay_rules.rental_id = rentals.id "+
+					"AND \\(rental_stay_rules.min_nights > \\$3 OR rental_stay_rules.max_nights < \\$3\\)\\)").
+					WithArgs(time.Date(2023, 7, 1, 0, 0, 0, 0, time.UTC), time.Date(2023, 7, 5, 0, 0, 0, 0, time.UTC), 4).
 					WillReturnRows(sqlmock.NewRows(_columns).
 						AddRow(rentalValues(_rentals[1])...))
+				expectRentalImages(mock, _rentals[1])
+				expectRentalAmenities(mock, _rentals[1])
+			},
+		},
+		{
+			name: "List with effective price filter",
+			filters: &storage.RentalFilters{
+				PriceMax:       toPtr[int64](20000),
+				AvailableFrom:  toPtr(time.Date(2023, 7, 1, 0, 0, 0, 0, time.UTC)),
+				AvailableTo:    toPtr(time.Date(2023, 7, 8, 0, 0, 0, 0, time.UTC)),
+				EffectivePrice: true,
+			},
+			expectedResult: model.Rentals{
+				_rentals[1],
+			},
+			expectedError: nil,
+			mockFunc: func(mock sqlmock.Sqlmock) {
+				selectQuery := fmt.Sprintf(sq, strings.Join(_columns, ", "))
+				mock.ExpectQuery(selectQuery+" AND NOT EXISTS .* AND \\(SELECT ROUND\\(SUM\\(ROUND\\(COALESCE\\(\\(SELECT pricing_rules.price_per_day "+
+					"FROM pricing_rules WHERE pricing_rules.rental_id = rentals.id AND pricing_rules.type = 'season' .*\\), rentals.price_per_day\\) \\* "+
+					"CASE WHEN EXTRACT\\(ISODOW FROM stay.night\\) IN \\(5, 6\\) THEN 1 \\+ COALESCE\\(.*'weekend_surcharge'.*, 0\\) / 100 ELSE 1 END\\)\\) "+
+					"\\* \\(1 - COALESCE\\(.*'weekly_discount' LIMIT 1\\), 0\\) / 100\\) / \\$3\\) "+
+					"FROM generate_series\\(\\$1::date, \\$2::date - 1, interval '1 day'\\) AS stay\\(night\\)\\) <= 20000 ORDER BY id$").
+					WithArgs(time.Date(2023, 7, 1, 0, 0, 0, 0, time.UTC), time.Date(2023, 7, 8, 0, 0, 0, 0, time.UTC), 7).
+					WillReturnRows(sqlmock.NewRows(_columns).
+						AddRow(rentalValues(_rentals[1])...))
+				expectRentalImages(mock, _rentals[1])
+				expectRentalAmenities(mock, _rentals[1])
 			},
 		},
 		{
 			name: "List with order by filter",
 			filters: &storage.RentalFilters{
-				OrderBy: toPtr("price_per_day"),
+				OrderBy: []storage.SortTerm{{Field: "price_per_day"}},
+			},
+			expectedResult: _rentals,
+			expectedError:  nil,
+			mockFunc: func(mock sqlmock.Sqlmock) {
+				selectQuery := fmt.Sprintf(sq, strings.Join(_columns, ", "))
+				mock.ExpectQuery(selectQuery + " ORDER BY price_per_day, id").
+					WillReturnRows(sqlmock.NewRows(_columns).
+						AddRow(rentalValues(_rentals[0])...).
+						AddRow(rentalValues(_rentals[1])...))
+				expectRentalImages(mock, _rentals...)
+				expectRentalAmenities(mock, _rentals...)
+			},
+		},
+		{
+			name: "List with rating filter and order",
+			filters: &storage.RentalFilters{
+				RatingMin: toPtr(4.0),
+				OrderBy:   []storage.SortTerm{{Field: "rating"}},
+			},
+			expectedResult: model.Rentals{
+				_rentals[0],
+			},
+			expectedError: nil,
+			mockFunc: func(mock sqlmock.Sqlmock) {
+				selectQuery := fmt.Sprintf(sq, strings.Join(_columns, ", "))
+				mock.ExpectQuery(selectQuery + " AND rentals.rating_average >= \\$1 ORDER BY rating_average, id").
+					WithArgs(4.0).
+					WillReturnRows(sqlmock.NewRows(_columns).
+						AddRow(rentalValues(_rentals[0])...))
+				expectRentalImages(mock, _rentals[0])
+				expectRentalAmenities(mock, _rentals[0])
+			},
+		},
+		{
+			name: "List with wishlist filter",
+			filters: &storage.RentalFilters{
+				WishlistID: toPtr(int32(3)),
+			},
+			expectedResult: model.Rentals{
+				_rentals[1],
+			},
+			expectedError: nil,
+			mockFunc: func(mock sqlmock.Sqlmock) {
+				selectQuery := fmt.Sprintf(sq, strings.Join(_columns, ", "))
+				mock.ExpectQuery(selectQuery + " AND rentals.id IN \\(SELECT wishlist_rentals.rental_id FROM wishlist_rentals " +
+					"WHERE wishlist_rentals.wishlist_id = \\$1\\)").
+					WithArgs(int32(3)).
+					WillReturnRows(sqlmock.NewRows(_columns).
+						AddRow(rentalValues(_rentals[1])...))
+				expectRentalImages(mock, _rentals[1])
+				expectRentalAmenities(mock, _rentals[1])
+			},
+		},
+		{
+			name: "List with amenities filter",
+			filters: &storage.RentalFilters{
+				Amenities: []string{"shower", "solar", "shower"},
+			},
+			expectedResult: model.Rentals{
+				_rentals[0],
+			},
+			expectedError: nil,
+			mockFunc: func(mock sqlmock.Sqlmock) {
+				selectQuery := fmt.Sprintf(sq, strings.Join(_columns, ", "))
+				mock.ExpectQuery(selectQuery+" AND rentals.id IN \\(SELECT rental_amenities.rental_id FROM rental_amenities "+
+					"JOIN amenities ON amenities.id = rental_amenities.amenity_id WHERE amenities.slug IN \\(\\$1, \\$2\\) "+
+					"GROUP BY rental_amenities.rental_id HAVING COUNT\\(\\*\\) = 2\\)").
+					WithArgs("shower", "solar").
+					WillReturnRows(sqlmock.NewRows(_columns).
+						AddRow(rentalValues(_rentals[0])...))
+				expectRentalImages(mock, _rentals[0])
+				expectRentalAmenities(mock, _rentals[0])
+			},
+		},
+		{
+			name: "List with attribute filters",
+			filters: &storage.RentalFilters{
+				Types:     []string{"camper-van", "trailer"},
+				Make:      toPtr("ford"),
+				Model:     toPtr("transit"),
+				YearMin:   toPtr(int32(2015)),
+				YearMax:   toPtr(int32(2022)),
+				LengthMin: toPtr(float32(7.5)),
+				LengthMax: toPtr(float32(12)),
+				SleepsMin: toPtr(int32(4)),
+			},
+			expectedResult: model.Rentals{
+				_rentals[0],
+			},
+			expectedError: nil,
+			mockFunc: func(mock sqlmock.Sqlmock) {
+				selectQuery := fmt.Sprintf(sq, strings.Join(_columns, ", "))
+				mock.ExpectQuery(selectQuery+" AND rentals.type IN \\(\\$1, \\$2\\)"+
+					" AND LOWER\\(rentals.vehicle_make\\) = LOWER\\(\\$3\\)"+
+					" AND LOWER\\(rentals.vehicle_model\\) = LOWER\\(\\$4\\)"+
+					" AND rentals.vehicle_year >= \\$5 AND rentals.vehicle_year <= \\$6"+
+					" AND rentals.vehicle_length >= \\$7 AND rentals.vehicle_length <= \\$8"+
+					" AND rentals.sleeps >= \\$9 ORDER BY id$").
+					WithArgs("camper-van", "trailer", "ford", "transit", int32(2015), int32(2022), float32(7.5), float32(12), int32(4)).
+					WillReturnRows(sqlmock.NewRows(_columns).
+						AddRow(rentalValues(_rentals[0])...))
+				expectRentalImages(mock, _rentals[0])
+				expectRentalAmenities(mock, _rentals[0])
+			},
+		},
+		{
+			name: "List with search ranked by relevance",
+			filters: &storage.RentalFilters{
+				Search: toPtr("sprinter van"),
 			},
 			expectedResult: _rentals,
 			expectedError:  nil,
 			mockFunc: func(mock sqlmock.Sqlmock) {
 				selectQuery := fmt.Sprintf(sq, strings.Join(_columns, ", "))
-				mock.ExpectQuery(selectQuery + " ORDER BY price_per_day").
+				mock.ExpectQuery(selectQuery + " AND rentals.search_vector @@ websearch_to_tsquery\\('english', \\$1\\) " +
+					"ORDER BY ts_rank\\(rentals.search_vector, websearch_to_tsquery\\('english', \\$1\\)\\) DESC, id$").
+					WithArgs("sprinter van").
 					WillReturnRows(sqlmock.NewRows(_columns).
 						AddRow(rentalValues(_rentals[0])...).
 						AddRow(rentalValues(_rentals[1])...))
+				expectRentalImages(mock, _rentals...)
+				expectRentalAmenities(mock, _rentals...)
+			},
+		},
+		{
+			name: "List with search and order",
+			filters: &storage.RentalFilters{
+				Search:  toPtr("sprinter"),
+				OrderBy: []storage.SortTerm{{Field: "price_per_day"}},
+			},
+			expectedResult: model.Rentals{
+				_rentals[1],
+			},
+			expectedError: nil,
+			mockFunc: func(mock sqlmock.Sqlmock) {
+				selectQuery := fmt.Sprintf(sq, strings.Join(_columns, ", "))
+				mock.ExpectQuery(selectQuery + " AND rentals.search_vector @@ websearch_to_tsquery\\('english', \\$1\\) " +
+					"ORDER BY price_per_day, id$").
+					WithArgs("sprinter").
+					WillReturnRows(sqlmock.NewRows(_columns).
+						AddRow(rentalValues(_rentals[1])...))
+				expectRentalImages(mock, _rentals[1])
+				expectRentalAmenities(mock, _rentals[1])
+			},
+		},
+		{
+			name: "List with search near a location",
+			filters: &storage.RentalFilters{
+				Search: toPtr("sprinter"),
+				Near: &storage.Location{
+					Latitude:  53.28,
+					Longitude: -129.12,
+				},
+			},
+			expectedResult: model.Rentals{
+				withDistance(_rentals[1], 12.5),
+			},
+			expectedError: nil,
+			mockFunc: func(mock sqlmock.Sqlmock) {
+				expectPostGIS(mock, false)
+
+				columns := strings.Join(_columns, ", ") + ", " + distanceColumn("\\$2", "\\$3") +
+					", ts_rank\\(rentals.search_vector, websearch_to_tsquery\\('english', \\$1\\)\\) AS rank"
+
+				selectQuery := fmt.Sprintf(sq, columns) + " AND rentals.search_vector @@ websearch_to_tsquery\\('english', \\$1\\)"
+				mock.ExpectQuery(
+					fmt.Sprintf("SELECT %s, subquery.distance FROM \\(%s\\) subquery WHERE subquery.distance <= \\$4 "+
+						"ORDER BY subquery.rank DESC, id$", subqueryColumns(), selectQuery)).
+					WithArgs("sprinter", float32(53.28), float32(-129.12), float64(100)).
+					WillReturnRows(sqlmock.NewRows(append(_columns, "distance")).
+						AddRow(append(rentalValues(_rentals[1]), 12.5)...))
+				expectRentalImages(mock, _rentals[1])
+				expectRentalAmenities(mock, _rentals[1])
+			},
+		},
+		{
+			name: "List with order by mapped column",
+			filters: &storage.RentalFilters{
+				OrderBy: []storage.SortTerm{{Field: "year"}},
+			},
+			expectedResult: _rentals,
+			expectedError:  nil,
+			mockFunc: func(mock sqlmock.Sqlmock) {
+				selectQuery := fmt.Sprintf(sq, strings.Join(_columns, ", "))
+				mock.ExpectQuery(selectQuery + " ORDER BY vehicle_year, id").
+					WillReturnRows(sqlmock.NewRows(_columns).
+						AddRow(rentalValues(_rentals[0])...).
+						AddRow(rentalValues(_rentals[1])...))
+				expectRentalImages(mock, _rentals...)
+				expectRentalAmenities(mock, _rentals...)
+			},
+		},
+		{
+			name: "List with multiple order by terms",
+			filters: &storage.RentalFilters{
+				OrderBy: []storage.SortTerm{
+					{Field: "price_per_day", Descending: true},
+					{Field: "year"},
+				},
+			},
+			expectedResult: _rentals,
+			expectedError:  nil,
+			mockFunc: func(mock sqlmock.Sqlmock) {
+				selectQuery := fmt.Sprintf(sq, strings.Join(_columns, ", "))
+				mock.ExpectQuery(selectQuery + " ORDER BY price_per_day DESC NULLS LAST, vehicle_year, id$").
+					WillReturnRows(sqlmock.NewRows(_columns).
+						AddRow(rentalValues(_rentals[0])...).
+						AddRow(rentalValues(_rentals[1])...))
+				expectRentalImages(mock, _rentals...)
+				expectRentalAmenities(mock, _rentals...)
+			},
+		},
+		{
+			name: "List ordered by id",
+			filters: &storage.RentalFilters{
+				OrderBy: []storage.SortTerm{
+					{Field: "id", Descending: true},
+				},
+			},
+			expectedResult: _rentals,
+			expectedError:  nil,
+			mockFunc: func(mock sqlmock.Sqlmock) {
+				selectQuery := fmt.Sprintf(sq, strings.Join(_columns, ", "))
+				mock.ExpectQuery(selectQuery + " ORDER BY id DESC NULLS LAST$").
+					WillReturnRows(sqlmock.NewRows(_columns).
+						AddRow(rentalValues(_rentals[0])...).
+						AddRow(rentalValues(_rentals[1])...))
+				expectRentalImages(mock, _rentals...)
+				expectRentalAmenities(mock, _rentals...)
+			},
+		},
+		{
+			name: "List after a key",
+			filters: &storage.RentalFilters{
+				After: &storage.RentalKey{ID: 1},
+				Pagination: storage.Pagination{
+					Limit: toPtr(1),
+				},
+			},
+			expectedResult: model.Rentals{
+				_rentals[1],
+			},
+			expectedError: nil,
+			mockFunc: func(mock sqlmock.Sqlmock) {
+				selectQuery := fmt.Sprintf(sq, strings.Join(_columns, ", "))
+				mock.ExpectQuery(selectQuery + " AND \\(rentals.id > \\$1\\) ORDER BY id LIMIT 1$").
+					WithArgs(int32(1)).
+					WillReturnRows(sqlmock.NewRows(_columns).
+						AddRow(rentalValues(_rentals[1])...))
+				expectRentalImages(mock, _rentals[1])
+				expectRentalAmenities(mock, _rentals[1])
+			},
+		},
+		{
+			name: "List after a key with order by terms",
+			filters: &storage.RentalFilters{
+				OrderBy: []storage.SortTerm{
+					{Field: "rating", Descending: true},
+					{Field: "price_per_day"},
+				},
+				After: &storage.RentalKey{Values: []any{4.5, int64(1000)}, ID: 1},
+			},
+			expectedResult: model.Rentals{
+				_rentals[1],
+			},
+			expectedError: nil,
+			mockFunc: func(mock sqlmock.Sqlmock) {
+				selectQuery := fmt.Sprintf(sq, strings.Join(_columns, ", "))
+				mock.ExpectQuery(selectQuery+" AND \\(\\(rentals.rating_average < \\$1 OR rentals.rating_average IS NULL\\)"+
+					" OR \\(rentals.rating_average = \\$1 AND rentals.price_per_day > \\$2\\)"+
+					" OR \\(rentals.rating_average = \\$1 AND rentals.price_per_day = \\$2 AND rentals.id > \\$3\\)\\)"+
+					" ORDER BY rating_average DESC NULLS LAST, price_per_day, id$").
+					WithArgs(4.5, int64(1000), int32(1)).
+					WillReturnRows(sqlmock.NewRows(_columns).
+						AddRow(rentalValues(_rentals[1])...))
+				expectRentalImages(mock, _rentals[1])
+				expectRentalAmenities(mock, _rentals[1])
+			},
+		},
+		{
+			name: "List after a key with length",
+			filters: &storage.RentalFilters{
+				OrderBy: []storage.SortTerm{
+					{Field: "length"},
+				},
+				After: &storage.RentalKey{Values: []any{"20.70"}, ID: 1},
+			},
+			expectedResult: model.Rentals{
+				_rentals[1],
+			},
+			expectedError: nil,
+			mockFunc: func(mock sqlmock.Sqlmock) {
+				selectQuery := fmt.Sprintf(sq, strings.Join(_columns, ", "))
+				mock.ExpectQuery(selectQuery+" AND \\(rentals.vehicle_length > \\$1::numeric"+
+					" OR \\(rentals.vehicle_length = \\$1::numeric AND rentals.id > \\$2\\)\\)"+
+					" ORDER BY vehicle_length, id$").
+					WithArgs("20.70", int32(1)).
+					WillReturnRows(sqlmock.NewRows(_columns).
+						AddRow(rentalValues(_rentals[1])...))
+				expectRentalImages(mock, _rentals[1])
+				expectRentalAmenities(mock, _rentals[1])
+			},
+		},
+		{
+			name: "List after a key without rating",
+			filters: &storage.RentalFilters{
+				OrderBy: []storage.SortTerm{
+					{Field: "rating"},
+					{Field: "id", Descending: true},
+				},
+				After: &storage.RentalKey{Values: []any{nil, int32(2)}, ID: 2},
+			},
+			expectedResult: model.Rentals{},
+			expectedError:  nil,
+			mockFunc: func(mock sqlmock.Sqlmock) {
+				selectQuery := fmt.Sprintf(sq, strings.Join(_columns, ", "))
+				mock.ExpectQuery(selectQuery + " AND \\(\\(rentals.rating_average IS NULL AND rentals.id < \\$1\\)\\)" +
+					" ORDER BY rating_average, id DESC NULLS LAST$").
+					WithArgs(int32(2)).
+					WillReturnRows(sqlmock.NewRows(_columns))
 			},
 		},
 		{
@@ -273,10 +1132,12 @@ func TestRentalRepository_List(t *testing.T) {
 			expectedError:  nil,
 			mockFunc: func(mock sqlmock.Sqlmock) {
 				selectQuery := fmt.Sprintf(sq, strings.Join(_columns, ", "))
-				mock.ExpectQuery(selectQuery + " LIMIT 3").
+				mock.ExpectQuery(selectQuery + " ORDER BY id LIMIT 3").
 					WillReturnRows(sqlmock.NewRows(_columns).
 						AddRow(rentalValues(_rentals[0])...).
 						AddRow(rentalValues(_rentals[1])...))
+				expectRentalImages(mock, _rentals...)
+				expectRentalAmenities(mock, _rentals...)
 			},
 		},
 		{
@@ -290,10 +1151,12 @@ func TestRentalRepository_List(t *testing.T) {
 			expectedError:  nil,
 			mockFunc: func(mock sqlmock.Sqlmock) {
 				selectQuery := fmt.Sprintf(sq, strings.Join(_columns, ", "))
-				mock.ExpectQuery(selectQuery + " OFFSET 8").
+				mock.ExpectQuery(selectQuery + " ORDER BY id OFFSET 8").
 					WillReturnRows(sqlmock.NewRows(_columns).
 						AddRow(rentalValues(_rentals[0])...).
 						AddRow(rentalValues(_rentals[1])...))
+				expectRentalImages(mock, _rentals...)
+				expectRentalAmenities(mock, _rentals...)
 			},
 		},
 		{
@@ -337,12 +1200,125 @@ func TestRentalRepository_List(t *testing.T) {
 	}
 }
 
+func TestRentalRepository_Count(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to create sqlmock: %s", err)
+	}
+	defer db.Close()
+
+	filters := &storage.RentalFilters{
+		PriceMin: toPtr[int64](100),
+		OrderBy:  []storage.SortTerm{{Field: "price_per_day"}},
+		After:    &storage.RentalKey{Values: []any{int64(100)}, ID: 1},
+		Pagination: storage.Pagination{
+			Limit: toPtr(3),
+		},
+	}
+
+	mock.ExpectQuery(fmt.Sprintf("^SELECT COUNT\\(\\*\\) FROM \\(SELECT %s FROM rentals JOIN users ON users.id = rentals.user_id "+
+		"WHERE rentals.deleted_at IS NULL AND price_per_day >= 100\\) filtered$", strings.Join(_columns, ", "))).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(7))
+
+	count, err := storage.NewRentalRepository(&config.Config{NearThresholdRadius: _nearThresholdRadius}, db).
+		Count(context.Background(), filters)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatalf("there were unfulfilled expectations: %s", err)
+	}
+
+	if count != 7 {
+		t.Fatalf("count expectation mismatch: expected 7, got %d", count)
+	}
+}
+
 // Helper function to create a pointers to values.
 func toPtr[T any](v T) *T {
 	return &v
 }
 
+// distanceColumn returns a pattern of the column selecting the distance of rentals to the location
+// of the given latitude and longitude placeholders.
+func distanceColumn(lat, lng string) string {
+	return fmt.Sprintf("3958.8 \\* 2 \\* ASIN\\(LEAST\\(1, SQRT\\(POWER\\(SIN\\(RADIANS\\(rentals.lat - %[1]s\\) / 2\\), 2\\) \\+ "+
+		"COS\\(RADIANS\\(%[1]s\\)\\) \\* COS\\(RADIANS\\(rentals.lat\\)\\) \\* "+
+		"POWER\\(SIN\\(RADIANS\\(rentals.lng - %[2]s\\) / 2\\), 2\\)\\)\\)\\) AS distance", lat, lng)
+}
+
+// subqueryColumns returns the rental columns selected from the subquery of rentals near a location.
+func subqueryColumns() string {
+	columns := strings.Join(_columns, ", ")
+	columns = strings.ReplaceAll(columns, "users.id as users_id", "subquery.users_id")
+	columns = strings.ReplaceAll(columns, "rentals.", "subquery.")
+
+	return strings.ReplaceAll(columns, "users.", "subquery.")
+}
+
+// expectPostGIS expects the query checking whether rentals have the PostGIS location column.
+func expectPostGIS(mock sqlmock.Sqlmock, available bool) {
+	mock.ExpectQuery("SELECT EXISTS \\(SELECT 1 FROM information_schema.columns " +
+		"WHERE table_name = 'rentals' AND column_name = 'location'\\)").
+		WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(available))
+}
+
+// withDistance returns a copy of the rental listed at the given distance.
+func withDistance(rental *model.Rental, distance float64) *model.Rental {
+	copied := *rental
+	copied.Distance = &distance
+
+	return &copied
+}
+
+// expectRentalImages expects the query loading the images of the given rentals.
+func expectRentalImages(mock sqlmock.Sqlmock, rentals ...*model.Rental) {
+	ids := make([]string, 0, len(rentals))
+	rows := sqlmock.NewRows([]string{"id", "rental_id", "url", "caption", "position", "storage_key", "thumbnails", "created"})
+
+	for _, rental := range rentals {
+		ids = append(ids, strconv.Itoa(int(rental.ID)))
+
+		for _, image := range rental.Images {
+			rows.AddRow(image.ID, image.RentalID, image.URL, image.Caption, image.Position, image.StorageKey, nil, image.Created)
+		}
+	}
+
+	mock.ExpectQuery(fmt.Sprintf("SELECT rental_images.id, rental_images.rental_id, rental_images.url, "+
+		"rental_images.caption, rental_images.position, rental_images.storage_key, rental_images.thumbnails, "+
+		"rental_images.created FROM rental_images "+
+		"WHERE rental_images.rental_id IN \\(%s\\) "+
+		"ORDER BY rental_images.rental_id, rental_images.position, rental_images.id", strings.Join(ids, ", "))).
+		WillReturnRows(rows)
+}
+
+// expectRentalAmenities expects the query loading the amenities of the given rentals.
+func expectRentalAmenities(mock sqlmock.Sqlmock, rentals ...*model.Rental) {
+	ids := make([]string, 0, len(rentals))
+	rows := sqlmock.NewRows([]string{"rental_id", "slug"})
+
+	for _, rental := range rentals {
+		ids = append(ids, strconv.Itoa(int(rental.ID)))
+
+		for _, slug := range rental.Amenities {
+			rows.AddRow(rental.ID, slug)
+		}
+	}
+
+	mock.ExpectQuery(fmt.Sprintf("SELECT rental_amenities.rental_id, amenities.slug FROM rental_amenities "+
+		"JOIN amenities ON amenities.id = rental_amenities.amenity_id "+
+		"WHERE rental_amenities.rental_id IN \\(%s\\) "+
+		"ORDER BY rental_amenities.rental_id, amenities.slug", strings.Join(ids, ", "))).
+		WillReturnRows(rows)
+}
+
 func rentalValues(rental *model.Rental) []driver.Value {
+	var ratingAverage driver.Value
+	if rental.RatingAverage != nil {
+		ratingAverage = *rental.RatingAverage
+	}
+
 	return []driver.Value{
 		rental.ID,
 		rental.UserID,
@@ -362,6 +1338,11 @@ func rentalValues(rental *model.Rental) []driver.Value {
 		rental.Latitude,
 		rental.Longitude,
 		rental.PrimaryImageURL,
+		string(rental.CancellationPolicy),
+		ratingAverage,
+		rental.RatingCount,
+		rental.Created,
+		rental.Updated,
 		rental.User.ID,
 		rental.User.FirstName,
 		rental.User.LastName,
@@ -0,0 +1,186 @@
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgconn"
+
+	model "github.com/dragonator/rental-service/module/rental/internal/model"
+)
+
+// _uniqueViolation is the Postgres error code raised when a unique constraint is violated.
+const _uniqueViolation = "23505"
+
+// lockRentalQuery locks the rental of a review being created until the end of the transaction,
+// so that the rating refreshes of concurrent reviews of the rental see each other's reviews.
+const lockRentalQuery = "SELECT id FROM rentals WHERE id = $1 FOR UPDATE"
+
+// refreshRatingQuery recalculates the rating of a rental from its reviews.
+const refreshRatingQuery = "UPDATE rentals SET " +
+	"rating_average = (SELECT ROUND(AVG(reviews.rating), 2) FROM reviews WHERE reviews.rental_id = rentals.id), " +
+	"rating_count = (SELECT COUNT(*) FROM reviews WHERE reviews.rental_id = rentals.id) " +
+	"WHERE rentals.id = $1"
+
+// ErrReviewExists is returned when the booking of a review has already been reviewed.
+var ErrReviewExists = errors.New("booking has already been reviewed")
+
+var (
+	reviewColumns = []string{
+		"reviews.id",
+		"reviews.rental_id",
+		"reviews.booking_id",
+		"reviews.user_id",
+		"reviews.rating",
+		"reviews.comment",
+		"reviews.created",
+	}
+	reviewWriteColumns = []string{
+		"rental_id",
+		"booking_id",
+		"user_id",
+		"rating",
+		"comment",
+		"created",
+	}
+)
+
+// ReviewRepository hold DB operations over review entities.
+type ReviewRepository struct {
+	db *sql.DB
+}
+
+// NewReviewRepository is a constructor function for ReviewRepository.
+func NewReviewRepository(db *sql.DB) *ReviewRepository {
+	return &ReviewRepository{
+		db: db,
+	}
+}
+
+// GetByID returns a single review object corresponding to the requested id.
+// If no such review exists it returns an error.
+func (rr *ReviewRepository) GetByID(ctx context.Context, reviewID int) (*model.Review, error) {
+	qb := NewQueryBuilder().
+		Select().
+		Columns(reviewColumns...).
+		From("reviews").
+		Where("reviews.id = $1")
+
+	review, err := scanReview(rr.db.QueryRowContext(ctx, qb.String(), reviewID))
+	if err != nil {
+		return nil, fmt.Errorf("getting review by id: %w", err)
+	}
+
+	return review, nil
+}
+
+// ListByRental returns a page of the reviews of the rental with the given id, newest first.
+func (rr *ReviewRepository) ListByRental(ctx context.Context, rentalID int, page *Pagination) (model.Reviews, error) {
+	qb := NewQueryBuilder().
+		Select().
+		Columns(reviewColumns...).
+		From("reviews").
+		Where("reviews.rental_id = $1").
+		OrderBy("reviews.created DESC, reviews.id DESC")
+
+	if page != nil && page.Limit != nil {
+		qb.Limit(*page.Limit)
+	}
+
+	if page != nil && page.Offset != nil {
+		qb.Offset(*page.Offset)
+	}
+
+	rows, err := rr.db.QueryContext(ctx, qb.String(), rentalID)
+	if err != nil {
+		return nil, fmt.Errorf("listing reviews: %w", err)
+	}
+
+	defer rows.Close()
+
+	reviews := make(model.Reviews, 0, 10)
+
+	for rows.Next() {
+		review, err := scanReview(rows)
+		if err != nil {
+			return nil, fmt.Errorf("scanning review: %w", err)
+		}
+
+		reviews = append(reviews, review)
+	}
+
+	return reviews, nil
+}
+
+// Create inserts a new review, updates the rating of its rental and returns the id assigned to the review.
+// If the booking of the review has already been reviewed it returns ErrReviewExists.
+func (rr *ReviewRepository) Create(ctx context.Context, review *model.Review) (_ int, err error) {
+	tx, err := rr.db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, fmt.Errorf("creating review: %w", err)
+	}
+
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback()
+		}
+	}()
+
+	if _, err = tx.ExecContext(ctx, lockRentalQuery, review.RentalID); err != nil {
+		return 0, fmt.Errorf("locking rental of review: %w", err)
+	}
+
+	qb := NewQueryBuilder().
+		Insert("reviews").
+		Columns(reviewWriteColumns...).
+		Returning("id")
+
+	var reviewID int
+	err = tx.QueryRowContext(ctx, qb.String(),
+		review.RentalID,
+		review.BookingID,
+		review.UserID,
+		review.Rating,
+		review.Comment,
+		time.Now().UTC(),
+	).Scan(&reviewID)
+
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == _uniqueViolation {
+		return 0, fmt.Errorf("creating review: %w", ErrReviewExists)
+	}
+	if err != nil {
+		return 0, fmt.Errorf("creating review: %w", err)
+	}
+
+	if _, err = tx.ExecContext(ctx, refreshRatingQuery, review.RentalID); err != nil {
+		return 0, fmt.Errorf("updating rental rating: %w", err)
+	}
+
+	if err = tx.Commit(); err != nil {
+		return 0, fmt.Errorf("creating review: %w", err)
+	}
+
+	return reviewID, nil
+}
+
+func scanReview(row rowScanner) (*model.Review, error) {
+	review := new(model.Review)
+
+	if err := row.Scan(
+		&review.ID,
+		&review.RentalID,
+		&review.BookingID,
+		&review.UserID,
+		&review.Rating,
+		&review.Comment,
+		&review.Created,
+	); err != nil {
+		return nil, err
+	}
+
+	return review, nil
+}
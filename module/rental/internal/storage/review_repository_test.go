@@ -0,0 +1,120 @@
+package storage_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/go-cmp/cmp"
+	"github.com/jackc/pgx/v5/pgconn"
+
+	model "github.com/dragonator/rental-service/module/rental/internal/model"
+	"github.com/dragonator/rental-service/module/rental/internal/storage"
+)
+
+var _reviewColumns = []string{"id", "rental_id", "booking_id", "user_id", "rating", "comment", "created"}
+
+func TestReviewRepository_Create(t *testing.T) {
+	insertQuery := "INSERT INTO reviews \\(rental_id, booking_id, user_id, rating, comment, created\\) " +
+		"VALUES \\(\\$1, \\$2, \\$3, \\$4, \\$5, \\$6\\) RETURNING id"
+	lockQuery := "SELECT id FROM rentals WHERE id = \\$1 FOR UPDATE"
+	refreshQuery := "UPDATE rentals SET " +
+		"rating_average = \\(SELECT ROUND\\(AVG\\(reviews.rating\\), 2\\) FROM reviews WHERE reviews.rental_id = rentals.id\\), " +
+		"rating_count = \\(SELECT COUNT\\(\\*\\) FROM reviews WHERE reviews.rental_id = rentals.id\\) " +
+		"WHERE rentals.id = \\$1"
+	review := &model.Review{RentalID: 2, BookingID: 4, UserID: 7, Rating: 5, Comment: "Great van"}
+
+	testCases := []struct {
+		name          string
+		expectedID    int
+		expectedError error
+		mockFunc      func(mock sqlmock.Sqlmock)
+	}{
+		{
+			name:       "New review",
+			expectedID: 1,
+			mockFunc: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectExec(lockQuery).WithArgs(2).WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectQuery(insertQuery).
+					WithArgs(2, 4, 7, 5, "Great van", sqlmock.AnyArg()).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+				mock.ExpectExec(refreshQuery).WithArgs(2).WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
+			},
+		},
+		{
+			name:          "Reviewed booking",
+			expectedError: storage.ErrReviewExists,
+			mockFunc: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectExec(lockQuery).WithArgs(2).WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectQuery(insertQuery).
+					WillReturnError(&pgconn.PgError{Code: "23505"})
+				mock.ExpectRollback()
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("failed to create sqlmock: %s", err)
+			}
+			defer db.Close()
+
+			tc.mockFunc(mock)
+
+			reviewID, err := storage.NewReviewRepository(db).Create(context.Background(), review)
+
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Fatalf("there were unfulfilled expectations: %s", err)
+			}
+
+			if !errors.Is(err, tc.expectedError) {
+				t.Fatalf("error expectation mismatch: expected %v, got %v", tc.expectedError, err)
+			}
+
+			if reviewID != tc.expectedID {
+				t.Fatalf("result expectation mismatch: expected %d, got %d", tc.expectedID, reviewID)
+			}
+		})
+	}
+}
+
+func TestReviewRepository_ListByRental(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to create sqlmock: %s", err)
+	}
+	defer db.Close()
+
+	created := time.Date(2023, 7, 10, 10, 0, 0, 0, time.UTC)
+
+	mock.ExpectQuery("SELECT reviews.id, reviews.rental_id, reviews.booking_id, reviews.user_id, reviews.rating, " +
+		"reviews.comment, reviews.created FROM reviews WHERE reviews.rental_id = \\$1 " +
+		"ORDER BY reviews.created DESC, reviews.id DESC LIMIT 10 OFFSET 20").
+		WithArgs(2).
+		WillReturnRows(sqlmock.NewRows(_reviewColumns).AddRow(1, 2, 4, 7, 5, "Great van", created))
+
+	page := &storage.Pagination{Limit: toPtr(10), Offset: toPtr(20)}
+
+	reviews, err := storage.NewReviewRepository(db).ListByRental(context.Background(), 2, page)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatalf("there were unfulfilled expectations: %s", err)
+	}
+
+	expectedReviews := model.Reviews{
+		{ID: 1, RentalID: 2, BookingID: 4, UserID: 7, Rating: 5, Comment: "Great van", Created: created},
+	}
+	if !cmp.Equal(reviews, expectedReviews) {
+		t.Fatalf("result expectation mismatch: expected %v, got %v", expectedReviews, reviews)
+	}
+}
@@ -0,0 +1,7 @@
+package storage
+
+// UserFilters is a filters type to be used for listing users.
+type UserFilters struct {
+	Pagination
+	Name *string
+}
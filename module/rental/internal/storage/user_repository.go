@@ -0,0 +1,188 @@
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/jackc/pgx/v5/pgconn"
+
+	model "github.com/dragonator/rental-service/module/rental/internal/model"
+)
+
+// _foreignKeyViolation is the Postgres error code raised when a foreign key constraint is violated.
+const _foreignKeyViolation = "23503"
+
+// ErrUserReferenced is returned when a user cannot be deleted because other records still refer to them.
+var ErrUserReferenced = errors.New("user is referenced by other records")
+
+var (
+	userWriteColumns = []string{
+		"first_name",
+		"last_name",
+	}
+	likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+)
+
+// UserRepository hold DB operations over user entities.
+type UserRepository struct {
+	db *sql.DB
+}
+
+// NewUserRepository is a constructor function for UserRepository.
+func NewUserRepository(db *sql.DB) *UserRepository {
+	return &UserRepository{
+		db: db,
+	}
+}
+
+// GetByID returns a single user object corresponding to the requested id.
+// If no such user exists it returns an error.
+func (ur *UserRepository) GetByID(ctx context.Context, userID int) (*model.User, error) {
+	qb := NewQueryBuilder().
+		Select().
+		Columns(userColumns...).
+		From("users").
+		Where("users.id = $1")
+
+	user, err := scanUser(ur.db.QueryRowContext(ctx, qb.String(), userID))
+	if err != nil {
+		return nil, fmt.Errorf("getting user by id: %w", err)
+	}
+
+	return user, nil
+}
+
+// List returns a list of users based on the given filters. If no results are found it returns an empty list.
+func (ur *UserRepository) List(ctx context.Context, filters *UserFilters) (model.Users, error) {
+	users := make(model.Users, 0, 10)
+
+	qb := buildUserListQuery(filters)
+
+	rows, err := ur.db.QueryContext(ctx, qb.String(), qb.Args()...)
+	if err != nil {
+		return nil, fmt.Errorf("listing users: %w", err)
+	}
+
+	defer rows.Close()
+
+	for rows.Next() {
+		user, err := scanUser(rows)
+		if err != nil {
+			return nil, fmt.Errorf("scanning user: %w", err)
+		}
+
+		users = append(users, user)
+	}
+
+	return users, nil
+}
+
+// Create inserts a new user and returns the id assigned to it.
+func (ur *UserRepository) Create(ctx context.Context, user *model.User) (int, error) {
+	qb := NewQueryBuilder().
+		Insert("users").
+		Columns(userWriteColumns...).
+		Returning("id")
+
+	var userID int
+	if err := ur.db.QueryRowContext(ctx, qb.String(), user.FirstName, user.LastName).Scan(&userID); err != nil {
+		return 0, fmt.Errorf("creating user: %w", err)
+	}
+
+	return userID, nil
+}
+
+// Update overwrites the stored user with the given one.
+// If no user matches it returns sql.ErrNoRows.
+func (ur *UserRepository) Update(ctx context.Context, user *model.User) error {
+	qb := NewQueryBuilder().
+		Update("users").
+		Columns(userWriteColumns...).
+		Where("id = $3")
+
+	if err := execAffectingRow(ctx, ur.db, qb.String(), user.FirstName, user.LastName, user.ID); err != nil {
+		return fmt.Errorf("updating user: %w", err)
+	}
+
+	return nil
+}
+
+// Delete removes the user with the given id.
+// If no user matches it returns sql.ErrNoRows. If other records still refer to the user it returns ErrUserReferenced.
+func (ur *UserRepository) Delete(ctx context.Context, userID int) error {
+	qb := NewQueryBuilder().
+		Delete("users").
+		Where("id = $1")
+
+	err := execAffectingRow(ctx, ur.db, qb.String(), userID)
+
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == _foreignKeyViolation {
+		return fmt.Errorf("deleting user: %w", ErrUserReferenced)
+	}
+	if err != nil {
+		return fmt.Errorf("deleting user: %w", err)
+	}
+
+	return nil
+}
+
+// HasRentals reports whether the user owns any rentals, including archived ones.
+func (ur *UserRepository) HasRentals(ctx context.Context, userID int) (bool, error) {
+	qb := NewQueryBuilder().
+		Select().
+		Columns("EXISTS (SELECT 1 FROM rentals WHERE rentals.user_id = users.id)").
+		From("users").
+		Where("users.id = $1")
+
+	var hasRentals bool
+	if err := ur.db.QueryRowContext(ctx, qb.String(), userID).Scan(&hasRentals); err != nil {
+		return false, fmt.Errorf("checking user rentals: %w", err)
+	}
+
+	return hasRentals, nil
+}
+
+func buildUserListQuery(f *UserFilters) *QueryBuilder {
+	qb := NewQueryBuilder().
+		Select().
+		Columns(userColumns...).
+		From("users").
+		OrderBy("users.id")
+
+	if f == nil {
+		return qb
+	}
+
+	if f.Name != nil {
+		pattern := qb.Arg("%" + likeEscaper.Replace(*f.Name) + "%")
+		qb.Where(fmt.Sprintf("users.first_name || ' ' || users.last_name ILIKE %s", pattern))
+	}
+
+	if f.Limit != nil {
+		qb.Limit(*f.Limit)
+	}
+
+	if f.Offset != nil {
+		qb.Offset(*f.Offset)
+	}
+
+	return qb
+}
+
+func scanUser(row rowScanner) (*model.User, error) {
+	user := new(model.User)
+
+	if err := row.Scan(
+		&user.ID,
+		&user.FirstName,
+		&user.LastName,
+	); err != nil {
+		return nil, err
+	}
+
+	return user, nil
+}
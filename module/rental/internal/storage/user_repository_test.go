@@ -0,0 +1,303 @@
+package storage_test
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/go-cmp/cmp"
+	"github.com/jackc/pgx/v5/pgconn"
+
+	model "github.com/dragonator/rental-service/module/rental/internal/model"
+	"github.com/dragonator/rental-service/module/rental/internal/storage"
+)
+
+var _userColumns = []string{
+	"users.id as users_id",
+	"users.first_name",
+	"users.last_name",
+}
+
+func TestUserRepository_GetByID(t *testing.T) {
+	selectQuery := "SELECT users.id as users_id, users.first_name, users.last_name FROM users WHERE users.id = \\$1"
+
+	testCases := []struct {
+		name          string
+		idParam       int
+		expectedUser  *model.User
+		expectedError error
+		mockFunc      func(mock sqlmock.Sqlmock)
+	}{
+		{
+			name:         "Valid user ID",
+			idParam:      2,
+			expectedUser: _rentals[0].User,
+			mockFunc: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(selectQuery).
+					WithArgs([]driver.Value{2}...).
+					WillReturnRows(sqlmock.NewRows(_userColumns).AddRow(userValues(_rentals[0].User)...))
+			},
+		},
+		{
+			name:          "Missing user ID",
+			idParam:       77,
+			expectedError: sql.ErrNoRows,
+			mockFunc: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(selectQuery).
+					WithArgs([]driver.Value{77}...).
+					WillReturnError(sql.ErrNoRows)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("failed to create sqlmock: %s", err)
+			}
+			defer db.Close()
+
+			repo := storage.NewUserRepository(db)
+
+			tc.mockFunc(mock)
+
+			user, err := repo.GetByID(context.Background(), tc.idParam)
+
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Fatalf("there were unfulfilled expectations: %s", err)
+			}
+
+			if !cmp.Equal(user, tc.expectedUser) {
+				t.Fatalf("result expectation mismatch: %s", cmp.Diff(user, tc.expectedUser))
+			}
+
+			if err != tc.expectedError && !errors.Is(err, tc.expectedError) {
+				t.Fatalf("error expectation mismatch: %s", cmp.Diff(err, tc.expectedError))
+			}
+		})
+	}
+}
+
+func TestUserRepository_List(t *testing.T) {
+	selectQuery := "SELECT users.id as users_id, users.first_name, users.last_name FROM users"
+
+	testCases := []struct {
+		name           string
+		filters        *storage.UserFilters
+		expectedResult model.Users
+		mockFunc       func(mock sqlmock.Sqlmock)
+	}{
+		{
+			name:           "List without filters",
+			expectedResult: model.Users{_rentals[0].User, _rentals[1].User},
+			mockFunc: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(selectQuery + " ORDER BY users.id$").
+					WillReturnRows(sqlmock.NewRows(_userColumns).
+						AddRow(userValues(_rentals[0].User)...).
+						AddRow(userValues(_rentals[1].User)...))
+			},
+		},
+		{
+			name: "List with name filter and pagination",
+			filters: &storage.UserFilters{
+				Name: toPtr("50%_off"),
+				Pagination: storage.Pagination{
+					Limit:  toPtr(3),
+					Offset: toPtr(6),
+				},
+			},
+			expectedResult: model.Users{_rentals[1].User},
+			mockFunc: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(selectQuery + " WHERE users.first_name \\|\\| ' ' \\|\\| users.last_name ILIKE \\$1 ORDER BY users.id LIMIT 3 OFFSET 6$").
+					WithArgs(`%50\%\_off%`).
+					WillReturnRows(sqlmock.NewRows(_userColumns).
+						AddRow(userValues(_rentals[1].User)...))
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("failed to create sqlmock: %s", err)
+			}
+			defer db.Close()
+
+			repo := storage.NewUserRepository(db)
+
+			tc.mockFunc(mock)
+
+			users, err := repo.List(context.Background(), tc.filters)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Fatalf("there were unfulfilled expectations: %s", err)
+			}
+
+			if !cmp.Equal(users, tc.expectedResult) {
+				t.Fatalf("result expectation mismatch: %s", cmp.Diff(users, tc.expectedResult))
+			}
+		})
+	}
+}
+
+func TestUserRepository_Create(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to create sqlmock: %s", err)
+	}
+	defer db.Close()
+
+	mock.ExpectQuery("INSERT INTO users \\(first_name, last_name\\) VALUES \\(\\$1, \\$2\\) RETURNING id").
+		WithArgs("John", "Smith").
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(6))
+
+	userID, err := storage.NewUserRepository(db).Create(context.Background(), &model.User{FirstName: "John", LastName: "Smith"})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatalf("there were unfulfilled expectations: %s", err)
+	}
+
+	if userID != 6 {
+		t.Fatalf("result expectation mismatch: expected 6, got %d", userID)
+	}
+}
+
+func TestUserRepository_Update(t *testing.T) {
+	updateQuery := "UPDATE users SET first_name = \\$1, last_name = \\$2 WHERE id = \\$3"
+
+	testCases := []struct {
+		name          string
+		affected      int64
+		expectedError error
+	}{
+		{
+			name:     "Existing user",
+			affected: 1,
+		},
+		{
+			name:          "Missing user",
+			affected:      0,
+			expectedError: sql.ErrNoRows,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("failed to create sqlmock: %s", err)
+			}
+			defer db.Close()
+
+			mock.ExpectExec(updateQuery).
+				WithArgs("John", "Smith", 2).
+				WillReturnResult(sqlmock.NewResult(0, tc.affected))
+
+			err = storage.NewUserRepository(db).Update(context.Background(), &model.User{ID: 2, FirstName: "John", LastName: "Smith"})
+
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Fatalf("there were unfulfilled expectations: %s", err)
+			}
+
+			if err != tc.expectedError && !errors.Is(err, tc.expectedError) {
+				t.Fatalf("error expectation mismatch: %s", cmp.Diff(err, tc.expectedError))
+			}
+		})
+	}
+}
+
+func TestUserRepository_Delete(t *testing.T) {
+	testCases := []struct {
+		name          string
+		affected      int64
+		execError     error
+		expectedError error
+	}{
+		{
+			name:     "Existing user",
+			affected: 1,
+		},
+		{
+			name:          "Missing user",
+			affected:      0,
+			expectedError: sql.ErrNoRows,
+		},
+		{
+			name:          "Referenced user",
+			execError:     &pgconn.PgError{Code: "23503"},
+			expectedError: storage.ErrUserReferenced,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("failed to create sqlmock: %s", err)
+			}
+			defer db.Close()
+
+			exec := mock.ExpectExec("DELETE FROM users WHERE id = \\$1").
+				WithArgs(2)
+			if tc.execError != nil {
+				exec.WillReturnError(tc.execError)
+			} else {
+				exec.WillReturnResult(sqlmock.NewResult(0, tc.affected))
+			}
+
+			err = storage.NewUserRepository(db).Delete(context.Background(), 2)
+
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Fatalf("there were unfulfilled expectations: %s", err)
+			}
+
+			if err != tc.expectedError && !errors.Is(err, tc.expectedError) {
+				t.Fatalf("error expectation mismatch: %s", cmp.Diff(err, tc.expectedError))
+			}
+		})
+	}
+}
+
+func TestUserRepository_HasRentals(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to create sqlmock: %s", err)
+	}
+	defer db.Close()
+
+	mock.ExpectQuery("SELECT EXISTS \\(SELECT 1 FROM rentals WHERE rentals.user_id = users.id\\) FROM users WHERE users.id = \\$1").
+		WithArgs(2).
+		WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(true))
+
+	hasRentals, err := storage.NewUserRepository(db).HasRentals(context.Background(), 2)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatalf("there were unfulfilled expectations: %s", err)
+	}
+
+	if !hasRentals {
+		t.Fatalf("result expectation mismatch: expected true, got false")
+	}
+}
+
+func userValues(user *model.User) []driver.Value {
+	return []driver.Value{
+		user.ID,
+		user.FirstName,
+		user.LastName,
+	}
+}
@@ -0,0 +1,186 @@
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	model "github.com/dragonator/rental-service/module/rental/internal/model"
+)
+
+var wishlistColumns = []string{
+	"wishlists.id",
+	"wishlists.user_id",
+	"wishlists.name",
+	"(SELECT COUNT(*) FROM wishlist_rentals WHERE wishlist_rentals.wishlist_id = wishlists.id)",
+	"wishlists.created",
+}
+
+// WishlistRepository hold DB operations over wishlist entities.
+type WishlistRepository struct {
+	db *sql.DB
+}
+
+// NewWishlistRepository is a constructor function for WishlistRepository.
+func NewWishlistRepository(db *sql.DB) *WishlistRepository {
+	return &WishlistRepository{
+		db: db,
+	}
+}
+
+// GetByID returns a single wishlist object corresponding to the requested id.
+// If no such wishlist exists it returns an error.
+func (wr *WishlistRepository) GetByID(ctx context.Context, wishlistID int) (*model.Wishlist, error) {
+	qb := NewQueryBuilder().
+		Select().
+		Columns(wishlistColumns...).
+		From("wishlists").
+		Where("wishlists.id = $1")
+
+	wishlist, err := scanWishlist(wr.db.QueryRowContext(ctx, qb.String(), wishlistID))
+	if err != nil {
+		return nil, fmt.Errorf("getting wishlist by id: %w", err)
+	}
+
+	return wishlist, nil
+}
+
+// ListByUser returns the wishlists of the user with the given id ordered by id.
+func (wr *WishlistRepository) ListByUser(ctx context.Context, userID int) (model.Wishlists, error) {
+	qb := NewQueryBuilder().
+		Select().
+		Columns(wishlistColumns...).
+		From("wishlists").
+		Where("wishlists.user_id = $1").
+		OrderBy("wishlists.id")
+
+	rows, err := wr.db.QueryContext(ctx, qb.String(), userID)
+	if err != nil {
+		return nil, fmt.Errorf("listing wishlists: %w", err)
+	}
+
+	defer rows.Close()
+
+	wishlists := make(model.Wishlists, 0, 4)
+
+	for rows.Next() {
+		wishlist, err := scanWishlist(rows)
+		if err != nil {
+			return nil, fmt.Errorf("scanning wishlist: %w", err)
+		}
+
+		wishlists = append(wishlists, wishlist)
+	}
+
+	return wishlists, nil
+}
+
+// Create inserts a new wishlist and returns the id assigned to it.
+func (wr *WishlistRepository) Create(ctx context.Context, wishlist *model.Wishlist) (int, error) {
+	qb := NewQueryBuilder().
+		Insert("wishlists").
+		Columns("user_id", "name", "created").
+		Returning("id")
+
+	var wishlistID int
+	if err := wr.db.QueryRowContext(ctx, qb.String(), wishlist.UserID, wishlist.Name, time.Now().UTC()).Scan(&wishlistID); err != nil {
+		return 0, fmt.Errorf("creating wishlist: %w", err)
+	}
+
+	return wishlistID, nil
+}
+
+// Delete removes the wishlist with the given id along with its rentals.
+// If no wishlist matches it returns sql.ErrNoRows.
+func (wr *WishlistRepository) Delete(ctx context.Context, wishlistID int) (err error) {
+	tx, err := wr.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("deleting wishlist: %w", err)
+	}
+
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback()
+		}
+	}()
+
+	deleteRentals := NewQueryBuilder().
+		Delete("wishlist_rentals").
+		Where("wishlist_id = $1")
+
+	if _, err = tx.ExecContext(ctx, deleteRentals.String(), wishlistID); err != nil {
+		return fmt.Errorf("deleting wishlist rentals: %w", err)
+	}
+
+	deleteWishlist := NewQueryBuilder().
+		Delete("wishlists").
+		Where("id = $1")
+
+	result, err := tx.ExecContext(ctx, deleteWishlist.String(), wishlistID)
+	if err != nil {
+		return fmt.Errorf("deleting wishlist: %w", err)
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("deleting wishlist: %w", err)
+	}
+
+	if affected == 0 {
+		err = sql.ErrNoRows
+		return fmt.Errorf("deleting wishlist: %w", err)
+	}
+
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("deleting wishlist: %w", err)
+	}
+
+	return nil
+}
+
+// AddRental saves the rental with the given id into the wishlist. Adding a rental which
+// is already in the wishlist has no effect.
+func (wr *WishlistRepository) AddRental(ctx context.Context, wishlistID, rentalID int) error {
+	qb := NewQueryBuilder().
+		Insert("wishlist_rentals").
+		Columns("wishlist_id", "rental_id", "created").
+		OnConflict("(wishlist_id, rental_id) DO NOTHING")
+
+	if _, err := wr.db.ExecContext(ctx, qb.String(), wishlistID, rentalID, time.Now().UTC()); err != nil {
+		return fmt.Errorf("adding rental to wishlist: %w", err)
+	}
+
+	return nil
+}
+
+// RemoveRental removes the rental with the given id from the wishlist.
+// If the rental is not in the wishlist it returns sql.ErrNoRows.
+func (wr *WishlistRepository) RemoveRental(ctx context.Context, wishlistID, rentalID int) error {
+	qb := NewQueryBuilder().
+		Delete("wishlist_rentals").
+		Where("wishlist_id = $1").
+		Where("rental_id = $2")
+
+	if err := execAffectingRow(ctx, wr.db, qb.String(), wishlistID, rentalID); err != nil {
+		return fmt.Errorf("removing rental from wishlist: %w", err)
+	}
+
+	return nil
+}
+
+func scanWishlist(row rowScanner) (*model.Wishlist, error) {
+	wishlist := new(model.Wishlist)
+
+	if err := row.Scan(
+		&wishlist.ID,
+		&wishlist.UserID,
+		&wishlist.Name,
+		&wishlist.RentalCount,
+		&wishlist.Created,
+	); err != nil {
+		return nil, err
+	}
+
+	return wishlist, nil
+}
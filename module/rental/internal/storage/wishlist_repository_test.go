@@ -0,0 +1,75 @@
+package storage_test
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+
+	"github.com/dragonator/rental-service/module/rental/internal/storage"
+)
+
+func TestWishlistRepository_AddRental(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to create sqlmock: %s", err)
+	}
+	defer db.Close()
+
+	mock.ExpectExec("INSERT INTO wishlist_rentals \\(wishlist_id, rental_id, created\\) VALUES \\(\\$1, \\$2, \\$3\\) "+
+		"ON CONFLICT \\(wishlist_id, rental_id\\) DO NOTHING").
+		WithArgs(1, 2, sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+
+	if err := storage.NewWishlistRepository(db).AddRental(context.Background(), 1, 2); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatalf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestWishlistRepository_RemoveRental(t *testing.T) {
+	testCases := []struct {
+		name          string
+		affected      int64
+		expectedError error
+	}{
+		{
+			name:     "Saved rental",
+			affected: 1,
+		},
+		{
+			name:          "Rental not in wishlist",
+			affected:      0,
+			expectedError: sql.ErrNoRows,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("failed to create sqlmock: %s", err)
+			}
+			defer db.Close()
+
+			mock.ExpectExec("DELETE FROM wishlist_rentals WHERE wishlist_id = \\$1 AND rental_id = \\$2").
+				WithArgs(1, 2).
+				WillReturnResult(sqlmock.NewResult(0, tc.affected))
+
+			err = storage.NewWishlistRepository(db).RemoveRental(context.Background(), 1, 2)
+
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Fatalf("there were unfulfilled expectations: %s", err)
+			}
+
+			if !errors.Is(err, tc.expectedError) {
+				t.Fatalf("error expectation mismatch: expected %v, got %v", tc.expectedError, err)
+			}
+		})
+	}
+}
@@ -3,10 +3,28 @@ package rental
 import (
 	"fmt"
 
+	"github.com/dragonator/rental-service/module/rental/internal/blob"
 	"github.com/dragonator/rental-service/module/rental/internal/db"
 	"github.com/dragonator/rental-service/module/rental/internal/http/handler"
 	"github.com/dragonator/rental-service/module/rental/internal/http/service"
+	"github.com/dragonator/rental-service/module/rental/internal/operation/amenitymanaging"
+	"github.com/dragonator/rental-service/module/rental/internal/operation/bookingfetching"
+	"github.com/dragonator/rental-service/module/rental/internal/operation/bookingmanaging"
+	"github.com/dragonator/rental-service/module/rental/internal/operation/calendarmanaging"
+	"github.com/dragonator/rental-service/module/rental/internal/operation/conversationmanaging"
+	"github.com/dragonator/rental-service/module/rental/internal/operation/imagemanaging"
+	"github.com/dragonator/rental-service/module/rental/internal/operation/pricingrulemanaging"
+	"github.com/dragonator/rental-service/module/rental/internal/operation/rentalarchiving"
+	"github.com/dragonator/rental-service/module/rental/internal/operation/rentalcreating"
 	"github.com/dragonator/rental-service/module/rental/internal/operation/rentalfetching"
+	"github.com/dragonator/rental-service/module/rental/internal/operation/rentalquoting"
+	"github.com/dragonator/rental-service/module/rental/internal/operation/rentalupdating"
+	"github.com/dragonator/rental-service/module/rental/internal/operation/reviewmanaging"
+	"github.com/dragonator/rental-service/module/rental/internal/operation/userfetching"
+	"github.com/dragonator/rental-service/module/rental/internal/operation/usermanaging"
+	"github.com/dragonator/rental-service/module/rental/internal/operation/wishlistmanaging"
+	"github.com/dragonator/rental-service/module/rental/internal/payment"
+	"github.com/dragonator/rental-service/module/rental/internal/pricing"
 	"github.com/dragonator/rental-service/module/rental/internal/storage"
 	"github.com/dragonator/rental-service/pkg/config"
 	"github.com/dragonator/rental-service/pkg/logger"
@@ -31,9 +49,67 @@ func NewRentalModule(config *config.Config, logger *logger.Logger) (*RentalModul
 	}
 
 	rentalStore := storage.NewRentalRepository(config, db)
+	userStore := storage.NewUserRepository(db)
+	bookingStore := storage.NewBookingRepository(db)
+	calendarStore := storage.NewCalendarRepository(db)
+	pricingRuleStore := storage.NewPricingRuleRepository(db)
+	reviewStore := storage.NewReviewRepository(db)
+	imageStore := storage.NewRentalImageRepository(db)
+	wishlistStore := storage.NewWishlistRepository(db)
+	conversationStore := storage.NewConversationRepository(db)
+	paymentStore := storage.NewPaymentRepository(db)
+	amenityStore := storage.NewAmenityRepository(db)
 	rentalFetchingOp := rentalfetching.NewOperation(rentalStore)
-	rentalHandler := handler.NewRentalHandler(rentalFetchingOp)
-	router := service.NewRouter(rentalHandler)
+	rentalCreatingOp := rentalcreating.NewOperation(rentalStore, userStore)
+	rentalUpdatingOp := rentalupdating.NewOperation(rentalStore, userStore)
+	rentalArchivingOp := rentalarchiving.NewOperation(rentalStore)
+	rentalHandler := handler.NewRentalHandler(rentalFetchingOp, rentalCreatingOp, rentalUpdatingOp, rentalArchivingOp)
+	userFetchingOp := userfetching.NewOperation(userStore)
+	userManagingOp := usermanaging.NewOperation(userStore)
+	userHandler := handler.NewUserHandler(userFetchingOp, userManagingOp, rentalFetchingOp)
+	calculator := pricing.NewCalculator(config)
+	bookingFetchingOp := bookingfetching.NewOperation(bookingStore, paymentStore)
+	bookingManagingOp := bookingmanaging.NewOperation(
+		bookingStore,
+		rentalStore,
+		userStore,
+		calendarStore,
+		pricingRuleStore,
+		calculator,
+		paymentStore,
+		payment.NewFake(),
+	)
+	bookingHandler := handler.NewBookingHandler(bookingFetchingOp, bookingManagingOp)
+	calendarManagingOp := calendarmanaging.NewOperation(calendarStore, bookingStore, rentalStore)
+	calendarHandler := handler.NewCalendarHandler(calendarManagingOp)
+	rentalQuotingOp := rentalquoting.NewOperation(rentalStore, pricingRuleStore, calculator)
+	quoteHandler := handler.NewQuoteHandler(rentalQuotingOp)
+	pricingRuleManagingOp := pricingrulemanaging.NewOperation(pricingRuleStore, rentalStore)
+	pricingRuleHandler := handler.NewPricingRuleHandler(pricingRuleManagingOp)
+	reviewManagingOp := reviewmanaging.NewOperation(reviewStore, bookingStore, rentalStore, paymentStore)
+	reviewHandler := handler.NewReviewHandler(reviewManagingOp)
+	blobStore := blob.NewFileSystem(config.Uploads.Dir, config.Uploads.PublicURL)
+	imageManagingOp := imagemanaging.NewOperation(imageStore, rentalStore, blobStore, config.Uploads.MaxSize)
+	imageHandler := handler.NewImageHandler(imageManagingOp, config.Uploads.MaxSize)
+	wishlistManagingOp := wishlistmanaging.NewOperation(wishlistStore, userStore, rentalStore)
+	wishlistHandler := handler.NewWishlistHandler(wishlistManagingOp)
+	conversationManagingOp := conversationmanaging.NewOperation(conversationStore, rentalStore, userStore)
+	conversationHandler := handler.NewConversationHandler(conversationManagingOp)
+	amenityManagingOp := amenitymanaging.NewOperation(amenityStore, rentalStore)
+	amenityHandler := handler.NewAmenityHandler(amenityManagingOp)
+	router := service.NewRouter(config, &service.Handlers{
+		Rental:       rentalHandler,
+		User:         userHandler,
+		Booking:      bookingHandler,
+		Calendar:     calendarHandler,
+		Quote:        quoteHandler,
+		PricingRule:  pricingRuleHandler,
+		Review:       reviewHandler,
+		Image:        imageHandler,
+		Wishlist:     wishlistHandler,
+		Conversation: conversationHandler,
+		Amenity:      amenityHandler,
+	})
 
 	rentalService, err := service.New(config, logger, router)
 	if err != nil {
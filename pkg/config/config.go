@@ -13,8 +13,11 @@ var _errUndefinedEnvVar = errors.New("undefined environment variable")
 // Config hold the service config.
 type Config struct {
 	Database            *Database
+	Pricing             *Pricing
+	Uploads             *Uploads
 	ServerPort          string
 	LoggerLevel         string
+	AdminToken          string
 	NearThresholdRadius int
 }
 
@@ -25,6 +28,16 @@ func New() (*Config, error) {
 		return nil, err
 	}
 
+	pricing, err := NewPricing()
+	if err != nil {
+		return nil, err
+	}
+
+	uploads, err := NewUploads()
+	if err != nil {
+		return nil, err
+	}
+
 	serverPort, defined := os.LookupEnv("SERVER_PORT")
 	if !defined {
 		return nil, fmt.Errorf("%w: SERVER_PORT", _errUndefinedEnvVar)
@@ -35,6 +48,11 @@ func New() (*Config, error) {
 		return nil, fmt.Errorf("%w: LOGGER_LEVEL", _errUndefinedEnvVar)
 	}
 
+	adminToken, defined := os.LookupEnv("ADMIN_TOKEN")
+	if !defined {
+		return nil, fmt.Errorf("%w: ADMIN_TOKEN", _errUndefinedEnvVar)
+	}
+
 	nearThresholdRadius, defined := os.LookupEnv("NEAR_THRESHOLD_RADIUS_IN_MILES")
 	if !defined {
 		return nil, fmt.Errorf("%w: NEAR_THRESHOLD_RADIUS_IN_MILES", _errUndefinedEnvVar)
@@ -47,8 +65,11 @@ func New() (*Config, error) {
 
 	return &Config{
 		Database:            db,
+		Pricing:             pricing,
+		Uploads:             uploads,
 		ServerPort:          serverPort,
 		LoggerLevel:         loggerLevel,
+		AdminToken:          adminToken,
 		NearThresholdRadius: nearThresholdRadiusInMiles,
 	}, nil
 }
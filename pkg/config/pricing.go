@@ -0,0 +1,50 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+)
+
+// Pricing is a struct containing the fees and taxes added to the price of every stay.
+type Pricing struct {
+	CleaningFee       int64
+	ServiceFeePercent float64
+	TaxPercent        float64
+}
+
+// NewPricing is a constructor function for pricing config.
+func NewPricing() (*Pricing, error) {
+	cleaningFee, defined := os.LookupEnv("PRICING_CLEANING_FEE")
+	if !defined {
+		return nil, fmt.Errorf("%w: PRICING_CLEANING_FEE", _errUndefinedEnvVar)
+	}
+
+	serviceFeePercent, defined := os.LookupEnv("PRICING_SERVICE_FEE_PERCENT")
+	if !defined {
+		return nil, fmt.Errorf("%w: PRICING_SERVICE_FEE_PERCENT", _errUndefinedEnvVar)
+	}
+
+	taxPercent, defined := os.LookupEnv("PRICING_TAX_PERCENT")
+	if !defined {
+		return nil, fmt.Errorf("%w: PRICING_TAX_PERCENT", _errUndefinedEnvVar)
+	}
+
+	pricing := new(Pricing)
+
+	var err error
+
+	if pricing.CleaningFee, err = strconv.ParseInt(cleaningFee, 10, 64); err != nil {
+		return nil, fmt.Errorf("invalid value for PRICING_CLEANING_FEE: %w", err)
+	}
+
+	if pricing.ServiceFeePercent, err = strconv.ParseFloat(serviceFeePercent, 64); err != nil {
+		return nil, fmt.Errorf("invalid value for PRICING_SERVICE_FEE_PERCENT: %w", err)
+	}
+
+	if pricing.TaxPercent, err = strconv.ParseFloat(taxPercent, 64); err != nil {
+		return nil, fmt.Errorf("invalid value for PRICING_TAX_PERCENT: %w", err)
+	}
+
+	return pricing, nil
+}
@@ -0,0 +1,45 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+)
+
+// Uploads is a struct containing the configuration of uploaded files.
+type Uploads struct {
+	Dir       string
+	PublicURL string
+	MaxSize   int64
+}
+
+// NewUploads is a constructor function for uploads config.
+func NewUploads() (*Uploads, error) {
+	dir, defined := os.LookupEnv("UPLOADS_DIR")
+	if !defined {
+		return nil, fmt.Errorf("%w: UPLOADS_DIR", _errUndefinedEnvVar)
+	}
+
+	publicURL, defined := os.LookupEnv("UPLOADS_PUBLIC_URL")
+	if !defined {
+		return nil, fmt.Errorf("%w: UPLOADS_PUBLIC_URL", _errUndefinedEnvVar)
+	}
+
+	maxSize, defined := os.LookupEnv("UPLOADS_MAX_SIZE_BYTES")
+	if !defined {
+		return nil, fmt.Errorf("%w: UPLOADS_MAX_SIZE_BYTES", _errUndefinedEnvVar)
+	}
+
+	uploads := &Uploads{
+		Dir:       dir,
+		PublicURL: publicURL,
+	}
+
+	var err error
+
+	if uploads.MaxSize, err = strconv.ParseInt(maxSize, 10, 64); err != nil || uploads.MaxSize <= 0 {
+		return nil, fmt.Errorf("invalid value for UPLOADS_MAX_SIZE_BYTES: %s", maxSize)
+	}
+
+	return uploads, nil
+}
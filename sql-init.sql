@@ -6,7 +6,7 @@ CREATE TABLE IF NOT EXISTS users (
 
 CREATE TABLE IF NOT EXISTS rentals (
     id SERIAL PRIMARY KEY,
-    user_id integer,
+    user_id integer REFERENCES users (id),
     name text,
     type text,
     description text,
@@ -20,13 +20,210 @@ CREATE TABLE IF NOT EXISTS rentals (
     vehicle_model text,
     vehicle_year integer,
     vehicle_length numeric(4,2),
-    created timestamp with time zone,
-    updated timestamp with time zone,
+    created timestamp with time zone NOT NULL DEFAULT NOW(),
+    updated timestamp with time zone NOT NULL DEFAULT NOW(),
     lat double precision,
     lng double precision,
-    primary_image_url text
+    primary_image_url text,
+    cancellation_policy text NOT NULL DEFAULT 'moderate' CHECK (cancellation_policy IN ('flexible', 'moderate', 'strict')),
+    rating_average numeric(3,2),
+    rating_count integer NOT NULL DEFAULT 0,
+    deleted_at timestamp with time zone,
+    search_vector tsvector
 );
 
+CREATE INDEX IF NOT EXISTS rentals_search_vector_idx ON rentals USING GIN (search_vector);
+
+CREATE OR REPLACE FUNCTION rentals_search_vector_update() RETURNS trigger AS $$
+BEGIN
+    NEW.search_vector :=
+        setweight(to_tsvector('english', coalesce(NEW.name, '')), 'A') ||
+        setweight(to_tsvector('english', coalesce(NEW.vehicle_make, '') || ' ' || coalesce(NEW.vehicle_model, '')), 'B') ||
+        setweight(to_tsvector('english', coalesce(NEW.description, '')), 'C');
+    RETURN NEW;
+END
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS rentals_search_vector_update ON rentals;
+CREATE TRIGGER rentals_search_vector_update
+    BEFORE INSERT OR UPDATE OF name, description, vehicle_make, vehicle_model ON rentals
+    FOR EACH ROW EXECUTE PROCEDURE rentals_search_vector_update();
+
+-- Rentals are looked up by their location with PostGIS when it is installed; otherwise the
+-- service falls back to computing distances from lat and lng.
+DO $$
+BEGIN
+    IF EXISTS (SELECT 1 FROM pg_available_extensions WHERE name = 'postgis') THEN
+        CREATE EXTENSION IF NOT EXISTS postgis;
+
+        ALTER TABLE rentals ADD COLUMN IF NOT EXISTS location geography(Point, 4326);
+        CREATE INDEX IF NOT EXISTS rentals_location_idx ON rentals USING GIST (location);
+
+        CREATE OR REPLACE FUNCTION rentals_location_update() RETURNS trigger AS $fn$
+        BEGIN
+            NEW.location := CASE
+                WHEN NEW.lat IS NULL OR NEW.lng IS NULL THEN NULL
+                ELSE ST_SetSRID(ST_MakePoint(NEW.lng, NEW.lat), 4326)::geography
+            END;
+            RETURN NEW;
+        END
+        $fn$ LANGUAGE plpgsql;
+
+        DROP TRIGGER IF EXISTS rentals_location_update ON rentals;
+        CREATE TRIGGER rentals_location_update
+            BEFORE INSERT OR UPDATE OF lat, lng ON rentals
+            FOR EACH ROW EXECUTE PROCEDURE rentals_location_update();
+
+        UPDATE rentals SET location = ST_SetSRID(ST_MakePoint(lng, lat), 4326)::geography
+            WHERE location IS NULL AND lat IS NOT NULL AND lng IS NOT NULL;
+    END IF;
+END
+$$;
+
+CREATE EXTENSION IF NOT EXISTS btree_gist;
+
+CREATE TABLE IF NOT EXISTS bookings (
+    id SERIAL PRIMARY KEY,
+    rental_id integer NOT NULL,
+    user_id integer NOT NULL REFERENCES users (id),
+    start_date date NOT NULL,
+    end_date date NOT NULL,
+    status text NOT NULL DEFAULT 'confirmed',
+    cancellation_policy text NOT NULL DEFAULT 'moderate',
+    created timestamp with time zone NOT NULL DEFAULT NOW(),
+    updated timestamp with time zone NOT NULL DEFAULT NOW(),
+    cancelled_at timestamp with time zone,
+    CHECK (end_date > start_date),
+    EXCLUDE USING gist (rental_id WITH =, daterange(start_date, end_date) WITH &&) WHERE (status = 'confirmed')
+);
+
+CREATE TABLE IF NOT EXISTS rental_blocked_days (
+    rental_id integer NOT NULL,
+    day date NOT NULL,
+    PRIMARY KEY (rental_id, day)
+);
+
+CREATE TABLE IF NOT EXISTS rental_stay_rules (
+    rental_id integer PRIMARY KEY,
+    min_nights integer NOT NULL DEFAULT 1 CHECK (min_nights >= 1),
+    max_nights integer CHECK (max_nights >= min_nights)
+);
+
+CREATE TABLE IF NOT EXISTS pricing_rules (
+    id SERIAL PRIMARY KEY,
+    rental_id integer NOT NULL,
+    type text NOT NULL,
+    start_date date,
+    end_date date,
+    price_per_day bigint,
+    percent numeric(5,2),
+    CHECK (end_date > start_date)
+);
+
+CREATE INDEX IF NOT EXISTS pricing_rules_rental_id_idx ON pricing_rules (rental_id);
+
+CREATE TABLE IF NOT EXISTS reviews (
+    id SERIAL PRIMARY KEY,
+    rental_id integer NOT NULL,
+    booking_id integer NOT NULL UNIQUE,
+    user_id integer NOT NULL REFERENCES users (id),
+    rating smallint NOT NULL CHECK (rating BETWEEN 1 AND 5),
+    comment text NOT NULL DEFAULT '',
+    created timestamp with time zone NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS reviews_rental_id_created_idx ON reviews (rental_id, created DESC);
+
+CREATE TABLE IF NOT EXISTS rental_images (
+    id SERIAL PRIMARY KEY,
+    rental_id integer NOT NULL,
+    url text NOT NULL,
+    caption text NOT NULL DEFAULT '',
+    position integer NOT NULL DEFAULT 0,
+    storage_key text NOT NULL DEFAULT '',
+    thumbnails jsonb,
+    created timestamp with time zone NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS rental_images_rental_id_position_idx ON rental_images (rental_id, position);
+
+CREATE TABLE IF NOT EXISTS wishlists (
+    id SERIAL PRIMARY KEY,
+    user_id integer NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    name text NOT NULL,
+    created timestamp with time zone NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS wishlists_user_id_idx ON wishlists (user_id);
+
+CREATE TABLE IF NOT EXISTS wishlist_rentals (
+    wishlist_id integer NOT NULL REFERENCES wishlists (id) ON DELETE CASCADE,
+    rental_id integer NOT NULL,
+    created timestamp with time zone NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (wishlist_id, rental_id)
+);
+
+CREATE TABLE IF NOT EXISTS amenities (
+    id SERIAL PRIMARY KEY,
+    slug text NOT NULL UNIQUE,
+    name text NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS rental_amenities (
+    rental_id integer NOT NULL,
+    amenity_id integer NOT NULL,
+    PRIMARY KEY (rental_id, amenity_id)
+);
+
+CREATE INDEX IF NOT EXISTS rental_amenities_amenity_id_idx ON rental_amenities (amenity_id);
+
+CREATE TABLE IF NOT EXISTS conversations (
+    id SERIAL PRIMARY KEY,
+    rental_id integer NOT NULL,
+    renter_id integer NOT NULL REFERENCES users (id),
+    owner_id integer NOT NULL REFERENCES users (id),
+    renter_last_read_id integer NOT NULL DEFAULT 0,
+    owner_last_read_id integer NOT NULL DEFAULT 0,
+    last_message_at timestamp with time zone NOT NULL DEFAULT NOW(),
+    created timestamp with time zone NOT NULL DEFAULT NOW(),
+    UNIQUE (rental_id, renter_id)
+);
+
+CREATE INDEX IF NOT EXISTS conversations_renter_id_idx ON conversations (renter_id);
+CREATE INDEX IF NOT EXISTS conversations_owner_id_idx ON conversations (owner_id);
+
+CREATE TABLE IF NOT EXISTS messages (
+    id SERIAL PRIMARY KEY,
+    conversation_id integer NOT NULL,
+    sender_id integer NOT NULL REFERENCES users (id),
+    body text NOT NULL,
+    created timestamp with time zone NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS messages_conversation_id_id_idx ON messages (conversation_id, id);
+
+CREATE TABLE IF NOT EXISTS payment_intents (
+    id SERIAL PRIMARY KEY,
+    booking_id integer NOT NULL UNIQUE,
+    amount bigint NOT NULL,
+    refunded_amount bigint NOT NULL DEFAULT 0,
+    status text NOT NULL,
+    provider_ref text NOT NULL DEFAULT '',
+    created timestamp with time zone NOT NULL DEFAULT NOW(),
+    updated timestamp with time zone NOT NULL DEFAULT NOW()
+);
+
+CREATE TABLE IF NOT EXISTS payment_transitions (
+    id SERIAL PRIMARY KEY,
+    payment_intent_id integer NOT NULL,
+    from_status text,
+    to_status text NOT NULL,
+    amount bigint NOT NULL DEFAULT 0,
+    created timestamp with time zone NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS payment_transitions_payment_intent_id_idx ON payment_transitions (payment_intent_id);
+
 INSERT INTO "users"("id", "first_name", "last_name")
 VALUES
     (1, 'John', 'Smith'),
@@ -36,6 +233,8 @@ VALUES
     (5, 'Ben', 'Reynard')
 ;
 
+SELECT setval('users_id_seq', (SELECT MAX(id) FROM users));
+
 INSERT INTO "rentals"("user_id", "name","type","description","sleeps","price_per_day","home_city","home_state","home_zip","home_country","vehicle_make","vehicle_model","vehicle_year","vehicle_length","created","updated","lat","lng","primary_image_url")
 VALUES
 (1, E'\'Abaco\' VW Bay Window: Westfalia Pop-top',E'camper-van',E'ultrices consectetur torquent posuere phasellus urna faucibus convallis fusce sem felis malesuada luctus diam hendrerit fermentum ante nisl potenti nam laoreet netus est erat mi',4,16900,E'Costa Mesa',E'CA',E'92627',E'US',E'Volkswagen',E'Bay Window',1978,15,E'2021-11-29 22:42:06.478595+00',E'2021-11-29 22:42:06.478595+00',33.64,-117.93,E'https://res.cloudinary.com/outdoorsy/image/upload/v1528586451/p/rentals/4447/images/yd7txtw4hnkjvklg8edg.jpg'),
@@ -67,4 +266,20 @@ VALUES
 (2, E'Coya | Van-gelina Jolie',E'camper-van',E'lacus cras molestie nam dapibus ullamcorper massa ultricies bibendum lectus auctor nisi ridiculus ultricies tristique curabitur diam feugiat erat inceptos sapien vivamus parturient sem nibh',2,20000,E'Seattle',E'WA',E'98116',E'US',E'Ford',E'Transit',2019,20,E'2021-11-29 22:42:06.478595+00',E'2021-11-29 22:42:06.478595+00',47.56,-122.39,E'https://res.cloudinary.com/outdoorsy/image/upload/v1582091293/p/rentals/153401/images/kaqt2b6n6sm1xnmvbi5w.jpg'),
 (3, E'sCAMPer X',E'camper-van',E'ac tellus phasellus ultrices nostra eros aenean metus ridiculus adipiscing habitant nulla cubilia tortor rhoncus quisque sem ultrices varius massa mollis congue praesent nam ante',4,17500,E'Atlanta',E'GA',E'30310',E'US',E'Ram',E'Promaster',2020,19,E'2021-11-29 22:42:06.478595+00',E'2021-11-29 22:42:06.478595+00',33.73,-84.41,E'https://res.cloudinary.com/outdoorsy/image/upload/v1589910541/p/rentals/156152/images/jvyvtqoeljadoizjjzag.jpg'),
 (4, E'2015 Dodge Sprinter Van',E'camper-van',E'pretium non litora lobortis pharetra elit sociosqu platea nostra interdum odio vestibulum tincidunt mi blandit convallis pellentesque tempor viverra fermentum ultricies nunc egestas id arcu',2,17000,E'Silverthorne',E'CO',E'80498',E'US',E'Dodge',E'Sprinter Van',2015,20,E'2021-11-29 22:42:06.478595+00',E'2021-11-29 22:42:06.478595+00',39.62,-106.09,E'https://res.cloudinary.com/outdoorsy/image/upload/v1588550855/p/rentals/162781/images/az0xp8wbdto4pjzlkyh3.jpg'),
-(5, E'The New Adventures of Pearl - 2014 Nissan NV2500 High Top',E'camper-van',E'malesuada eget conubia porta sollicitudin urna ad aenean lacus vulputate parturient vulputate suspendisse sit parturient ante mauris maecenas dignissim donec eget adipiscing dui luctus eget',2,18900,E'Denver',E'CO',E'80222',E'US',E'Nissan',E'NV2500',2014,20,E'2021-11-29 22:42:06.478595+00',E'2021-11-29 22:42:06.478595+00',39.67,-104.92,E'https://res.cloudinary.com/outdoorsy/image/upload/v1590500837/undefined/rentals/164961/images/t3nkxdl0ua8g6gp1idcm.jpg');
\ No newline at end of file
+(5, E'The New Adventures of Pearl - 2014 Nissan NV2500 High Top',E'camper-van',E'malesuada eget conubia porta sollicitudin urna ad aenean lacus vulputate parturient vulputate suspendisse sit parturient ante mauris maecenas dignissim donec eget adipiscing dui luctus eget',2,18900,E'Denver',E'CO',E'80222',E'US',E'Nissan',E'NV2500',2014,20,E'2021-11-29 22:42:06.478595+00',E'2021-11-29 22:42:06.478595+00',39.67,-104.92,E'https://res.cloudinary.com/outdoorsy/image/upload/v1590500837/undefined/rentals/164961/images/t3nkxdl0ua8g6gp1idcm.jpg');
+
+INSERT INTO "rental_images"("rental_id", "url", "position")
+SELECT id, primary_image_url, 0 FROM rentals WHERE primary_image_url <> '';
+
+INSERT INTO "amenities"("slug", "name")
+VALUES
+(E'shower',E'Shower'),
+(E'toilet',E'Toilet'),
+(E'kitchen',E'Kitchen'),
+(E'solar',E'Solar panels'),
+(E'air_conditioning',E'Air conditioning'),
+(E'heater',E'Heater'),
+(E'pet_friendly',E'Pet friendly'),
+(E'wifi',E'Wi-Fi'),
+(E'generator',E'Generator'),
+(E'bike_rack',E'Bike rack');